@@ -1,26 +1,133 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"financehub/backtest"
+	"financehub/bonds"
+	"financehub/calculators"
+	"financehub/importer"
 	"financehub/models"
+	"financehub/planning"
+	"financehub/portfolio"
+	"financehub/risk"
 	"financehub/services"
+	"financehub/trading"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // App struct
 type App struct {
-	ctx           context.Context
-	topicsService *services.TopicsService
+	ctx               context.Context
+	topicsService     *services.TopicsService
+	budgetService     *services.BudgetService
+	portfolioService  *services.PortfolioService
+	riskService       *services.RiskService
+	allocationService *services.AllocationService
+	actionService     *services.CorporateActionService
+	paperService      *services.PaperTradingService
+	backtestService   *services.BacktestService
+	timeSeries        *services.TimeSeriesStore
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
+	actionService := newCorporateActionService()
+	timeSeries := newTimeSeriesStore()
 	return &App{
-		topicsService: services.NewTopicsService(),
+		// Replaced by the Wails context on startup, which is cancelled when the app closes
+		ctx:               context.Background(),
+		topicsService:     services.NewTopicsService(),
+		budgetService:     newBudgetService(),
+		portfolioService:  services.NewPortfolioService(timeSeries, actionService),
+		riskService:       services.NewRiskService(timeSeries),
+		allocationService: newAllocationService(),
+		actionService:     actionService,
+		paperService:      newPaperTradingService(),
+		backtestService:   services.NewBacktestService(timeSeries),
+		timeSeries:        timeSeries,
+	}
+}
+
+// newTimeSeriesStore keeps price history in the user's config directory,
+// or in memory if the directory is unavailable
+func newTimeSeriesStore() *services.TimeSeriesStore {
+	dir := ""
+	if configDir, err := os.UserConfigDir(); err == nil {
+		dir = filepath.Join(configDir, "FinanceHub", "timeseries")
+	}
+	return services.NewTimeSeriesStore(dir, services.NewAlphaVantageService(), services.NewCoinGeckoService())
+}
+
+// newBudgetService loads budget data from the user's config directory,
+// falling back to an in-memory budget if it cannot be loaded
+func newBudgetService() *services.BudgetService {
+	path := ""
+	if configDir, err := os.UserConfigDir(); err == nil {
+		path = filepath.Join(configDir, "FinanceHub", "budget.json")
+	}
+
+	budget, err := services.NewBudgetService(path)
+	if err != nil {
+		slog.Warn("Budget data unavailable, changes will not be saved", "error", err)
+		budget, _ = services.NewBudgetService("")
+	}
+	return budget
+}
+
+// newAllocationService loads allocation targets from the user's config directory,
+// falling back to in-memory targets if they cannot be loaded
+func newAllocationService() *services.AllocationService {
+	path := ""
+	if configDir, err := os.UserConfigDir(); err == nil {
+		path = filepath.Join(configDir, "FinanceHub", "allocation.json")
+	}
+
+	allocation, err := services.NewAllocationService(path, services.NewAlphaVantageService())
+	if err != nil {
+		slog.Warn("Allocation data unavailable, changes will not be saved", "error", err)
+		allocation, _ = services.NewAllocationService("", services.NewAlphaVantageService())
+	}
+	return allocation
+}
+
+// newCorporateActionService loads recorded dividends and splits from the user's config directory,
+// falling back to in-memory records if they cannot be loaded
+func newCorporateActionService() *services.CorporateActionService {
+	path := ""
+	if configDir, err := os.UserConfigDir(); err == nil {
+		path = filepath.Join(configDir, "FinanceHub", "corporate_actions.json")
+	}
+
+	actions, err := services.NewCorporateActionService(path, services.NewAlphaVantageService())
+	if err != nil {
+		slog.Warn("Corporate action data unavailable, changes will not be saved", "error", err)
+		actions, _ = services.NewCorporateActionService("", services.NewAlphaVantageService())
+	}
+	return actions
+}
+
+// newPaperTradingService loads paper trading accounts from the user's config directory,
+// falling back to in-memory accounts if they cannot be loaded
+func newPaperTradingService() *services.PaperTradingService {
+	path := ""
+	if configDir, err := os.UserConfigDir(); err == nil {
+		path = filepath.Join(configDir, "FinanceHub", "paper_trading.json")
+	}
+
+	paper, err := services.NewPaperTradingService(path, services.NewAlphaVantageService(), services.NewCoinGeckoService())
+	if err != nil {
+		slog.Warn("Paper trading data unavailable, changes will not be saved", "error", err)
+		paper, _ = services.NewPaperTradingService("", services.NewAlphaVantageService(), services.NewCoinGeckoService())
 	}
+	return paper
 }
 
 // startup is called when the app starts. The context is saved
@@ -75,3 +182,274 @@ func (a *App) IsProduction() bool {
 	// This can be set via build tags or environment variables
 	return true
 }
+
+// AnalyzeBond computes price, yields, duration, convexity and accrued interest for a bond
+func (a *App) AnalyzeBond(req bonds.AnalyticsRequest) (*bonds.Analytics, error) {
+	return bonds.Analyze(req)
+}
+
+// CalculateAccruedInterest returns a bond's accrued interest under a day-count convention
+func (a *App) CalculateAccruedInterest(req bonds.AccruedInterestRequest) (float64, error) {
+	return bonds.CalculateAccruedInterest(req)
+}
+
+// CalculateMortgage returns the amortization schedule for a fixed or adjustable-rate mortgage
+func (a *App) CalculateMortgage(req calculators.MortgageRequest) (*calculators.MortgageSchedule, error) {
+	return calculators.CalculateMortgage(req)
+}
+
+// ExportMortgageScheduleCSV returns the mortgage amortization schedule as CSV text
+func (a *App) ExportMortgageScheduleCSV(req calculators.MortgageRequest) (string, error) {
+	schedule, err := calculators.CalculateMortgage(req)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := calculators.WriteScheduleCSV(&buf, schedule); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// AnalyzeRentalProperty evaluates a rental property deal with multi-year projections
+func (a *App) AnalyzeRentalProperty(req calculators.RentalRequest) (*calculators.RentalAnalysis, error) {
+	return calculators.AnalyzeRental(req)
+}
+
+// ProjectRetirement runs a deterministic compound-growth retirement projection
+func (a *App) ProjectRetirement(req planning.PlanRequest) (*planning.Projection, error) {
+	return planning.Project(req)
+}
+
+// SimulateRetirement runs a seeded Monte Carlo retirement simulation
+func (a *App) SimulateRetirement(req planning.PlanRequest) (*planning.SimulationResult, error) {
+	return planning.Simulate(req)
+}
+
+// GetBudgetCategories returns all budget categories
+func (a *App) GetBudgetCategories() []models.Category {
+	return a.budgetService.ListCategories()
+}
+
+// AddBudgetCategory creates a budget category
+func (a *App) AddBudgetCategory(category models.Category) (*models.Category, error) {
+	return a.budgetService.AddCategory(category)
+}
+
+// DeleteBudgetCategory removes a budget category without transactions
+func (a *App) DeleteBudgetCategory(id string) error {
+	return a.budgetService.DeleteCategory(id)
+}
+
+// GetBudgets returns all monthly category budgets
+func (a *App) GetBudgets() []models.Budget {
+	return a.budgetService.ListBudgets()
+}
+
+// SetBudget sets a category's monthly budget from a month onward
+func (a *App) SetBudget(budget models.Budget) (*models.Budget, error) {
+	return a.budgetService.SetBudget(budget)
+}
+
+// RecordIncome records an income transaction
+func (a *App) RecordIncome(expense models.Expense) (*models.Expense, error) {
+	expense.Kind = models.CategoryIncome
+	return a.budgetService.RecordExpense(expense)
+}
+
+// RecordExpense records an expense transaction
+func (a *App) RecordExpense(expense models.Expense) (*models.Expense, error) {
+	expense.Kind = models.CategoryExpense
+	return a.budgetService.RecordExpense(expense)
+}
+
+// GetBudgetTransactions returns recorded transactions, optionally for a YYYY-MM month
+func (a *App) GetBudgetTransactions(month string) ([]models.Expense, error) {
+	return a.budgetService.ListExpenses(month)
+}
+
+// DeleteBudgetTransaction removes a recorded transaction
+func (a *App) DeleteBudgetTransaction(id int64) error {
+	return a.budgetService.DeleteExpense(id)
+}
+
+// GetBudgetRollup returns income, expenses and per-category budget status for a YYYY-MM month
+func (a *App) GetBudgetRollup(month string) (*models.MonthlyRollup, error) {
+	return a.budgetService.GetMonthlyRollup(month)
+}
+
+// GetBudgetTrends returns income and spending trends for the months ending at a YYYY-MM month
+func (a *App) GetBudgetTrends(month string, months int) (*models.BudgetTrends, error) {
+	return a.budgetService.GetTrends(month, months)
+}
+
+// GetImportRules returns the statement categorization rules
+func (a *App) GetImportRules() []importer.Rule {
+	return a.budgetService.ListRules()
+}
+
+// SetImportRules replaces the statement categorization rules
+func (a *App) SetImportRules(rules []importer.Rule) ([]importer.Rule, error) {
+	return a.budgetService.SetRules(rules)
+}
+
+// ImportStatement opens a file dialog and imports the selected CSV, OFX/QFX or QIF statement
+// into the budget. It returns nil when the dialog is cancelled.
+func (a *App) ImportStatement(opts importer.Options) (*models.ImportResult, error) {
+	path, err := wailsruntime.OpenFileDialog(a.ctx, wailsruntime.OpenDialogOptions{
+		Title: "Import Bank Statement",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "Bank Statements (*.csv;*.ofx;*.qfx;*.qif)", Pattern: "*.csv;*.ofx;*.qfx;*.qif"},
+		},
+	})
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return a.importStatementFile(path, opts)
+}
+
+// importStatementFile parses a statement file and imports its transactions into the budget
+func (a *App) importStatementFile(path string, opts importer.Options) (*models.ImportResult, error) {
+	if opts.Format == "" {
+		format, err := importer.DetectFormat(path)
+		if err != nil {
+			return nil, err
+		}
+		opts.Format = format
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open statement: %w", err)
+	}
+	defer file.Close()
+
+	transactions, err := importer.Parse(file, opts)
+	if err != nil {
+		return nil, err
+	}
+	return a.budgetService.ImportTransactions(transactions)
+}
+
+// GetPortfolioPerformance computes time-weighted and money-weighted returns for holdings transactions
+func (a *App) GetPortfolioPerformance(req portfolio.PerformanceRequest) (*portfolio.Performance, error) {
+	return a.portfolioService.GetPerformance(a.ctx, req)
+}
+
+// GetRealizedGains relieves tax lots and reports realized gains by lot and tax year
+func (a *App) GetRealizedGains(req portfolio.GainsRequest) (*portfolio.GainsReport, error) {
+	return a.portfolioService.GetRealizedGains(req)
+}
+
+// ExportRealizedGainsCSV returns the realized gains by lot as CSV text
+func (a *App) ExportRealizedGainsCSV(req portfolio.GainsRequest) (string, error) {
+	report, err := a.portfolioService.GetRealizedGains(req)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := portfolio.WriteGainsCSV(&buf, report); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// GetDividendIncome reports dividend income per holding, projected annual income and yield on cost
+func (a *App) GetDividendIncome(req portfolio.IncomeRequest) (*portfolio.IncomeReport, error) {
+	return a.portfolioService.GetIncome(req)
+}
+
+// GetCorporateActions returns recorded dividends and splits for a symbol, or for every symbol when empty
+func (a *App) GetCorporateActions(symbol string) []portfolio.CorporateAction {
+	if symbol == "" {
+		return a.actionService.ListActions()
+	}
+	return a.actionService.ListActions(symbol)
+}
+
+// AddCorporateAction records a manually entered dividend or split
+func (a *App) AddCorporateAction(action portfolio.CorporateAction) (*portfolio.CorporateAction, error) {
+	return a.actionService.AddAction(action)
+}
+
+// DeleteCorporateAction removes a recorded dividend or split
+func (a *App) DeleteCorporateAction(id string) error {
+	return a.actionService.DeleteAction(id)
+}
+
+// SyncCorporateActions ingests a symbol's dividends and splits from Alpha Vantage
+func (a *App) SyncCorporateActions(symbol string) ([]portfolio.CorporateAction, error) {
+	return a.actionService.SyncActions(a.ctx, symbol)
+}
+
+// AnalyzeRisk computes volatility, beta, drawdown, Value at Risk and correlations for a set of holdings
+func (a *App) AnalyzeRisk(req risk.Request) (*risk.Report, error) {
+	return a.riskService.Analyze(a.ctx, req)
+}
+
+// GetAllocationTargets returns the allocation targets of every portfolio, keyed by portfolio name
+func (a *App) GetAllocationTargets() map[string]portfolio.AllocationTargets {
+	return a.allocationService.ListTargets()
+}
+
+// SetAllocationTargets saves a portfolio's allocation targets and drift bands
+func (a *App) SetAllocationTargets(name string, targets portfolio.AllocationTargets) (*portfolio.AllocationTargets, error) {
+	return a.allocationService.SetTargets(name, targets)
+}
+
+// DeleteAllocationTargets removes a portfolio's allocation targets
+func (a *App) DeleteAllocationTargets(name string) error {
+	return a.allocationService.DeleteTargets(name)
+}
+
+// PlanRebalance proposes buy and sell orders that bring a portfolio back within its drift bands
+func (a *App) PlanRebalance(name string, req portfolio.RebalanceRequest) (*portfolio.RebalancePlan, error) {
+	return a.allocationService.PlanRebalance(a.ctx, name, req)
+}
+
+// GetPaperAccounts returns every paper trading account
+func (a *App) GetPaperAccounts() []trading.Account {
+	return a.paperService.ListAccounts()
+}
+
+// CreatePaperAccount opens a paper trading account with starting cash and trading costs
+func (a *App) CreatePaperAccount(req services.PaperAccountRequest) (*trading.Account, error) {
+	return a.paperService.CreateAccount(req)
+}
+
+// GetPaperAccount fills triggered orders and returns a paper trading account with its valuation
+func (a *App) GetPaperAccount(id string) (*services.PaperAccountSummary, error) {
+	return a.paperService.GetAccount(a.ctx, id)
+}
+
+// DeletePaperAccount closes a paper trading account
+func (a *App) DeletePaperAccount(id string) error {
+	return a.paperService.DeleteAccount(id)
+}
+
+// PlacePaperOrder places a market, limit or stop order in a paper trading account
+func (a *App) PlacePaperOrder(id string, req trading.OrderRequest) (*trading.Order, error) {
+	return a.paperService.PlaceOrder(a.ctx, id, req)
+}
+
+// CancelPaperOrder cancels a pending order
+func (a *App) CancelPaperOrder(id string, orderID int64) (*trading.Order, error) {
+	return a.paperService.CancelOrder(id, orderID)
+}
+
+// RefreshPaperAccount evaluates a paper trading account's pending orders at the latest quotes
+func (a *App) RefreshPaperAccount(id string) ([]trading.Trade, error) {
+	return a.paperService.Refresh(a.ctx, id)
+}
+
+// RunBacktest replays a symbol's daily price history through a built-in strategy
+func (a *App) RunBacktest(req backtest.Request) (*backtest.Result, error) {
+	return a.backtestService.Run(a.ctx, req)
+}
+
+// GetStoredTimeSeries lists the price history kept for offline analytics
+func (a *App) GetStoredTimeSeries() ([]services.SeriesInfo, error) {
+	return a.timeSeries.List()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"financehub/backtest"
+	"financehub/services"
+)
+
+// runBacktest runs the backtest subcommand, printing a summary and the trades, or the full result with -json.
+// Fetching prices stops when the context is cancelled.
+func runBacktest(ctx context.Context, args []string, out io.Writer) error {
+	var req backtest.Request
+	flags := flag.NewFlagSet("backtest", flag.ContinueOnError)
+	flags.StringVar(&req.Symbol, "symbol", "", "stock ticker, or CoinGecko coin ID with -source crypto")
+	flags.StringVar(&req.Source, "source", backtest.SourceStock, "price source: stock or crypto")
+	flags.StringVar(&req.Strategy, "strategy", backtest.BuyAndHold, "strategy: buy-and-hold, sma-crossover or rsi-reversion")
+	flags.IntVar(&req.Days, "days", 504, "number of daily bars to replay")
+	flags.IntVar(&req.Params.FastPeriod, "fast", 0, "fast moving average period (default 50)")
+	flags.IntVar(&req.Params.SlowPeriod, "slow", 0, "slow moving average period (default 200)")
+	flags.IntVar(&req.Params.RSIPeriod, "rsi-period", 0, "RSI period (default 14)")
+	flags.Float64Var(&req.Params.Oversold, "oversold", 0, "RSI level to buy below (default 30)")
+	flags.Float64Var(&req.Params.Overbought, "overbought", 0, "RSI level to sell above (default 70)")
+	flags.Float64Var(&req.Settings.StartingCash, "cash", 10000, "starting cash")
+	flags.Float64Var(&req.Settings.Commission, "commission", 0, "flat commission per fill")
+	flags.Float64Var(&req.Settings.CommissionRate, "commission-rate", 0, "commission as a fraction of traded value")
+	flags.Float64Var(&req.Settings.Slippage, "slippage", 0, "slippage as a fraction of price")
+	flags.Float64Var(&req.Settings.RiskFreeRate, "risk-free", 0, "annual risk-free rate for the Sharpe ratio")
+	asJSON := flags.Bool("json", false, "print the full result as JSON")
+	flags.SetOutput(out)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	// Share the server's time series store so backtests can run offline on stored prices
+	dir := os.Getenv("TIMESERIES_DATA_DIR")
+	if dir == "" {
+		dir = "data/timeseries"
+	}
+	prices := services.NewTimeSeriesStore(dir, services.NewAlphaVantageService(), services.NewCoinGeckoService())
+	service := services.NewBacktestService(prices)
+	result, err := service.Run(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Symbol\t%s\n", result.Symbol)
+	fmt.Fprintf(w, "Strategy\t%s\n", result.Strategy)
+	fmt.Fprintf(w, "Period\t%s to %s (%d bars)\n", result.StartDate, result.EndDate, result.Bars)
+	fmt.Fprintf(w, "Ending equity\t%.2f (from %.2f)\n", result.EndingEquity, result.StartingCash)
+	fmt.Fprintf(w, "Total return\t%.2f%% (buy and hold %.2f%%)\n", result.TotalReturn*100, result.BuyAndHoldReturn*100)
+	fmt.Fprintf(w, "CAGR\t%.2f%%\n", result.CAGR*100)
+	fmt.Fprintf(w, "Sharpe ratio\t%.2f\n", result.SharpeRatio)
+	fmt.Fprintf(w, "Max drawdown\t%.2f%%\n", result.MaxDrawdown*100)
+	fmt.Fprintf(w, "Exposure\t%.2f%%\n", result.Exposure*100)
+	fmt.Fprintf(w, "Win rate\t%.2f%% of %d trades\n", result.WinRate*100, len(result.Trades))
+	fmt.Fprintf(w, "Fees\t%.2f\n", result.TotalFees)
+	if len(result.Trades) > 0 {
+		fmt.Fprintln(w, "\nEntry\tPrice\tExit\tPrice\tQuantity\tProfit\tReturn")
+		for _, trade := range result.Trades {
+			exit := trade.ExitDate
+			if trade.Open {
+				exit = "open"
+			}
+			fmt.Fprintf(w, "%s\t%.2f\t%s\t%.2f\t%.4f\t%.2f\t%.2f%%\n", trade.EntryDate, trade.EntryPrice,
+				exit, trade.ExitPrice, trade.Quantity, trade.Profit, trade.Return*100)
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,330 @@
+// Package backtest replays daily price bars through a trading strategy, simulating fills, fees and
+// slippage, and reports the equity curve, trades, CAGR, Sharpe ratio and maximum drawdown.
+//
+// Strategies are long-only and all-in: a buy invests all available cash and a sell closes the
+// whole position. Signals are decided on a bar's close and filled at the next bar's open, so a
+// strategy never trades on a price it has not yet seen.
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"financehub/models"
+	"financehub/risk"
+)
+
+// ErrInvalidBacktest is returned when a backtest request, strategy or price series is missing or out of range
+var ErrInvalidBacktest = errors.New("invalid backtest")
+
+// Price sources
+const (
+	SourceStock  = "stock"
+	SourceCrypto = "crypto"
+)
+
+const (
+	dateLayout          = "2006-01-02"
+	defaultStartingCash = 10000
+	defaultDays         = 504
+	maxDays             = 5000
+)
+
+// Settings are the simulated account and trading costs. Commission is a flat fee per fill,
+// CommissionRate a fraction of the traded value and Slippage a fraction of the price paid on buys
+// and given up on sells. StartingCash defaults to 10,000 and PeriodsPerYear to 252.
+type Settings struct {
+	StartingCash   float64 `json:"startingCash,omitempty"`
+	Commission     float64 `json:"commission"`
+	CommissionRate float64 `json:"commissionRate"`
+	Slippage       float64 `json:"slippage"`
+	RiskFreeRate   float64 `json:"riskFreeRate"`
+	PeriodsPerYear float64 `json:"periodsPerYear,omitempty"`
+}
+
+// Request describes a backtest of a built-in strategy over a symbol's last Days daily bars.
+// Symbols are stock tickers or, for the crypto source, CoinGecko coin IDs. Days defaults to 504
+// (about two years of trading days), and crypto backtests default to 365 periods per year.
+type Request struct {
+	Symbol   string   `json:"symbol"`
+	Source   string   `json:"source,omitempty"`
+	Days     int      `json:"days,omitempty"`
+	Strategy string   `json:"strategy"`
+	Params   Params   `json:"params"`
+	Settings Settings `json:"settings"`
+}
+
+// EquityPoint is the account value at a bar's close
+type EquityPoint struct {
+	Date     string  `json:"date"`
+	Equity   float64 `json:"equity"`
+	Drawdown float64 `json:"drawdown"`
+}
+
+// Trade is a round trip from entry to exit. A position still open on the last bar is valued at its
+// close, has no exit date and is marked Open.
+type Trade struct {
+	EntryDate  string  `json:"entryDate"`
+	EntryPrice float64 `json:"entryPrice"`
+	ExitDate   string  `json:"exitDate,omitempty"`
+	ExitPrice  float64 `json:"exitPrice"`
+	Quantity   float64 `json:"quantity"`
+	Fees       float64 `json:"fees"`
+	Profit     float64 `json:"profit"`
+	Return     float64 `json:"return"`
+	Open       bool    `json:"open,omitempty"`
+}
+
+// Result is the outcome of a backtest. Returns and drawdowns are fractions; Exposure is the share of
+// bars a position was held and BuyAndHoldReturn the price change over the whole series.
+type Result struct {
+	Symbol           string        `json:"symbol,omitempty"`
+	Strategy         string        `json:"strategy"`
+	StartDate        string        `json:"startDate"`
+	EndDate          string        `json:"endDate"`
+	Bars             int           `json:"bars"`
+	StartingCash     float64       `json:"startingCash"`
+	EndingEquity     float64       `json:"endingEquity"`
+	TotalReturn      float64       `json:"totalReturn"`
+	CAGR             float64       `json:"cagr"`
+	Volatility       float64       `json:"volatility"`
+	SharpeRatio      float64       `json:"sharpeRatio"`
+	MaxDrawdown      float64       `json:"maxDrawdown"`
+	Exposure         float64       `json:"exposure"`
+	WinRate          float64       `json:"winRate"`
+	TotalFees        float64       `json:"totalFees"`
+	BuyAndHoldReturn float64       `json:"buyAndHoldReturn"`
+	Trades           []Trade       `json:"trades"`
+	EquityCurve      []EquityPoint `json:"equityCurve"`
+}
+
+// Normalize validates the settings and fills in defaults
+func (s *Settings) Normalize() error {
+	if s.StartingCash == 0 {
+		s.StartingCash = defaultStartingCash
+	}
+	if s.PeriodsPerYear == 0 {
+		s.PeriodsPerYear = 252
+	}
+	switch {
+	case s.StartingCash < 0:
+		return fmt.Errorf("%w: starting cash must be positive", ErrInvalidBacktest)
+	case s.Commission < 0 || s.CommissionRate < 0 || s.Slippage < 0:
+		return fmt.Errorf("%w: commissions and slippage cannot be negative", ErrInvalidBacktest)
+	case s.CommissionRate >= 1 || s.Slippage >= 1:
+		return fmt.Errorf("%w: commission rate and slippage must be below 1", ErrInvalidBacktest)
+	case s.Commission >= s.StartingCash:
+		return fmt.Errorf("%w: the commission must be less than the starting cash", ErrInvalidBacktest)
+	case s.PeriodsPerYear < 1:
+		return fmt.Errorf("%w: periods per year must be positive", ErrInvalidBacktest)
+	}
+	return nil
+}
+
+// Normalize validates the request, including its strategy parameters, and fills in defaults
+func (r *Request) Normalize() error {
+	r.Symbol = strings.TrimSpace(r.Symbol)
+	r.Strategy = strings.ToLower(strings.TrimSpace(r.Strategy))
+	if r.Source == "" {
+		r.Source = SourceStock
+	}
+	if r.Days == 0 {
+		r.Days = defaultDays
+	}
+	if r.Source == SourceCrypto && r.Settings.PeriodsPerYear == 0 {
+		r.Settings.PeriodsPerYear = 365
+	}
+	switch {
+	case r.Symbol == "":
+		return fmt.Errorf("%w: symbol is required", ErrInvalidBacktest)
+	case r.Source != SourceStock && r.Source != SourceCrypto:
+		return fmt.Errorf("%w: source must be stock or crypto", ErrInvalidBacktest)
+	case r.Days < 2 || r.Days > maxDays:
+		return fmt.Errorf("%w: days must be between 2 and %d", ErrInvalidBacktest, maxDays)
+	}
+	if _, err := NewStrategy(r.Strategy, r.Params); err != nil {
+		return err
+	}
+	return r.Settings.Normalize()
+}
+
+// Run replays bars through a strategy. Bars may be in any order and are sorted by date; at least two are required.
+func Run(strategy Strategy, bars []models.TimeSeriesData, settings Settings) (*Result, error) {
+	if err := settings.Normalize(); err != nil {
+		return nil, err
+	}
+	if len(bars) < 2 {
+		return nil, fmt.Errorf("%w: at least two price bars are required", ErrInvalidBacktest)
+	}
+	bars = append([]models.TimeSeriesData{}, bars...)
+	sort.SliceStable(bars, func(i, j int) bool {
+		return bars[i].Date < bars[j].Date
+	})
+
+	sim := simulation{settings: settings, cash: settings.StartingCash}
+	equity := make([]float64, len(bars))
+	invested := 0
+	signal := Hold
+	for i, bar := range bars {
+		switch signal {
+		case Buy:
+			sim.buy(bar)
+		case Sell:
+			sim.sell(bar)
+		}
+		if sim.shares > 0 {
+			invested++
+		}
+		equity[i] = sim.cash + sim.shares*bar.Close
+		signal = strategy.Signal(bars[:i+1], sim.shares > 0)
+	}
+
+	last := bars[len(bars)-1]
+	if sim.open != nil {
+		trade := *sim.open
+		trade.ExitPrice = last.Close
+		trade.Open = true
+		sim.close(&trade, trade.Quantity*last.Close)
+	}
+
+	result := &Result{
+		Strategy:     strategy.Name(),
+		StartDate:    bars[0].Date,
+		EndDate:      last.Date,
+		Bars:         len(bars),
+		StartingCash: settings.StartingCash,
+		EndingEquity: roundTo(equity[len(equity)-1], 2),
+		Exposure:     roundTo(float64(invested)/float64(len(bars)), 6),
+		TotalFees:    roundTo(sim.fees, 2),
+		Trades:       sim.trades,
+		EquityCurve:  make([]EquityPoint, len(bars)),
+	}
+	if result.Trades == nil {
+		result.Trades = []Trade{}
+	}
+
+	returns := risk.Returns(equity)
+	drawdown, _, _ := risk.MaxDrawdown(equity)
+	result.TotalReturn = roundTo(equity[len(equity)-1]/settings.StartingCash-1, 6)
+	result.CAGR = roundTo(cagr(settings.StartingCash, equity[len(equity)-1], bars[0].Date, last.Date), 6)
+	result.Volatility = roundTo(risk.Volatility(returns, settings.PeriodsPerYear), 6)
+	result.SharpeRatio = roundTo(risk.SharpeRatio(returns, settings.RiskFreeRate, settings.PeriodsPerYear), 6)
+	result.MaxDrawdown = roundTo(drawdown, 6)
+	if bars[0].Close > 0 {
+		result.BuyAndHoldReturn = roundTo(last.Close/bars[0].Close-1, 6)
+	}
+
+	wins, closed := 0, 0
+	for _, trade := range result.Trades {
+		if trade.Open {
+			continue
+		}
+		closed++
+		if trade.Profit > 0 {
+			wins++
+		}
+	}
+	if closed > 0 {
+		result.WinRate = roundTo(float64(wins)/float64(closed), 6)
+	}
+
+	peak := 0.0
+	for i, value := range equity {
+		peak = math.Max(peak, value)
+		result.EquityCurve[i] = EquityPoint{
+			Date:     bars[i].Date,
+			Equity:   roundTo(value, 2),
+			Drawdown: roundTo(1-value/peak, 6),
+		}
+	}
+	return result, nil
+}
+
+// simulation is the account state while bars are replayed
+type simulation struct {
+	settings  Settings
+	cash      float64
+	shares    float64
+	fees      float64
+	open      *Trade
+	entryCost float64
+	trades    []Trade
+}
+
+// buy invests all cash at the bar's open, after slippage and fees
+func (s *simulation) buy(bar models.TimeSeriesData) {
+	price := fillPrice(bar) * (1 + s.settings.Slippage)
+	quantity := (s.cash - s.settings.Commission) / (price * (1 + s.settings.CommissionRate))
+	if price <= 0 || quantity <= 0 {
+		return
+	}
+	fee := s.settings.Commission + s.settings.CommissionRate*quantity*price
+
+	s.entryCost = s.cash
+	s.cash = 0
+	s.shares = quantity
+	s.fees += fee
+	s.open = &Trade{
+		EntryDate:  bar.Date,
+		EntryPrice: roundTo(price, 6),
+		Quantity:   quantity,
+		Fees:       fee,
+	}
+}
+
+// sell closes the position at the bar's open, after slippage and fees
+func (s *simulation) sell(bar models.TimeSeriesData) {
+	if s.open == nil {
+		return
+	}
+	price := fillPrice(bar) * (1 - s.settings.Slippage)
+	value := s.shares * price
+	fee := math.Min(s.settings.Commission+s.settings.CommissionRate*value, value)
+
+	s.cash += value - fee
+	s.shares = 0
+	s.fees += fee
+	trade := *s.open
+	trade.ExitDate = bar.Date
+	trade.ExitPrice = roundTo(price, 6)
+	trade.Fees += fee
+	s.close(&trade, value-fee)
+	s.open = nil
+}
+
+// close records a round trip, measuring its profit against the cash spent on entry including fees
+func (s *simulation) close(trade *Trade, proceeds float64) {
+	trade.Profit = roundTo(proceeds-s.entryCost, 2)
+	trade.Return = roundTo(proceeds/s.entryCost-1, 6)
+	trade.Quantity = roundTo(trade.Quantity, 6)
+	trade.Fees = roundTo(trade.Fees, 2)
+	s.trades = append(s.trades, *trade)
+}
+
+// fillPrice is the bar's open, or its close when the series has no opening prices
+func fillPrice(bar models.TimeSeriesData) float64 {
+	if bar.Open > 0 {
+		return bar.Open
+	}
+	return bar.Close
+}
+
+// cagr returns the compound annual growth rate between two dates
+func cagr(start, end float64, startDate, endDate string) float64 {
+	from, err1 := time.Parse(dateLayout, startDate)
+	to, err2 := time.Parse(dateLayout, endDate)
+	years := to.Sub(from).Hours() / 24 / 365.25
+	if err1 != nil || err2 != nil || years <= 0 || start <= 0 || end <= 0 {
+		return 0
+	}
+	return math.Pow(end/start, 1/years) - 1
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,196 @@
+package backtest
+
+import (
+	"fmt"
+	"strings"
+
+	"financehub/models"
+)
+
+// Built-in strategy names
+const (
+	BuyAndHold   = "buy-and-hold"
+	SMACrossover = "sma-crossover"
+	RSIReversion = "rsi-reversion"
+)
+
+const (
+	defaultFastPeriod = 50
+	defaultSlowPeriod = 200
+	defaultRSIPeriod  = 14
+	defaultOversold   = 30.0
+	defaultOverbought = 70.0
+	maxPeriod         = 1000
+)
+
+// Signal is a strategy's decision after a bar closes
+type Signal int
+
+// Signals. Orders are filled at the next bar's open.
+const (
+	Hold Signal = iota
+	Buy
+	Sell
+)
+
+// Strategy decides after each bar closes whether to open or close a long position.
+// Bars run from the first bar through the bar that just closed, oldest first.
+type Strategy interface {
+	Name() string
+	Signal(bars []models.TimeSeriesData, invested bool) Signal
+}
+
+// Params configure the built-in strategies. Zero values take the defaults: 50- and 200-bar
+// moving averages, and a 14-bar RSI bought below 30 and sold above 70.
+type Params struct {
+	FastPeriod int     `json:"fastPeriod,omitempty"`
+	SlowPeriod int     `json:"slowPeriod,omitempty"`
+	RSIPeriod  int     `json:"rsiPeriod,omitempty"`
+	Oversold   float64 `json:"oversold,omitempty"`
+	Overbought float64 `json:"overbought,omitempty"`
+}
+
+// NewStrategy returns a built-in strategy by name, filling in default parameters
+func NewStrategy(name string, params Params) (Strategy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case BuyAndHold:
+		return buyAndHold{}, nil
+	case SMACrossover:
+		if params.FastPeriod == 0 {
+			params.FastPeriod = defaultFastPeriod
+		}
+		if params.SlowPeriod == 0 {
+			params.SlowPeriod = defaultSlowPeriod
+		}
+		if params.FastPeriod < 1 || params.SlowPeriod > maxPeriod || params.FastPeriod >= params.SlowPeriod {
+			return nil, fmt.Errorf("%w: the fast period must be positive and shorter than the slow period, at most %d",
+				ErrInvalidBacktest, maxPeriod)
+		}
+		return smaCrossover{fast: params.FastPeriod, slow: params.SlowPeriod}, nil
+	case RSIReversion:
+		if params.RSIPeriod == 0 {
+			params.RSIPeriod = defaultRSIPeriod
+		}
+		if params.Oversold == 0 {
+			params.Oversold = defaultOversold
+		}
+		if params.Overbought == 0 {
+			params.Overbought = defaultOverbought
+		}
+		switch {
+		case params.RSIPeriod < 2 || params.RSIPeriod > maxPeriod:
+			return nil, fmt.Errorf("%w: the RSI period must be between 2 and %d", ErrInvalidBacktest, maxPeriod)
+		case params.Oversold <= 0 || params.Overbought >= 100 || params.Oversold >= params.Overbought:
+			return nil, fmt.Errorf("%w: RSI thresholds must satisfy 0 < oversold < overbought < 100", ErrInvalidBacktest)
+		}
+		return rsiReversion{period: params.RSIPeriod, oversold: params.Oversold, overbought: params.Overbought}, nil
+	}
+	return nil, fmt.Errorf("%w: strategy must be %s, %s or %s", ErrInvalidBacktest, BuyAndHold, SMACrossover, RSIReversion)
+}
+
+// buyAndHold buys on the first bar and never sells
+type buyAndHold struct{}
+
+func (buyAndHold) Name() string { return BuyAndHold }
+
+func (buyAndHold) Signal(bars []models.TimeSeriesData, invested bool) Signal {
+	if invested {
+		return Hold
+	}
+	return Buy
+}
+
+// smaCrossover holds a position while the fast moving average of closes is above the slow one
+type smaCrossover struct {
+	fast, slow int
+}
+
+func (s smaCrossover) Name() string { return SMACrossover }
+
+func (s smaCrossover) Signal(bars []models.TimeSeriesData, invested bool) Signal {
+	if len(bars) < s.slow {
+		return Hold
+	}
+	fast, slow := SMA(bars, s.fast), SMA(bars, s.slow)
+	switch {
+	case fast > slow && !invested:
+		return Buy
+	case fast < slow && invested:
+		return Sell
+	}
+	return Hold
+}
+
+// rsiReversion buys when the RSI falls below the oversold level and sells when it rises above overbought
+type rsiReversion struct {
+	period               int
+	oversold, overbought float64
+}
+
+func (s rsiReversion) Name() string { return RSIReversion }
+
+func (s rsiReversion) Signal(bars []models.TimeSeriesData, invested bool) Signal {
+	if len(bars) <= s.period {
+		return Hold
+	}
+	rsi := RSI(bars, s.period)
+	switch {
+	case rsi < s.oversold && !invested:
+		return Buy
+	case rsi > s.overbought && invested:
+		return Sell
+	}
+	return Hold
+}
+
+// SMA returns the simple moving average of the last period closes, or zero with fewer bars
+func SMA(bars []models.TimeSeriesData, period int) float64 {
+	if period < 1 || len(bars) < period {
+		return 0
+	}
+	sum := 0.0
+	for _, bar := range bars[len(bars)-period:] {
+		sum += bar.Close
+	}
+	return sum / float64(period)
+}
+
+// RSI returns the relative strength index of the closes with Wilder's smoothing over period bars.
+// It needs at least period+1 bars and returns 50 otherwise.
+func RSI(bars []models.TimeSeriesData, period int) float64 {
+	if period < 1 || len(bars) <= period {
+		return 50
+	}
+
+	gain, loss := 0.0, 0.0
+	for i := 1; i <= period; i++ {
+		change := bars[i].Close - bars[i-1].Close
+		if change > 0 {
+			gain += change
+		} else {
+			loss -= change
+		}
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+
+	for i := period + 1; i < len(bars); i++ {
+		change := bars[i].Close - bars[i-1].Close
+		up, down := 0.0, 0.0
+		if change > 0 {
+			up = change
+		} else {
+			down = -change
+		}
+		gain = (gain*float64(period-1) + up) / float64(period)
+		loss = (loss*float64(period-1) + down) / float64(period)
+	}
+
+	if loss == 0 {
+		if gain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
@@ -0,0 +1,168 @@
+// Package bonds provides fixed-income pricing and analytics: price from yield,
+// yield-to-maturity and yield-to-call solvers, duration, convexity and accrued interest.
+//
+// Rates are expressed as annual decimals (0.05 = 5%) and periods are assumed to
+// fall on coupon dates.
+package bonds
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidBond is returned when bond terms are missing or out of range
+var ErrInvalidBond = errors.New("invalid bond")
+
+// ErrNoSolution is returned when a yield cannot be solved for the given price
+var ErrNoSolution = errors.New("yield solver did not converge")
+
+// Bond describes a plain fixed-coupon bond
+type Bond struct {
+	FaceValue       float64 `json:"faceValue"`
+	CouponRate      float64 `json:"couponRate"`
+	Frequency       int     `json:"frequency"`
+	YearsToMaturity float64 `json:"yearsToMaturity"`
+}
+
+// Validate checks that the bond terms are usable
+func (b Bond) Validate() error {
+	switch {
+	case b.FaceValue <= 0:
+		return fmt.Errorf("%w: face value must be positive", ErrInvalidBond)
+	case b.CouponRate < 0:
+		return fmt.Errorf("%w: coupon rate cannot be negative", ErrInvalidBond)
+	case b.Frequency != 1 && b.Frequency != 2 && b.Frequency != 4 && b.Frequency != 12:
+		return fmt.Errorf("%w: frequency must be 1, 2, 4 or 12 payments per year", ErrInvalidBond)
+	case b.periods() < 1:
+		return fmt.Errorf("%w: years to maturity must cover at least one coupon period", ErrInvalidBond)
+	}
+	return nil
+}
+
+// Coupon returns the coupon paid each period
+func (b Bond) Coupon() float64 {
+	return b.FaceValue * b.CouponRate / float64(b.Frequency)
+}
+
+func (b Bond) periods() int {
+	return int(math.Round(b.YearsToMaturity * float64(b.Frequency)))
+}
+
+// Price returns the price of the bond for an annual yield to maturity
+func Price(b Bond, yield float64) (float64, error) {
+	if err := b.Validate(); err != nil {
+		return 0, err
+	}
+	return presentValue(b.Coupon(), b.FaceValue, b.periods(), b.Frequency, yield), nil
+}
+
+// YieldToMaturity solves for the annual yield that discounts the bond's cash flows to price
+func YieldToMaturity(b Bond, price float64) (float64, error) {
+	if err := b.Validate(); err != nil {
+		return 0, err
+	}
+	if price <= 0 {
+		return 0, fmt.Errorf("%w: price must be positive", ErrInvalidBond)
+	}
+	return solveYield(b.Coupon(), b.FaceValue, b.periods(), b.Frequency, price)
+}
+
+// YieldToCall solves for the annual yield assuming the bond is redeemed at callPrice after yearsToCall
+func YieldToCall(b Bond, price, callPrice, yearsToCall float64) (float64, error) {
+	if err := b.Validate(); err != nil {
+		return 0, err
+	}
+	periods := int(math.Round(yearsToCall * float64(b.Frequency)))
+	switch {
+	case price <= 0:
+		return 0, fmt.Errorf("%w: price must be positive", ErrInvalidBond)
+	case callPrice <= 0:
+		return 0, fmt.Errorf("%w: call price must be positive", ErrInvalidBond)
+	case periods < 1 || periods > b.periods():
+		return 0, fmt.Errorf("%w: call date must fall between the first coupon and maturity", ErrInvalidBond)
+	}
+	return solveYield(b.Coupon(), callPrice, periods, b.Frequency, price)
+}
+
+// MacaulayDuration returns the weighted average time to the bond's cash flows in years
+func MacaulayDuration(b Bond, yield float64) (float64, error) {
+	if err := b.Validate(); err != nil {
+		return 0, err
+	}
+	price := presentValue(b.Coupon(), b.FaceValue, b.periods(), b.Frequency, yield)
+	rate := yield / float64(b.Frequency)
+
+	var weighted float64
+	for t := 1; t <= b.periods(); t++ {
+		cashFlow := b.Coupon()
+		if t == b.periods() {
+			cashFlow += b.FaceValue
+		}
+		weighted += float64(t) * cashFlow / math.Pow(1+rate, float64(t))
+	}
+	return weighted / price / float64(b.Frequency), nil
+}
+
+// ModifiedDuration returns the percentage price sensitivity to a change in yield
+func ModifiedDuration(b Bond, yield float64) (float64, error) {
+	macaulay, err := MacaulayDuration(b, yield)
+	if err != nil {
+		return 0, err
+	}
+	return macaulay / (1 + yield/float64(b.Frequency)), nil
+}
+
+// Convexity returns the annualized convexity of the bond
+func Convexity(b Bond, yield float64) (float64, error) {
+	if err := b.Validate(); err != nil {
+		return 0, err
+	}
+	price := presentValue(b.Coupon(), b.FaceValue, b.periods(), b.Frequency, yield)
+	frequency := float64(b.Frequency)
+	rate := yield / frequency
+
+	var sum float64
+	for t := 1; t <= b.periods(); t++ {
+		cashFlow := b.Coupon()
+		if t == b.periods() {
+			cashFlow += b.FaceValue
+		}
+		sum += float64(t*(t+1)) * cashFlow / math.Pow(1+rate, float64(t))
+	}
+	return sum / (price * math.Pow(1+rate, 2) * frequency * frequency), nil
+}
+
+// presentValue discounts periodic coupons and a final redemption at an annual yield
+func presentValue(coupon, redemption float64, periods, frequency int, yield float64) float64 {
+	rate := yield / float64(frequency)
+	n := float64(periods)
+	if rate == 0 {
+		return coupon*n + redemption
+	}
+	discount := math.Pow(1+rate, -n)
+	return coupon*(1-discount)/rate + redemption*discount
+}
+
+// solveYield finds the annual yield matching price by bisection; price falls monotonically as yield rises
+func solveYield(coupon, redemption float64, periods, frequency int, price float64) (float64, error) {
+	// Keep the periodic rate above -100% so discount factors stay finite
+	low, high := -0.99*float64(frequency), 10.0
+	if presentValue(coupon, redemption, periods, frequency, low) < price ||
+		presentValue(coupon, redemption, periods, frequency, high) > price {
+		return 0, ErrNoSolution
+	}
+
+	for i := 0; i < 200; i++ {
+		mid := (low + high) / 2
+		if presentValue(coupon, redemption, periods, frequency, mid) > price {
+			low = mid
+		} else {
+			high = mid
+		}
+		if high-low < 1e-12 {
+			break
+		}
+	}
+	return (low + high) / 2, nil
+}
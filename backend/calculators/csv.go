@@ -0,0 +1,42 @@
+package calculators
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// scheduleCSVHeader lists the columns written by WriteScheduleCSV
+var scheduleCSVHeader = []string{"Period", "Date", "Rate", "Payment", "Principal", "Interest", "Extra", "PMI", "Balance"}
+
+// WriteScheduleCSV writes the amortization rows of a schedule as CSV
+func WriteScheduleCSV(w io.Writer, schedule *MortgageSchedule) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(scheduleCSVHeader); err != nil {
+		return err
+	}
+
+	for _, row := range schedule.Rows {
+		record := []string{
+			strconv.Itoa(row.Period),
+			row.Date,
+			strconv.FormatFloat(row.Rate, 'f', -1, 64),
+			formatAmount(row.Payment),
+			formatAmount(row.Principal),
+			formatAmount(row.Interest),
+			formatAmount(row.Extra),
+			formatAmount(row.PMI),
+			formatAmount(row.Balance),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
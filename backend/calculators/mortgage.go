@@ -0,0 +1,304 @@
+// Package calculators provides loan and mortgage amortization schedules.
+//
+// Rates are expressed as annual decimals (0.06 = 6%) and amounts are rounded to cents per payment.
+package calculators
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// ErrInvalidLoan is returned when loan terms are missing or out of range
+var ErrInvalidLoan = errors.New("invalid loan")
+
+// pmiRemovalLTV is the loan-to-value ratio at which PMI is automatically cancelled
+const pmiRemovalLTV = 0.78
+
+// AdjustableRate describes an adjustable-rate mortgage. After InitialYears the rate resets every
+// AdjustmentIntervalYears toward IndexRate+Margin, limited by the periodic and lifetime caps.
+type AdjustableRate struct {
+	InitialYears            int     `json:"initialYears"`
+	AdjustmentIntervalYears int     `json:"adjustmentIntervalYears"`
+	IndexRate               float64 `json:"indexRate"`
+	Margin                  float64 `json:"margin"`
+	PeriodicCap             float64 `json:"periodicCap"`
+	LifetimeCap             float64 `json:"lifetimeCap"`
+}
+
+// LumpSum is a one-time extra principal payment made with the given payment number
+type LumpSum struct {
+	Period int     `json:"period"`
+	Amount float64 `json:"amount"`
+}
+
+// MortgageRequest describes a mortgage and optional payment scenarios.
+// LoanAmount defaults to HomePrice minus DownPayment when omitted.
+type MortgageRequest struct {
+	HomePrice    float64         `json:"homePrice"`
+	DownPayment  float64         `json:"downPayment"`
+	LoanAmount   float64         `json:"loanAmount"`
+	AnnualRate   float64         `json:"annualRate"`
+	TermYears    int             `json:"termYears"`
+	Adjustable   *AdjustableRate `json:"adjustable,omitempty"`
+	ExtraPayment float64         `json:"extraPayment"`
+	LumpSums     []LumpSum       `json:"lumpSums,omitempty"`
+	Biweekly     bool            `json:"biweekly"`
+	PMIRate      float64         `json:"pmiRate"`
+	StartDate    string          `json:"startDate,omitempty"`
+}
+
+// AmortizationRow is a single payment in the schedule
+type AmortizationRow struct {
+	Period    int     `json:"period"`
+	Date      string  `json:"date,omitempty"`
+	Rate      float64 `json:"rate"`
+	Payment   float64 `json:"payment"`
+	Principal float64 `json:"principal"`
+	Interest  float64 `json:"interest"`
+	Extra     float64 `json:"extra"`
+	PMI       float64 `json:"pmi"`
+	Balance   float64 `json:"balance"`
+}
+
+// ScenarioComparison compares a schedule with extra or biweekly payments to the standard monthly schedule
+type ScenarioComparison struct {
+	BaselinePayment       float64 `json:"baselinePayment"`
+	BaselineTotalInterest float64 `json:"baselineTotalInterest"`
+	BaselinePayoffMonths  int     `json:"baselinePayoffMonths"`
+	InterestSaved         float64 `json:"interestSaved"`
+	MonthsSaved           int     `json:"monthsSaved"`
+}
+
+// MortgageSchedule is the full amortization result
+type MortgageSchedule struct {
+	LoanAmount       float64             `json:"loanAmount"`
+	Payment          float64             `json:"payment"`
+	PaymentsPerYear  int                 `json:"paymentsPerYear"`
+	TotalInterest    float64             `json:"totalInterest"`
+	TotalPMI         float64             `json:"totalPMI"`
+	TotalPaid        float64             `json:"totalPaid"`
+	PayoffPeriods    int                 `json:"payoffPeriods"`
+	PayoffMonths     int                 `json:"payoffMonths"`
+	PMIDropOffPeriod int                 `json:"pmiDropOffPeriod,omitempty"`
+	Comparison       *ScenarioComparison `json:"comparison,omitempty"`
+	Rows             []AmortizationRow   `json:"rows"`
+}
+
+// Validate checks that the mortgage terms are usable
+func (r MortgageRequest) Validate() error {
+	switch {
+	case r.principal() <= 0:
+		return fmt.Errorf("%w: loan amount must be positive", ErrInvalidLoan)
+	case r.AnnualRate < 0:
+		return fmt.Errorf("%w: annual rate cannot be negative", ErrInvalidLoan)
+	case r.TermYears <= 0 || r.TermYears > 50:
+		return fmt.Errorf("%w: term must be between 1 and 50 years", ErrInvalidLoan)
+	case r.ExtraPayment < 0 || r.PMIRate < 0:
+		return fmt.Errorf("%w: extra payment and PMI rate cannot be negative", ErrInvalidLoan)
+	case r.HomePrice > 0 && r.principal() > r.HomePrice:
+		return fmt.Errorf("%w: loan amount exceeds home price", ErrInvalidLoan)
+	}
+	if r.StartDate != "" {
+		if _, err := time.Parse("2006-01-02", r.StartDate); err != nil {
+			return fmt.Errorf("%w: startDate must be YYYY-MM-DD", ErrInvalidLoan)
+		}
+	}
+	if arm := r.Adjustable; arm != nil {
+		if arm.InitialYears <= 0 || arm.InitialYears >= r.TermYears {
+			return fmt.Errorf("%w: adjustable initial period must be shorter than the term", ErrInvalidLoan)
+		}
+		if arm.AdjustmentIntervalYears < 0 || arm.PeriodicCap < 0 || arm.LifetimeCap < 0 {
+			return fmt.Errorf("%w: adjustable caps and interval cannot be negative", ErrInvalidLoan)
+		}
+	}
+	return nil
+}
+
+func (r MortgageRequest) principal() float64 {
+	if r.LoanAmount > 0 {
+		return r.LoanAmount
+	}
+	return r.HomePrice - r.DownPayment
+}
+
+// MonthlyPayment returns the level payment that amortizes principal over months at an annual rate
+func MonthlyPayment(principal, annualRate float64, months int) float64 {
+	return annuityPayment(principal, annualRate/12, months)
+}
+
+func annuityPayment(principal, rate float64, periods int) float64 {
+	if periods <= 0 {
+		return principal
+	}
+	if rate == 0 {
+		return principal / float64(periods)
+	}
+	return principal * rate / (1 - math.Pow(1+rate, -float64(periods)))
+}
+
+// CalculateMortgage builds the amortization schedule for a mortgage request. When extra,
+// lump-sum or biweekly payments are requested, the result is compared to the standard schedule.
+func CalculateMortgage(req MortgageRequest) (*MortgageSchedule, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	schedule := amortize(req)
+
+	if req.ExtraPayment > 0 || len(req.LumpSums) > 0 || req.Biweekly {
+		baselineReq := req
+		baselineReq.ExtraPayment = 0
+		baselineReq.LumpSums = nil
+		baselineReq.Biweekly = false
+		baseline := amortize(baselineReq)
+
+		schedule.Comparison = &ScenarioComparison{
+			BaselinePayment:       baseline.Payment,
+			BaselineTotalInterest: baseline.TotalInterest,
+			BaselinePayoffMonths:  baseline.PayoffMonths,
+			InterestSaved:         round2(baseline.TotalInterest - schedule.TotalInterest),
+			MonthsSaved:           baseline.PayoffMonths - schedule.PayoffMonths,
+		}
+	}
+
+	return schedule, nil
+}
+
+// amortize runs the payment schedule for a validated request
+func amortize(req MortgageRequest) *MortgageSchedule {
+	principal := req.principal()
+	periodsPerYear := 12
+	if req.Biweekly {
+		periodsPerYear = 26
+	}
+	totalMonths := req.TermYears * 12
+
+	lumpSums := make(map[int]float64, len(req.LumpSums))
+	for _, lump := range req.LumpSums {
+		lumpSums[lump.Period] += lump.Amount
+	}
+
+	var start time.Time
+	if req.StartDate != "" {
+		start, _ = time.Parse("2006-01-02", req.StartDate)
+	}
+
+	schedule := &MortgageSchedule{
+		LoanAmount:      round2(principal),
+		PaymentsPerYear: periodsPerYear,
+		Rows:            []AmortizationRow{},
+	}
+
+	rate := req.AnnualRate
+	balance := principal
+	payment := scheduledPayment(balance, rate, totalMonths, req.Biweekly)
+	schedule.Payment = payment
+
+	pmiActive := req.PMIRate > 0 && req.HomePrice > 0 && principal > req.HomePrice*0.8
+	pmiPayment := round2(principal * req.PMIRate / float64(periodsPerYear))
+
+	// Biweekly schedules can run past the term only through rounding; cap the loop defensively
+	maxPeriods := req.TermYears*periodsPerYear + periodsPerYear
+	for period := 1; balance > 0.005 && period <= maxPeriods; period++ {
+		if nextRate, adjusted := adjustedRate(req, rate, period, periodsPerYear); adjusted {
+			rate = nextRate
+			elapsedMonths := (period - 1) * 12 / periodsPerYear
+			payment = scheduledPayment(balance, rate, totalMonths-elapsedMonths, req.Biweekly)
+		}
+
+		interest := round2(balance * rate / float64(periodsPerYear))
+		principalPaid := math.Min(payment-interest, balance)
+		if period*12 >= totalMonths*periodsPerYear {
+			// The final scheduled payment absorbs any rounding remainder
+			principalPaid = balance
+		}
+		extra := math.Min(req.ExtraPayment+lumpSums[period], balance-principalPaid)
+		if extra < 0 {
+			extra = 0
+		}
+		balance = round2(balance - principalPaid - extra)
+
+		var pmi float64
+		if pmiActive {
+			pmi = pmiPayment
+			schedule.TotalPMI += pmi
+			if balance <= req.HomePrice*pmiRemovalLTV {
+				pmiActive = false
+				schedule.PMIDropOffPeriod = period + 1
+			}
+		}
+
+		row := AmortizationRow{
+			Period:    period,
+			Rate:      rate,
+			Payment:   round2(principalPaid + interest),
+			Principal: round2(principalPaid),
+			Interest:  interest,
+			Extra:     round2(extra),
+			PMI:       pmi,
+			Balance:   balance,
+		}
+		if !start.IsZero() {
+			if req.Biweekly {
+				row.Date = start.AddDate(0, 0, 14*(period-1)).Format("2006-01-02")
+			} else {
+				row.Date = start.AddDate(0, period-1, 0).Format("2006-01-02")
+			}
+		}
+
+		schedule.Rows = append(schedule.Rows, row)
+		schedule.TotalInterest += interest
+		schedule.TotalPaid += row.Payment + row.Extra + pmi
+	}
+
+	schedule.TotalInterest = round2(schedule.TotalInterest)
+	schedule.TotalPMI = round2(schedule.TotalPMI)
+	schedule.TotalPaid = round2(schedule.TotalPaid)
+	schedule.PayoffPeriods = len(schedule.Rows)
+	schedule.PayoffMonths = int(math.Ceil(float64(schedule.PayoffPeriods) * 12 / float64(periodsPerYear)))
+	return schedule
+}
+
+// scheduledPayment returns the payment amortizing balance over the remaining months.
+// Biweekly payments are half of the monthly payment, made 26 times a year.
+func scheduledPayment(balance, annualRate float64, remainingMonths int, biweekly bool) float64 {
+	payment := MonthlyPayment(balance, annualRate, remainingMonths)
+	if biweekly {
+		payment /= 2
+	}
+	return round2(payment)
+}
+
+// adjustedRate returns the new rate when an adjustable-rate mortgage resets at the start of period
+func adjustedRate(req MortgageRequest, current float64, period, periodsPerYear int) (float64, bool) {
+	arm := req.Adjustable
+	if arm == nil {
+		return current, false
+	}
+
+	interval := arm.AdjustmentIntervalYears
+	if interval == 0 {
+		interval = 1
+	}
+	elapsed := period - 1
+	firstReset := arm.InitialYears * periodsPerYear
+	if elapsed < firstReset || (elapsed-firstReset)%(interval*periodsPerYear) != 0 {
+		return current, false
+	}
+
+	target := arm.IndexRate + arm.Margin
+	if arm.PeriodicCap > 0 {
+		target = math.Min(target, current+arm.PeriodicCap)
+		target = math.Max(target, current-arm.PeriodicCap)
+	}
+	if arm.LifetimeCap > 0 {
+		target = math.Min(target, req.AnnualRate+arm.LifetimeCap)
+	}
+	return math.Max(math.Round(target*1e6)/1e6, 0), true
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"financehub/models"
+	"financehub/portfolio"
+	"financehub/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAllocationTargets returns the allocation targets of every portfolio, keyed by portfolio name
+func (h *Handler) GetAllocationTargets(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.Allocation.ListTargets(),
+	})
+}
+
+// GetPortfolioTargets returns one portfolio's allocation targets
+func (h *Handler) GetPortfolioTargets(c *gin.Context) {
+	targets, err := h.Allocation.GetTargets(c.Param("portfolio"))
+	if err != nil {
+		allocationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    targets,
+	})
+}
+
+// SetPortfolioTargets saves a portfolio's allocation targets and drift bands
+func (h *Handler) SetPortfolioTargets(c *gin.Context) {
+	var targets portfolio.AllocationTargets
+	if err := c.ShouldBindJSON(&targets); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	saved, err := h.Allocation.SetTargets(c.Param("portfolio"), targets)
+	if err != nil {
+		allocationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    saved,
+	})
+}
+
+// DeletePortfolioTargets removes a portfolio's allocation targets
+func (h *Handler) DeletePortfolioTargets(c *gin.Context) {
+	if err := h.Allocation.DeleteTargets(c.Param("portfolio")); err != nil {
+		allocationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Allocation targets deleted",
+	})
+}
+
+// PlanRebalance returns allocation drift and the buy and sell orders that bring
+// a portfolio's holdings back within their drift bands
+func (h *Handler) PlanRebalance(c *gin.Context) {
+	var req portfolio.RebalanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	plan, err := h.Allocation.PlanRebalance(c.Request.Context(), c.Param("portfolio"), req)
+	if err != nil {
+		allocationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    plan,
+	})
+}
+
+// allocationError writes an error response with a status code matching the allocation error
+func allocationError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrTargetsNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, portfolio.ErrInvalidAllocation):
+		status = http.StatusBadRequest
+	}
+	errorResponse(c, status, err)
+}
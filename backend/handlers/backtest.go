@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"financehub/backtest"
+	"financehub/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RunBacktest replays a symbol's daily price history through a built-in strategy and returns
+// the equity curve, trades, CAGR, Sharpe ratio and maximum drawdown
+func (h *Handler) RunBacktest(c *gin.Context) {
+	var req backtest.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := h.Backtest.Run(c.Request.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, backtest.ErrInvalidBacktest) {
+			status = http.StatusBadRequest
+		}
+		errorResponse(c, status, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
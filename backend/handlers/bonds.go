@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"financehub/bonds"
+	"financehub/models"
+	"financehub/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetYieldCurve returns the Treasury yield curve snapshot for an optional date
+func (h *Handler) GetYieldCurve(c *gin.Context) {
+	date := c.Query("date")
+	if date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			errorResponse(c, http.StatusBadRequest, errors.New("date must be in YYYY-MM-DD format"))
+			return
+		}
+	}
+
+	curve, err := h.YieldCurve.GetYieldCurve(c.Request.Context(), date, c.Query("interval"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrNoYieldCurveData):
+			status = http.StatusNotFound
+		case errors.Is(err, services.ErrInvalidIndicatorOption):
+			status = http.StatusBadRequest
+		}
+		errorResponse(c, status, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    curve,
+	})
+}
+
+// AnalyzeBond returns price, yields, duration, convexity and accrued interest for a bond
+func (h *Handler) AnalyzeBond(c *gin.Context) {
+	var req bonds.AnalyticsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	analytics, err := bonds.Analyze(req)
+	if err != nil {
+		errorResponse(c, bondErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    analytics,
+	})
+}
+
+// CalculateAccruedInterest returns accrued interest under the requested day-count convention
+func (h *Handler) CalculateAccruedInterest(c *gin.Context) {
+	var req bonds.AccruedInterestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	accrued, err := bonds.CalculateAccruedInterest(req)
+	if err != nil {
+		errorResponse(c, bondErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    gin.H{"accruedInterest": accrued},
+	})
+}
+
+// bondErrorStatus maps bond calculation errors to HTTP status codes
+func bondErrorStatus(err error) int {
+	if errors.Is(err, bonds.ErrInvalidBond) || errors.Is(err, bonds.ErrNoSolution) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"financehub/importer"
+	"financehub/models"
+	"financehub/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBudgetCategories returns all budget categories
+func (h *Handler) GetBudgetCategories(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.Budget.ListCategories(),
+	})
+}
+
+// CreateBudgetCategory adds a budget category
+func (h *Handler) CreateBudgetCategory(c *gin.Context) {
+	var category models.Category
+	if err := c.ShouldBindJSON(&category); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	created, err := h.Budget.AddCategory(category)
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    created,
+	})
+}
+
+// DeleteBudgetCategory removes a budget category without transactions
+func (h *Handler) DeleteBudgetCategory(c *gin.Context) {
+	if err := h.Budget.DeleteCategory(c.Param("id")); err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Category deleted",
+	})
+}
+
+// GetBudgets returns all monthly category budgets
+func (h *Handler) GetBudgets(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.Budget.ListBudgets(),
+	})
+}
+
+// SetBudget sets a category's monthly budget from a month onward
+func (h *Handler) SetBudget(c *gin.Context) {
+	var budget models.Budget
+	if err := c.ShouldBindJSON(&budget); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	saved, err := h.Budget.SetBudget(budget)
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    saved,
+	})
+}
+
+// GetBudgetTransactions returns recorded transactions, optionally filtered by ?month=YYYY-MM
+func (h *Handler) GetBudgetTransactions(c *gin.Context) {
+	expenses, err := h.Budget.ListExpenses(c.Query("month"))
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    expenses,
+	})
+}
+
+// RecordIncome records an income transaction
+func (h *Handler) RecordIncome(c *gin.Context) {
+	h.recordTransaction(c, models.CategoryIncome)
+}
+
+// RecordExpense records an expense transaction
+func (h *Handler) RecordExpense(c *gin.Context) {
+	h.recordTransaction(c, models.CategoryExpense)
+}
+
+// DeleteBudgetTransaction removes a recorded transaction
+func (h *Handler) DeleteBudgetTransaction(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, errors.New("Invalid transaction ID"))
+		return
+	}
+
+	if err := h.Budget.DeleteExpense(id); err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Transaction deleted",
+	})
+}
+
+// GetBudgetRollup returns the monthly rollup versus budget for ?month=YYYY-MM
+func (h *Handler) GetBudgetRollup(c *gin.Context) {
+	rollup, err := h.Budget.GetMonthlyRollup(c.Query("month"))
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    rollup,
+	})
+}
+
+// GetBudgetTrends returns income and spending trends for the ?months= months ending at ?month=
+func (h *Handler) GetBudgetTrends(c *gin.Context) {
+	months, _ := strconv.Atoi(c.Query("months"))
+
+	trends, err := h.Budget.GetTrends(c.Query("month"), months)
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    trends,
+	})
+}
+
+// GetImportRules returns the statement categorization rules
+func (h *Handler) GetImportRules(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.Budget.ListRules(),
+	})
+}
+
+// SetImportRules replaces the statement categorization rules
+func (h *Handler) SetImportRules(c *gin.Context) {
+	var rules []importer.Rule
+	if err := c.ShouldBindJSON(&rules); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	saved, err := h.Budget.SetRules(rules)
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    saved,
+	})
+}
+
+// ImportStatement imports an uploaded CSV, OFX/QFX or QIF statement into the budget.
+// The multipart form takes the statement as "file" and optional parsing options as JSON in "options";
+// the format is detected from the file extension when not given.
+func (h *Handler) ImportStatement(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, errors.New("Statement file is required"))
+		return
+	}
+
+	var opts importer.Options
+	if raw := c.PostForm("options"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &opts); err != nil {
+			errorResponse(c, http.StatusBadRequest, fmt.Errorf("Invalid import options: %w", err))
+			return
+		}
+	}
+	if opts.Format == "" {
+		if opts.Format, err = importer.DetectFormat(fileHeader.Filename); err != nil {
+			budgetError(c, err)
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+	defer file.Close()
+
+	transactions, err := importer.Parse(file, opts)
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	result, err := h.Budget.ImportTransactions(transactions)
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// recordTransaction binds and records a transaction of the given kind
+func (h *Handler) recordTransaction(c *gin.Context, kind string) {
+	var expense models.Expense
+	if err := c.ShouldBindJSON(&expense); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	expense.Kind = kind
+
+	recorded, err := h.Budget.RecordExpense(expense)
+	if err != nil {
+		budgetError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    recorded,
+	})
+}
+
+// budgetError writes a budget service error with the matching HTTP status code
+func budgetError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrCategoryNotFound), errors.Is(err, services.ErrExpenseNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrCategoryExists), errors.Is(err, services.ErrCategoryInUse),
+		errors.Is(err, services.ErrDuplicateTransaction):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrInvalidBudget), errors.Is(err, importer.ErrInvalidStatement),
+		errors.Is(err, importer.ErrUnsupportedFormat):
+		status = http.StatusBadRequest
+	}
+	errorResponse(c, status, err)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"financehub/models"
+	"financehub/portfolio"
+	"financehub/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCorporateActions returns recorded dividends and splits, optionally filtered with ?symbol=
+func (h *Handler) GetCorporateActions(c *gin.Context) {
+	var symbols []string
+	if symbol := c.Query("symbol"); symbol != "" {
+		symbols = append(symbols, symbol)
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.CorporateActions.ListActions(symbols...),
+	})
+}
+
+// CreateCorporateAction records a manually entered dividend or split
+func (h *Handler) CreateCorporateAction(c *gin.Context) {
+	var action portfolio.CorporateAction
+	if err := c.ShouldBindJSON(&action); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	created, err := h.CorporateActions.AddAction(action)
+	if err != nil {
+		corporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    created,
+	})
+}
+
+// DeleteCorporateAction removes a recorded dividend or split
+func (h *Handler) DeleteCorporateAction(c *gin.Context) {
+	if err := h.CorporateActions.DeleteAction(c.Param("id")); err != nil {
+		corporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Corporate action deleted",
+	})
+}
+
+// SyncCorporateActions ingests a symbol's dividends and splits from Alpha Vantage
+// daily-adjusted data and returns the newly recorded actions
+func (h *Handler) SyncCorporateActions(c *gin.Context) {
+	added, err := h.CorporateActions.SyncActions(c.Request.Context(), c.Param("symbol"))
+	if err != nil {
+		corporateActionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    added,
+	})
+}
+
+// corporateActionError writes an error response with a status code matching the corporate action error
+func corporateActionError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrCorporateActionNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrCorporateActionExists):
+		status = http.StatusConflict
+	case errors.Is(err, portfolio.ErrInvalidPortfolio):
+		status = http.StatusBadRequest
+	}
+	errorResponse(c, status, err)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"financehub/models"
+	"financehub/portfolio"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPortfolioPerformance returns time-weighted and money-weighted returns for a set of
+// holdings transactions, with an optional benchmark comparison and a daily series for charting
+func (h *Handler) GetPortfolioPerformance(c *gin.Context) {
+	var req portfolio.PerformanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	performance, err := h.Portfolio.GetPerformance(c.Request.Context(), req)
+	if err != nil {
+		errorResponse(c, portfolioErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    performance,
+	})
+}
+
+// GetRealizedGains relieves tax lots for buy and sell transactions and returns realized gains
+// by lot and tax year, with wash sales and splits adjusted. Pass ?format=csv to download the realized lots as CSV.
+func (h *Handler) GetRealizedGains(c *gin.Context) {
+	var req portfolio.GainsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := h.Portfolio.GetRealizedGains(req)
+	if err != nil {
+		errorResponse(c, portfolioErrorStatus(err), err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		var buf bytes.Buffer
+		if err := portfolio.WriteGainsCSV(&buf, report); err != nil {
+			errorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="realized_gains.csv"`)
+		c.Data(http.StatusOK, "text/csv", buf.Bytes())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// GetDividendIncome returns dividend income history per holding, projected annual income and
+// yield on cost, using recorded dividends and splits unless the request lists its own
+func (h *Handler) GetDividendIncome(c *gin.Context) {
+	var req portfolio.IncomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	income, err := h.Portfolio.GetIncome(req)
+	if err != nil {
+		errorResponse(c, portfolioErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    income,
+	})
+}
+
+// portfolioErrorStatus maps portfolio errors to HTTP status codes
+func portfolioErrorStatus(err error) int {
+	if errors.Is(err, portfolio.ErrInvalidPortfolio) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
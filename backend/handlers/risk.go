@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"financehub/models"
+	"financehub/risk"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyzeRisk returns volatility, beta, Sharpe and Sortino ratios, maximum drawdown, Value at Risk
+// and a correlation matrix for a set of stock and crypto holdings
+func (h *Handler) AnalyzeRisk(c *gin.Context) {
+	var req risk.Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	report, err := h.Risk.Analyze(c.Request.Context(), req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, risk.ErrInvalidRequest) {
+			status = http.StatusBadRequest
+		}
+		errorResponse(c, status, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
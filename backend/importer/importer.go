@@ -0,0 +1,171 @@
+// Package importer parses bank statements in CSV, OFX/QFX and QIF formats into normalized transactions.
+//
+// Amounts are signed: credits (deposits) are positive and debits (purchases, withdrawals) are negative.
+// Dates are normalized to YYYY-MM-DD.
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedFormat is returned when a statement format is not recognized
+var ErrUnsupportedFormat = errors.New("unsupported statement format")
+
+// ErrInvalidStatement is returned when a statement cannot be parsed
+var ErrInvalidStatement = errors.New("invalid statement")
+
+// Supported statement formats
+const (
+	FormatCSV = "csv"
+	FormatOFX = "ofx"
+	FormatQFX = "qfx"
+	FormatQIF = "qif"
+)
+
+// DateLayout is the normalized transaction date format
+const DateLayout = "2006-01-02"
+
+// dateLayouts are tried in order when no date format is configured
+var dateLayouts = []string{
+	"2006-01-02",
+	"01/02/2006",
+	"1/2/2006",
+	"01/02/06",
+	"1/2/06",
+	"2006/01/02",
+	"01-02-2006",
+	"02 Jan 2006",
+	"Jan 2, 2006",
+	"20060102",
+}
+
+// Transaction is a normalized statement transaction
+type Transaction struct {
+	ID          string  `json:"id"`
+	Date        string  `json:"date"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+	Memo        string  `json:"memo,omitempty"`
+	Reference   string  `json:"reference,omitempty"`
+	Category    string  `json:"category,omitempty"`
+	CategoryID  string  `json:"categoryId,omitempty"`
+}
+
+// Options controls how a statement is parsed
+type Options struct {
+	Format     string     `json:"format"`
+	DateFormat string     `json:"dateFormat,omitempty"`
+	CSV        CSVMapping `json:"csv"`
+}
+
+// DetectFormat returns the statement format implied by a file name's extension
+func DetectFormat(filename string) (string, error) {
+	format := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	switch format {
+	case FormatCSV, FormatOFX, FormatQFX, FormatQIF:
+		return format, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, filepath.Ext(filename))
+}
+
+// Parse reads a statement and returns its transactions with IDs assigned
+func Parse(r io.Reader, opts Options) ([]Transaction, error) {
+	var transactions []Transaction
+	var err error
+
+	switch strings.ToLower(opts.Format) {
+	case FormatCSV:
+		transactions, err = parseCSV(r, opts)
+	case FormatOFX, FormatQFX:
+		transactions, err = parseOFX(r)
+	case FormatQIF:
+		transactions, err = parseQIF(r, opts)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedFormat, opts.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	AssignIDs(transactions)
+	return transactions, nil
+}
+
+// AssignIDs sets a stable fingerprint on each transaction so re-imported statements can be detected.
+// Identical transactions within one statement are numbered by occurrence so they stay distinct.
+func AssignIDs(transactions []Transaction) {
+	seen := make(map[string]int)
+	for i := range transactions {
+		t := &transactions[i]
+		key := strings.Join([]string{
+			t.Date,
+			strconv.FormatFloat(t.Amount, 'f', 2, 64),
+			strings.Join(strings.Fields(strings.ToLower(t.Description)), " "),
+			t.Reference,
+		}, "|")
+		seen[key]++
+
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%s#%d", key, seen[key])))
+		t.ID = hex.EncodeToString(sum[:8])
+	}
+}
+
+// Deduplicate splits transactions into those not yet imported and those whose ID is already known
+func Deduplicate(transactions []Transaction, imported map[string]bool) (fresh, duplicates []Transaction) {
+	fresh, duplicates = []Transaction{}, []Transaction{}
+	for _, t := range transactions {
+		if imported[t.ID] {
+			duplicates = append(duplicates, t)
+		} else {
+			fresh = append(fresh, t)
+		}
+	}
+	return fresh, duplicates
+}
+
+// parseDate parses a statement date with the configured layout, or the common layouts when none is set
+func parseDate(value, layout string) (string, error) {
+	value = strings.TrimSpace(value)
+	layouts := dateLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+	for _, l := range layouts {
+		if date, err := time.Parse(l, value); err == nil {
+			return date.Format(DateLayout), nil
+		}
+	}
+	return "", fmt.Errorf("%w: unrecognized date %q", ErrInvalidStatement, value)
+}
+
+// parseAmount parses a statement amount, accepting currency symbols,
+// thousands separators and accounting-style parentheses for negatives
+func parseAmount(value string) (float64, error) {
+	cleaned := strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(cleaned, "(") && strings.HasSuffix(cleaned, ")") {
+		negative = true
+		cleaned = strings.Trim(cleaned, "()")
+	}
+	cleaned = strings.NewReplacer("$", "", ",", "", " ", "").Replace(cleaned)
+	if cleaned == "" {
+		return 0, nil
+	}
+
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid amount %q", ErrInvalidStatement, value)
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parseQIF parses a Quicken Interchange Format statement. Each record is a
+// series of single-letter field lines terminated by "^"; split lines are ignored.
+func parseQIF(r io.Reader, opts Options) ([]Transaction, error) {
+	scanner := bufio.NewScanner(r)
+	transactions := []Transaction{}
+
+	var current Transaction
+	var hasDate, hasAmount bool
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "!") {
+			continue
+		}
+
+		code, value := text[0], strings.TrimSpace(text[1:])
+		switch code {
+		case 'D':
+			// Quicken writes dates such as 1/15'24 for years after 1999
+			normalized := strings.ReplaceAll(strings.ReplaceAll(value, " ", ""), "'", "/")
+			date, err := parseDate(normalized, opts.DateFormat)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			current.Date = date
+			hasDate = true
+		case 'T', 'U':
+			amount, err := parseAmount(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			current.Amount = amount
+			hasAmount = true
+		case 'P':
+			current.Description = value
+		case 'M':
+			current.Memo = value
+		case 'N':
+			current.Reference = value
+		case 'L':
+			current.Category = value
+		case '^':
+			if !hasDate || !hasAmount {
+				return nil, fmt.Errorf("line %d: %w: record is missing a date or amount", line, ErrInvalidStatement)
+			}
+			if current.Description == "" {
+				current.Description = current.Memo
+			}
+			transactions = append(transactions, current)
+			current = Transaction{}
+			hasDate, hasAmount = false, false
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	// Tolerate a final record without a terminating "^"
+	if hasDate && hasAmount {
+		if current.Description == "" {
+			current.Description = current.Memo
+		}
+		transactions = append(transactions, current)
+	}
+	return transactions, nil
+}
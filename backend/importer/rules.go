@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidRule is returned when a categorization rule is incomplete or its pattern does not compile
+var ErrInvalidRule = errors.New("invalid categorization rule")
+
+// Rule assigns CategoryID to transactions whose description, memo or statement category
+// contains the Contains text (case-insensitive) or matches the Pattern regular expression
+type Rule struct {
+	Contains   string `json:"contains,omitempty"`
+	Pattern    string `json:"pattern,omitempty"`
+	CategoryID string `json:"categoryId"`
+}
+
+// Validate checks that the rule has a matcher and a category
+func (r Rule) Validate() error {
+	if r.Contains == "" && r.Pattern == "" {
+		return fmt.Errorf("%w: contains or pattern is required", ErrInvalidRule)
+	}
+	if r.CategoryID == "" {
+		return fmt.Errorf("%w: categoryId is required", ErrInvalidRule)
+	}
+	if r.Pattern != "" {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+	return nil
+}
+
+// Categorize sets CategoryID on uncategorized transactions using the first matching rule
+func Categorize(transactions []Transaction, rules []Rule) error {
+	patterns := make([]*regexp.Regexp, len(rules))
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if rule.Pattern != "" {
+			patterns[i] = regexp.MustCompile("(?i)" + rule.Pattern)
+		}
+	}
+
+	for i := range transactions {
+		t := &transactions[i]
+		if t.CategoryID != "" {
+			continue
+		}
+		text := strings.ToLower(strings.Join([]string{t.Description, t.Memo, t.Category}, " "))
+		for j, rule := range rules {
+			if (rule.Contains != "" && strings.Contains(text, strings.ToLower(rule.Contains))) ||
+				(patterns[j] != nil && patterns[j].MatchString(text)) {
+				t.CategoryID = rule.CategoryID
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"financehub/handlers"
+	"financehub/logging"
+	"financehub/server"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -17,40 +24,52 @@ func main() {
 		log.Println("No .env file found, using system environment variables")
 	}
 
-	// Initialize Gin router
-	router := gin.Default()
+	// Shut down on an interrupt or a termination request from the service manager
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Run a subcommand instead of the server when one is given
+	if len(os.Args) > 1 && os.Args[1] == "backtest" {
+		if err := runBacktest(ctx, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Write structured logs as configured by the LOG_* variables
+	logConfig, err := logging.ConfigFromEnv()
+	logger, closer, setupErr := logging.Setup(logConfig)
+	if setupErr != nil {
+		log.Fatalf("Failed to open log file: %v", setupErr)
+	}
+	defer closer.Close()
+	if err != nil {
+		logger.Warn("Using default logging settings", "error", err)
+	}
+
+	// Initialize Gin router, logging each request with its request ID
+	router := gin.New()
+	router.Use(gin.Recovery(), logging.Middleware(logger))
 
 	// Configure CORS
 	config := cors.DefaultConfig()
 	config.AllowOrigins = []string{"http://localhost:5173", "http://localhost:3000"}
 	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", logging.RequestIDHeader}
+	config.ExposeHeaders = []string{logging.RequestIDHeader}
 	router.Use(cors.New(config))
 
 	// Initialize handlers
 	h := handlers.NewHandler()
 
-	// API routes
-	api := router.Group("/api")
-	{
-		// Health check
-		api.GET("/health", h.HealthCheck)
-
-		// Topics
-		api.GET("/topics", h.GetAllTopics)
-		api.GET("/topics/:id", h.GetTopicByID)
+	// Keep the market overview warm in the background
+	h.Market.Start(ctx, 15*time.Minute)
 
-		// Stocks
-		api.GET("/stocks/:symbol", h.GetStockQuote)
-		api.GET("/stocks/:symbol/timeseries", h.GetStockTimeSeries)
+	// Run the background data synchronization jobs
+	h.Jobs.Start(ctx)
 
-		// Cryptocurrencies
-		api.GET("/crypto/top", h.GetTopCryptos)
-		api.GET("/crypto/:id", h.GetCryptoPrice)
-
-		// Currency Exchange
-		api.GET("/currency/:from/:to", h.GetCurrencyRate)
-	}
+	// API routes
+	h.RegisterRoutes(router)
 
 	// Get port from environment or use default
 	port := os.Getenv("PORT")
@@ -58,9 +77,27 @@ func main() {
 		port = "8080"
 	}
 
-	// Start server
-	log.Printf("Finance Hub API server starting on port %s", port)
-	if err := router.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	shutdownTimeout, err := server.ShutdownTimeoutFromEnv()
+	if err != nil {
+		logger.Warn("Using the default shutdown timeout", "error", err)
+	}
+
+	// Start server, draining requests in flight when shutting down
+	srv := &http.Server{
+		Addr:              ":" + port,
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	logger.Info("Finance Hub API server starting", "port", port)
+	err = server.Serve(ctx, srv, shutdownTimeout)
+
+	// Stop the background work, waiting for jobs in progress
+	h.Market.Stop()
+	h.Jobs.Stop()
+	if err != nil {
+		logger.Error("Finance Hub API server failed", "error", err)
+		closer.Close()
+		os.Exit(1)
 	}
+	logger.Info("Finance Hub API server stopped")
 }
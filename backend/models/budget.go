@@ -0,0 +1,101 @@
+package models
+
+// Category kinds
+const (
+	CategoryIncome  = "income"
+	CategoryExpense = "expense"
+)
+
+// Rollover rules controlling how unused or overspent budget carries into the next month
+const (
+	RolloverNone    = "none"    // every month starts from its own budget
+	RolloverSurplus = "surplus" // unspent budget carries forward, overspending does not
+	RolloverFull    = "full"    // both unspent budget and overspending carry forward
+)
+
+// Category represents a budgeting category such as groceries or salary
+type Category struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Rollover string `json:"rollover"`
+}
+
+// Budget represents a monthly budget amount for a category, effective from a month onward
+type Budget struct {
+	CategoryID    string  `json:"categoryId"`
+	Amount        float64 `json:"amount"`
+	EffectiveFrom string  `json:"effectiveFrom"`
+}
+
+// Expense represents a recorded income or expense transaction
+type Expense struct {
+	ID          int64   `json:"id"`
+	CategoryID  string  `json:"categoryId"`
+	Kind        string  `json:"kind"`
+	Amount      float64 `json:"amount"`
+	Date        string  `json:"date"`
+	Description string  `json:"description,omitempty"`
+	ImportID    string  `json:"importId,omitempty"`
+}
+
+// ImportResult represents the outcome of importing a bank statement into the budget.
+// Uncategorized transactions are not recorded and keep their ImportID so they can be recorded later.
+type ImportResult struct {
+	Imported      []Expense `json:"imported"`
+	Duplicates    []Expense `json:"duplicates"`
+	Uncategorized []Expense `json:"uncategorized"`
+}
+
+// CategoryRollup represents a category's activity versus budget for a month
+type CategoryRollup struct {
+	CategoryID  string  `json:"categoryId"`
+	Name        string  `json:"name"`
+	Kind        string  `json:"kind"`
+	Budgeted    float64 `json:"budgeted"`
+	CarriedOver float64 `json:"carriedOver"`
+	Available   float64 `json:"available"`
+	Actual      float64 `json:"actual"`
+	Remaining   float64 `json:"remaining"`
+	PercentUsed float64 `json:"percentUsed"`
+	OverBudget  bool    `json:"overBudget"`
+}
+
+// MonthlyRollup represents income, expenses and per-category budget status for a month
+type MonthlyRollup struct {
+	Month         string           `json:"month"`
+	TotalIncome   float64          `json:"totalIncome"`
+	TotalExpenses float64          `json:"totalExpenses"`
+	Net           float64          `json:"net"`
+	SavingsRate   float64          `json:"savingsRate"`
+	TotalBudgeted float64          `json:"totalBudgeted"`
+	Categories    []CategoryRollup `json:"categories"`
+}
+
+// MonthlyTotals represents income and expense totals for a single month
+type MonthlyTotals struct {
+	Month       string  `json:"month"`
+	Income      float64 `json:"income"`
+	Expenses    float64 `json:"expenses"`
+	Net         float64 `json:"net"`
+	SavingsRate float64 `json:"savingsRate"`
+}
+
+// CategoryTrend represents a category's spending across a range of months
+type CategoryTrend struct {
+	CategoryID    string    `json:"categoryId"`
+	Name          string    `json:"name"`
+	Kind          string    `json:"kind"`
+	Monthly       []float64 `json:"monthly"`
+	Average       float64   `json:"average"`
+	ChangePercent float64   `json:"changePercent"`
+}
+
+// BudgetTrends represents income, expense and category trends over recent months, oldest first
+type BudgetTrends struct {
+	Months          []MonthlyTotals `json:"months"`
+	AverageIncome   float64         `json:"averageIncome"`
+	AverageExpenses float64         `json:"averageExpenses"`
+	AverageNet      float64         `json:"averageNet"`
+	Categories      []CategoryTrend `json:"categories"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// EconomicDataPoint represents a single observation of an economic indicator
+type EconomicDataPoint struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+// EconomicSeries represents a time series for a macroeconomic indicator, newest first
+type EconomicSeries struct {
+	Indicator string              `json:"indicator"`
+	Name      string              `json:"name"`
+	Interval  string              `json:"interval"`
+	Unit      string              `json:"unit"`
+	Maturity  string              `json:"maturity,omitempty"`
+	Data      []EconomicDataPoint `json:"data"`
+}
+
+// EconomicIndicatorInfo describes an available macroeconomic indicator
+type EconomicIndicatorInfo struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Intervals  []string `json:"intervals"`
+	Maturities []string `json:"maturities,omitempty"`
+}
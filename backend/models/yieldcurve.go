@@ -0,0 +1,29 @@
+package models
+
+// YieldCurvePoint represents the yield at a single tenor on the curve
+type YieldCurvePoint struct {
+	Tenor        string  `json:"tenor"`
+	Years        float64 `json:"years"`
+	Yield        float64 `json:"yield"`
+	ObservedOn   string  `json:"observedOn,omitempty"`
+	Interpolated bool    `json:"interpolated"`
+}
+
+// YieldSpread represents the difference between two tenors in percentage points
+type YieldSpread struct {
+	Name        string  `json:"name"`
+	Long        string  `json:"long"`
+	Short       string  `json:"short"`
+	Value       float64 `json:"value"`
+	BasisPoints float64 `json:"basisPoints"`
+	Inverted    bool    `json:"inverted"`
+}
+
+// YieldCurve represents a Treasury yield curve snapshot for a date
+type YieldCurve struct {
+	Date     string            `json:"date"`
+	Points   []YieldCurvePoint `json:"points"`
+	Spreads  []YieldSpread     `json:"spreads"`
+	Shape    string            `json:"shape"`
+	Inverted bool              `json:"inverted"`
+}
@@ -0,0 +1,115 @@
+package planning
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// defaultSimulations is used when a request does not specify a simulation count
+const defaultSimulations = 1000
+
+// PercentileBand holds the balance distribution across simulations for one year
+type PercentileBand struct {
+	Age  int     `json:"age"`
+	Year int     `json:"year"`
+	P10  float64 `json:"p10"`
+	P25  float64 `json:"p25"`
+	P50  float64 `json:"p50"`
+	P75  float64 `json:"p75"`
+	P90  float64 `json:"p90"`
+}
+
+// SimulationResult summarizes a Monte Carlo simulation
+type SimulationResult struct {
+	Simulations          int              `json:"simulations"`
+	Seed                 int64            `json:"seed"`
+	ProbabilityOfSuccess float64          `json:"probabilityOfSuccess"`
+	MedianEndingBalance  float64          `json:"medianEndingBalance"`
+	MedianDepletionAge   int              `json:"medianDepletionAge,omitempty"`
+	Bands                []PercentileBand `json:"bands"`
+}
+
+// Simulate runs Monte Carlo simulations drawing annual returns from a normal distribution
+// with the expected return and volatility. A simulation succeeds when the balance stays
+// positive through EndAge. The same seed always produces the same result.
+func Simulate(req PlanRequest) (*SimulationResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	simulations := req.Simulations
+	if simulations == 0 {
+		simulations = defaultSimulations
+	}
+	years := req.EndAge - req.CurrentAge
+	rng := rand.New(rand.NewSource(req.Seed))
+
+	result := &SimulationResult{
+		Simulations: simulations,
+		Seed:        req.Seed,
+		Bands:       make([]PercentileBand, 0, years),
+	}
+
+	// Simulations advance a year at a time so that only their current balances are kept, along
+	// with a sorted copy for the year's percentiles
+	balances := make([]float64, simulations)
+	for sim := range balances {
+		balances[sim] = req.InitialBalance
+	}
+	depleted := make([]bool, simulations)
+	sorted := make([]float64, simulations)
+	var depletionAges []int
+	for year := 1; year <= years; year++ {
+		contribution, withdrawal := req.cashFlows(year)
+		for sim, balance := range balances {
+			if depleted[sim] {
+				continue
+			}
+			annualReturn := math.Max(req.ExpectedReturn+req.Volatility*rng.NormFloat64(), -1)
+			balance = balance*(1+annualReturn) + contribution - withdrawal
+			if balance <= 0 && req.AnnualWithdrawal > 0 {
+				balance = 0
+				depleted[sim] = true
+				depletionAges = append(depletionAges, req.CurrentAge+year)
+			}
+			balances[sim] = balance
+		}
+
+		copy(sorted, balances)
+		sort.Float64s(sorted)
+		result.Bands = append(result.Bands, PercentileBand{
+			Age:  req.CurrentAge + year,
+			Year: year,
+			P10:  round2(Percentile(sorted, 10)),
+			P25:  round2(Percentile(sorted, 25)),
+			P50:  round2(Percentile(sorted, 50)),
+			P75:  round2(Percentile(sorted, 75)),
+			P90:  round2(Percentile(sorted, 90)),
+		})
+	}
+	result.ProbabilityOfSuccess = float64(simulations-len(depletionAges)) / float64(simulations)
+	result.MedianEndingBalance = result.Bands[len(result.Bands)-1].P50
+
+	// Only report a median depletion age when most simulations run out of money
+	if len(depletionAges)*2 > simulations {
+		sort.Ints(depletionAges)
+		result.MedianDepletionAge = depletionAges[simulations/2]
+	}
+
+	return result, nil
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted values using linear interpolation
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
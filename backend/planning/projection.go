@@ -0,0 +1,137 @@
+// Package planning provides retirement and compound growth projections, both deterministic
+// and Monte Carlo. Rates are annual decimals (0.07 = 7%) and cash flows occur at year end.
+package planning
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidPlan is returned when projection inputs are missing or out of range
+var ErrInvalidPlan = errors.New("invalid plan")
+
+// maxSimulations bounds the work done by a single Monte Carlo request
+const maxSimulations = 100000
+
+// PlanRequest describes savings and retirement assumptions. Contributions are made each year
+// before RetirementAge and grow by ContributionGrowth; withdrawals are stated in today's dollars
+// and start at RetirementAge, rising with inflation.
+type PlanRequest struct {
+	CurrentAge         int     `json:"currentAge"`
+	RetirementAge      int     `json:"retirementAge"`
+	EndAge             int     `json:"endAge"`
+	InitialBalance     float64 `json:"initialBalance"`
+	AnnualContribution float64 `json:"annualContribution"`
+	ContributionGrowth float64 `json:"contributionGrowth"`
+	AnnualWithdrawal   float64 `json:"annualWithdrawal"`
+	ExpectedReturn     float64 `json:"expectedReturn"`
+	Volatility         float64 `json:"volatility"`
+	InflationRate      float64 `json:"inflationRate"`
+	Simulations        int     `json:"simulations"`
+	Seed               int64   `json:"seed"`
+}
+
+// ProjectionYear is a single year of a deterministic projection
+type ProjectionYear struct {
+	Age          int     `json:"age"`
+	Year         int     `json:"year"`
+	Contribution float64 `json:"contribution"`
+	Withdrawal   float64 `json:"withdrawal"`
+	Growth       float64 `json:"growth"`
+	Balance      float64 `json:"balance"`
+	RealBalance  float64 `json:"realBalance"`
+}
+
+// Projection is the result of a deterministic compound-growth projection
+type Projection struct {
+	Years               []ProjectionYear `json:"years"`
+	TotalContributions  float64          `json:"totalContributions"`
+	TotalWithdrawals    float64          `json:"totalWithdrawals"`
+	TotalGrowth         float64          `json:"totalGrowth"`
+	BalanceAtRetirement float64          `json:"balanceAtRetirement"`
+	EndingBalance       float64          `json:"endingBalance"`
+	DepletionAge        int              `json:"depletionAge,omitempty"`
+}
+
+// Validate checks that the plan inputs are usable
+func (r PlanRequest) Validate() error {
+	switch {
+	case r.CurrentAge < 0 || r.EndAge <= r.CurrentAge:
+		return fmt.Errorf("%w: end age must be after current age", ErrInvalidPlan)
+	case r.EndAge-r.CurrentAge > 120:
+		return fmt.Errorf("%w: projection cannot exceed 120 years", ErrInvalidPlan)
+	case r.RetirementAge < r.CurrentAge || r.RetirementAge > r.EndAge:
+		return fmt.Errorf("%w: retirement age must be between current and end age", ErrInvalidPlan)
+	case r.InitialBalance < 0 || r.AnnualContribution < 0 || r.AnnualWithdrawal < 0:
+		return fmt.Errorf("%w: balances, contributions and withdrawals cannot be negative", ErrInvalidPlan)
+	case r.ExpectedReturn <= -1 || r.Volatility < 0:
+		return fmt.Errorf("%w: expected return must exceed -100%% and volatility cannot be negative", ErrInvalidPlan)
+	case r.Simulations < 0 || r.Simulations > maxSimulations:
+		return fmt.Errorf("%w: simulations must be between 0 and %d", ErrInvalidPlan, maxSimulations)
+	}
+	return nil
+}
+
+// Project runs a deterministic projection using the expected return every year
+func Project(req PlanRequest) (*Projection, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	projection := &Projection{Years: []ProjectionYear{}}
+	balance := req.InitialBalance
+	for year := 1; year <= req.EndAge-req.CurrentAge; year++ {
+		age := req.CurrentAge + year
+		contribution, withdrawal := req.cashFlows(year)
+
+		growth := balance * req.ExpectedReturn
+		balance += growth + contribution
+		if withdrawal > balance {
+			withdrawal = balance
+		}
+		balance -= withdrawal
+
+		if balance <= 0 && projection.DepletionAge == 0 && req.AnnualWithdrawal > 0 {
+			projection.DepletionAge = age
+		}
+
+		projection.Years = append(projection.Years, ProjectionYear{
+			Age:          age,
+			Year:         year,
+			Contribution: round2(contribution),
+			Withdrawal:   round2(withdrawal),
+			Growth:       round2(growth),
+			Balance:      round2(balance),
+			RealBalance:  round2(balance / math.Pow(1+req.InflationRate, float64(year))),
+		})
+		projection.TotalContributions += contribution
+		projection.TotalWithdrawals += withdrawal
+		projection.TotalGrowth += growth
+		if age == req.RetirementAge {
+			projection.BalanceAtRetirement = round2(balance)
+		}
+	}
+
+	if req.RetirementAge == req.CurrentAge {
+		projection.BalanceAtRetirement = round2(req.InitialBalance)
+	}
+	projection.TotalContributions = round2(projection.TotalContributions)
+	projection.TotalWithdrawals = round2(projection.TotalWithdrawals)
+	projection.TotalGrowth = round2(projection.TotalGrowth)
+	projection.EndingBalance = round2(balance)
+	return projection, nil
+}
+
+// cashFlows returns the contribution and inflation-adjusted withdrawal for a projection year
+func (r PlanRequest) cashFlows(year int) (float64, float64) {
+	age := r.CurrentAge + year
+	if age <= r.RetirementAge {
+		return r.AnnualContribution * math.Pow(1+r.ContributionGrowth, float64(year-1)), 0
+	}
+	return 0, r.AnnualWithdrawal * math.Pow(1+r.InflationRate, float64(year))
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
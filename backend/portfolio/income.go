@@ -0,0 +1,195 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// IncomeRequest describes the holdings history and corporate actions to evaluate.
+// AsOf defaults to the latest transaction or corporate action date.
+type IncomeRequest struct {
+	Transactions []Transaction     `json:"transactions"`
+	Actions      []CorporateAction `json:"actions"`
+	AsOf         string            `json:"asOf,omitempty"`
+}
+
+// DividendPayment is the dividend received on the shares held before an ex-date
+type DividendPayment struct {
+	Date     string  `json:"date"`
+	Shares   float64 `json:"shares"`
+	PerShare float64 `json:"perShare"`
+	Amount   float64 `json:"amount"`
+}
+
+// HoldingIncome is the dividend history and projected income of one holding.
+// TrailingDividend is the per-share dividend over the last twelve months, adjusted to today's
+// shares; projected income assumes it is repeated over the next year. CostBasis is the average
+// cost of the shares held.
+type HoldingIncome struct {
+	Symbol                string            `json:"symbol"`
+	Shares                float64           `json:"shares"`
+	CostBasis             float64           `json:"costBasis"`
+	TotalIncome           float64           `json:"totalIncome"`
+	TrailingDividend      float64           `json:"trailingDividend"`
+	ProjectedAnnualIncome float64           `json:"projectedAnnualIncome"`
+	YieldOnCost           float64           `json:"yieldOnCost"`
+	Payments              []DividendPayment `json:"payments"`
+}
+
+// YearIncome is the dividend income received in a calendar year
+type YearIncome struct {
+	Year   int     `json:"year"`
+	Amount float64 `json:"amount"`
+}
+
+// IncomeReport holds dividend income per holding and by year, with portfolio totals
+type IncomeReport struct {
+	AsOf                  string          `json:"asOf"`
+	TotalIncome           float64         `json:"totalIncome"`
+	ProjectedAnnualIncome float64         `json:"projectedAnnualIncome"`
+	CostBasis             float64         `json:"costBasis"`
+	YieldOnCost           float64         `json:"yieldOnCost"`
+	Holdings              []HoldingIncome `json:"holdings"`
+	ByYear                []YearIncome    `json:"byYear"`
+}
+
+// CalculateIncome replays transactions and corporate actions through AsOf, paying each dividend
+// on the shares held before its ex-date and applying splits to the shares held
+func CalculateIncome(req IncomeRequest) (*IncomeReport, error) {
+	actions, err := sortedActions(req.Actions)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := sortedTransactions(req.Transactions, splitsOnly(actions))
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := req.AsOf
+	if asOf == "" {
+		asOf = transactions[len(transactions)-1].Date
+		if len(actions) > 0 && actions[len(actions)-1].Date > asOf {
+			asOf = actions[len(actions)-1].Date
+		}
+	}
+	asOfDate, err := time.Parse(DateLayout, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("%w: as-of date must be in YYYY-MM-DD format", ErrInvalidPortfolio)
+	}
+	yearAgo := asOfDate.AddDate(-1, 0, 0).Format(DateLayout)
+
+	holdings := make(map[string]*HoldingIncome)
+	holding := func(symbol string) *HoldingIncome {
+		if holdings[symbol] == nil {
+			holdings[symbol] = &HoldingIncome{Symbol: symbol, Payments: []DividendPayment{}}
+		}
+		return holdings[symbol]
+	}
+	byYear := make(map[int]float64)
+
+	var splits []CorporateAction
+	next := 0
+	for _, action := range actions {
+		if action.Date > asOf {
+			break
+		}
+		// Trades before the ex-date determine the shares that receive a dividend
+		for ; next < len(transactions) && transactions[next].Date < action.Date; next++ {
+			trade(holding(transactions[next].Symbol), transactions[next])
+		}
+
+		h := holdings[action.Symbol]
+		if action.Type == Split {
+			splits = append(splits, action)
+			if h != nil {
+				h.Shares *= action.Ratio
+			}
+			continue
+		}
+		if h == nil || h.Shares <= quantityTolerance {
+			continue
+		}
+		amount := h.Shares * action.Amount
+		h.TotalIncome += amount
+		h.Payments = append(h.Payments, DividendPayment{
+			Date:     action.Date,
+			Shares:   roundTo(h.Shares, 6),
+			PerShare: action.Amount,
+			Amount:   roundTo(amount, 2),
+		})
+		year, _ := strconv.Atoi(action.Date[:4])
+		byYear[year] += amount
+	}
+	for ; next < len(transactions) && transactions[next].Date <= asOf; next++ {
+		trade(holding(transactions[next].Symbol), transactions[next])
+	}
+
+	// Trailing dividends per share, restated in shares outstanding as of the report date
+	trailing := make(map[string]float64)
+	for _, action := range actions {
+		if action.Type == Dividend && action.Date > yearAgo && action.Date <= asOf {
+			trailing[action.Symbol] += action.Amount / splitFactor(splits, action.Symbol, action.Date)
+		}
+	}
+
+	report := &IncomeReport{
+		AsOf:     asOf,
+		Holdings: []HoldingIncome{},
+		ByYear:   []YearIncome{},
+	}
+	for _, h := range holdings {
+		if h.Shares <= quantityTolerance && h.TotalIncome == 0 {
+			continue
+		}
+		if h.Shares <= quantityTolerance {
+			h.Shares, h.CostBasis = 0, 0
+		}
+		h.TrailingDividend = roundTo(trailing[h.Symbol], 6)
+		projected := h.Shares * trailing[h.Symbol]
+		if h.CostBasis > 0 {
+			h.YieldOnCost = roundTo(projected/h.CostBasis, 6)
+		}
+		report.TotalIncome += h.TotalIncome
+		report.ProjectedAnnualIncome += projected
+		report.CostBasis += h.CostBasis
+
+		h.Shares = roundTo(h.Shares, 6)
+		h.CostBasis = roundTo(h.CostBasis, 2)
+		h.TotalIncome = roundTo(h.TotalIncome, 2)
+		h.ProjectedAnnualIncome = roundTo(projected, 2)
+		report.Holdings = append(report.Holdings, *h)
+	}
+	sort.Slice(report.Holdings, func(i, j int) bool {
+		return report.Holdings[i].Symbol < report.Holdings[j].Symbol
+	})
+
+	for year, amount := range byYear {
+		report.ByYear = append(report.ByYear, YearIncome{Year: year, Amount: roundTo(amount, 2)})
+	}
+	sort.Slice(report.ByYear, func(i, j int) bool {
+		return report.ByYear[i].Year < report.ByYear[j].Year
+	})
+
+	if report.CostBasis > 0 {
+		report.YieldOnCost = roundTo(report.ProjectedAnnualIncome/report.CostBasis, 6)
+	}
+	report.TotalIncome = roundTo(report.TotalIncome, 2)
+	report.ProjectedAnnualIncome = roundTo(report.ProjectedAnnualIncome, 2)
+	report.CostBasis = roundTo(report.CostBasis, 2)
+	return report, nil
+}
+
+// trade applies a buy or sell to a holding's shares and average cost basis
+func trade(h *HoldingIncome, t Transaction) {
+	if t.Type == Buy {
+		h.Shares += t.Quantity
+		h.CostBasis += t.Quantity*t.Price + t.Fees
+		return
+	}
+	if h.Shares > 0 {
+		h.CostBasis -= h.CostBasis * t.Quantity / h.Shares
+	}
+	h.Shares -= t.Quantity
+}
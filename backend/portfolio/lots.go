@@ -0,0 +1,362 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Lot relief methods
+const (
+	FIFO       = "fifo"
+	LIFO       = "lifo"
+	HIFO       = "hifo"
+	SpecificID = "specific"
+)
+
+// Holding period terms
+const (
+	ShortTerm = "short"
+	LongTerm  = "long"
+)
+
+// washSaleWindow is the number of days before and after a loss sale in which buying
+// the same security makes it a wash sale
+const washSaleWindow = 30
+
+// quantityTolerance absorbs floating point error when matching share quantities
+const quantityTolerance = 1e-9
+
+// LotSelection picks shares from a lot to relieve on a sell
+type LotSelection struct {
+	LotID    string  `json:"lotId"`
+	Quantity float64 `json:"quantity"`
+}
+
+// Lot is shares acquired by one buy. Quantity and CostBasis are what remains unsold.
+// HoldingDate starts the holding period and is earlier than AcquiredDate for wash-sale
+// replacement shares, which also carry the disallowed loss in WashSaleAdjustment.
+type Lot struct {
+	ID                 string  `json:"id"`
+	Symbol             string  `json:"symbol"`
+	AcquiredDate       string  `json:"acquiredDate"`
+	HoldingDate        string  `json:"holdingDate"`
+	Quantity           float64 `json:"quantity"`
+	CostBasis          float64 `json:"costBasis"`
+	WashSaleAdjustment float64 `json:"washSaleAdjustment,omitempty"`
+
+	buy int
+}
+
+// RealizedLot is the sale of shares from one lot. Gain is proceeds less cost basis plus any
+// loss disallowed by the wash-sale rule.
+type RealizedLot struct {
+	Symbol         string  `json:"symbol"`
+	LotID          string  `json:"lotId"`
+	Quantity       float64 `json:"quantity"`
+	AcquiredDate   string  `json:"acquiredDate"`
+	SoldDate       string  `json:"soldDate"`
+	Proceeds       float64 `json:"proceeds"`
+	CostBasis      float64 `json:"costBasis"`
+	DisallowedLoss float64 `json:"disallowedLoss,omitempty"`
+	Gain           float64 `json:"gain"`
+	Term           string  `json:"term"`
+	WashSale       bool    `json:"washSale"`
+}
+
+// washAdjustment is a disallowed loss to add to replacement shares
+type washAdjustment struct {
+	quantity    float64
+	amount      float64
+	holdingDays int
+}
+
+// lotLedger relieves lots as transactions are replayed in date order
+type lotLedger struct {
+	transactions []Transaction
+	splits       splitCursor
+	method       string
+	lots         []*Lot
+	ids          map[string]bool
+	pending      map[int][]washAdjustment
+	replaced     map[int]float64
+	realized     []RealizedLot
+}
+
+func newLotLedger(transactions []Transaction, splits []CorporateAction, method string) *lotLedger {
+	return &lotLedger{
+		transactions: transactions,
+		splits:       splitCursor{splits: splits},
+		method:       method,
+		ids:          make(map[string]bool),
+		pending:      make(map[int][]washAdjustment),
+		replaced:     make(map[int]float64),
+	}
+}
+
+// run replays every transaction, then applies any later splits to the open lots
+func (l *lotLedger) run() error {
+	for k, t := range l.transactions {
+		l.splits.through(t.Date, l.split)
+		if t.Type == Buy {
+			l.buy(k)
+			continue
+		}
+		if err := l.sell(k); err != nil {
+			return err
+		}
+	}
+	for _, split := range l.splits.splits[l.splits.next:] {
+		l.split(split)
+	}
+	return nil
+}
+
+// split multiplies the shares of a symbol's open lots, leaving their cost basis unchanged
+func (l *lotLedger) split(split CorporateAction) {
+	for _, lot := range l.lots {
+		if lot.Symbol == split.Symbol && lot.Quantity > 0 {
+			lot.Quantity *= split.Ratio
+		}
+	}
+}
+
+// buy opens a lot and applies wash-sale adjustments from earlier loss sales it replaces
+func (l *lotLedger) buy(k int) {
+	t := l.transactions[k]
+	id := t.ID
+	if id == "" {
+		id = t.Symbol + "-" + t.Date
+	}
+	lot := &Lot{
+		ID:           l.uniqueID(id),
+		Symbol:       t.Symbol,
+		AcquiredDate: t.Date,
+		HoldingDate:  t.Date,
+		Quantity:     t.Quantity,
+		CostBasis:    t.Quantity*t.Price + t.Fees,
+		buy:          k,
+	}
+	l.lots = append(l.lots, lot)
+	for _, adjustment := range l.pending[k] {
+		l.applyWashSale(lot, adjustment)
+	}
+}
+
+// sell relieves lots for a sell and records the realized gain on each
+func (l *lotLedger) sell(k int) error {
+	t := l.transactions[k]
+	selections, err := l.selectLots(t)
+	if err != nil {
+		return err
+	}
+
+	for _, selection := range selections {
+		lot, quantity := selection.lot, selection.quantity
+		proceeds := quantity*t.Price - t.Fees*quantity/t.Quantity
+		cost := lot.CostBasis * quantity / lot.Quantity
+		lot.Quantity -= quantity
+		lot.CostBasis -= cost
+		if lot.Quantity < quantityTolerance {
+			lot.Quantity, lot.CostBasis = 0, 0
+		}
+
+		realized := RealizedLot{
+			Symbol:       t.Symbol,
+			LotID:        lot.ID,
+			Quantity:     quantity,
+			AcquiredDate: lot.AcquiredDate,
+			SoldDate:     t.Date,
+			Proceeds:     proceeds,
+			CostBasis:    cost,
+			Gain:         proceeds - cost,
+			Term:         holdingTerm(lot.HoldingDate, t.Date),
+		}
+		if realized.Gain < 0 {
+			l.washSale(k, lot, &realized)
+		}
+		l.realized = append(l.realized, realized)
+	}
+	return nil
+}
+
+type lotQuantity struct {
+	lot      *Lot
+	quantity float64
+}
+
+// selectLots returns the lots and quantities a sell relieves, from its selected lots
+// or by the relief method
+func (l *lotLedger) selectLots(t Transaction) ([]lotQuantity, error) {
+	if len(t.Lots) > 0 {
+		var selections []lotQuantity
+		total := 0.0
+		for _, selection := range t.Lots {
+			lot := l.findLot(t.Symbol, selection.LotID)
+			if lot == nil {
+				return nil, fmt.Errorf("%w: %s has no open lot %s on %s", ErrInvalidPortfolio, t.Symbol, selection.LotID, t.Date)
+			}
+			if selection.Quantity > lot.Quantity+quantityTolerance {
+				return nil, fmt.Errorf("%w: lot %s has %g shares, not %g", ErrInvalidPortfolio, lot.ID, lot.Quantity, selection.Quantity)
+			}
+			selections = append(selections, lotQuantity{lot, math.Min(selection.Quantity, lot.Quantity)})
+			total += selection.Quantity
+		}
+		if math.Abs(total-t.Quantity) > quantityTolerance {
+			return nil, fmt.Errorf("%w: selected lots for the %s sell on %s total %g, not %g shares",
+				ErrInvalidPortfolio, t.Symbol, t.Date, total, t.Quantity)
+		}
+		return selections, nil
+	}
+	if l.method == SpecificID {
+		return nil, fmt.Errorf("%w: the %s sell on %s must select lots", ErrInvalidPortfolio, t.Symbol, t.Date)
+	}
+
+	var open []*Lot
+	for _, lot := range l.lots {
+		if lot.Symbol == t.Symbol && lot.Quantity > 0 {
+			open = append(open, lot)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		switch l.method {
+		case LIFO:
+			return open[i].AcquiredDate > open[j].AcquiredDate
+		case HIFO:
+			return open[i].CostBasis/open[i].Quantity > open[j].CostBasis/open[j].Quantity
+		default:
+			return open[i].AcquiredDate < open[j].AcquiredDate
+		}
+	})
+
+	var selections []lotQuantity
+	remaining := t.Quantity
+	for _, lot := range open {
+		if remaining < quantityTolerance {
+			break
+		}
+		quantity := math.Min(remaining, lot.Quantity)
+		selections = append(selections, lotQuantity{lot, quantity})
+		remaining -= quantity
+	}
+	return selections, nil
+}
+
+// washSale disallows a realized loss to the extent the same security was bought within
+// 30 days before or after the sale, and adds it to the basis of the replacement shares.
+// Shares from the lot being sold are not replacements, and each bought share replaces at most once.
+func (l *lotLedger) washSale(k int, sold *Lot, realized *RealizedLot) {
+	t := l.transactions[k]
+	saleDate, _ := time.Parse(DateLayout, t.Date)
+	holdingDate, _ := time.Parse(DateLayout, sold.HoldingDate)
+	lossPerShare := -realized.Gain / realized.Quantity
+	remaining := realized.Quantity
+
+	for j, b := range l.transactions {
+		if remaining < quantityTolerance {
+			break
+		}
+		if b.Type != Buy || b.Symbol != t.Symbol || j == sold.buy {
+			continue
+		}
+		buyDate, _ := time.Parse(DateLayout, b.Date)
+		if days := buyDate.Sub(saleDate).Hours() / 24; math.Abs(days) > washSaleWindow {
+			continue
+		}
+
+		available := b.Quantity - l.replaced[j]
+		var replacement *Lot
+		if j < k {
+			// Already bought: only shares still held and not yet adjusted can replace
+			replacement = l.unadjustedLot(j)
+			if replacement == nil {
+				continue
+			}
+			available = math.Min(available, replacement.Quantity)
+		}
+		quantity := math.Min(available, remaining)
+		if quantity < quantityTolerance {
+			continue
+		}
+
+		adjustment := washAdjustment{
+			quantity:    quantity,
+			amount:      quantity * lossPerShare,
+			holdingDays: int(saleDate.Sub(holdingDate).Hours() / 24),
+		}
+		if replacement != nil {
+			l.applyWashSale(replacement, adjustment)
+		} else {
+			l.pending[j] = append(l.pending[j], adjustment)
+		}
+		l.replaced[j] += quantity
+		remaining -= quantity
+		realized.DisallowedLoss += adjustment.amount
+	}
+
+	if realized.DisallowedLoss > 0 {
+		realized.WashSale = true
+		realized.Gain += realized.DisallowedLoss
+	}
+}
+
+// applyWashSale adds a disallowed loss to replacement shares and tacks the holding period of the
+// sold shares onto them. Replacement shares are split into their own lot when only part of a lot replaces.
+func (l *lotLedger) applyWashSale(lot *Lot, adjustment washAdjustment) {
+	target := lot
+	if adjustment.quantity < lot.Quantity-quantityTolerance {
+		part := *lot
+		part.ID = l.uniqueID(lot.ID + "-W")
+		part.Quantity = adjustment.quantity
+		part.CostBasis = lot.CostBasis * adjustment.quantity / lot.Quantity
+		lot.Quantity -= part.Quantity
+		lot.CostBasis -= part.CostBasis
+		target = &part
+		l.lots = append(l.lots, target)
+	}
+
+	acquired, _ := time.Parse(DateLayout, target.AcquiredDate)
+	target.CostBasis += adjustment.amount
+	target.WashSaleAdjustment += adjustment.amount
+	target.HoldingDate = acquired.AddDate(0, 0, -adjustment.holdingDays).Format(DateLayout)
+}
+
+// unadjustedLot returns the open lot of a buy that has not absorbed a wash-sale adjustment
+func (l *lotLedger) unadjustedLot(buy int) *Lot {
+	for _, lot := range l.lots {
+		if lot.buy == buy && lot.Quantity > 0 && lot.WashSaleAdjustment == 0 {
+			return lot
+		}
+	}
+	return nil
+}
+
+func (l *lotLedger) findLot(symbol, id string) *Lot {
+	for _, lot := range l.lots {
+		if lot.ID == id && lot.Symbol == symbol && lot.Quantity > 0 {
+			return lot
+		}
+	}
+	return nil
+}
+
+// uniqueID returns id, suffixed with a counter if it is already taken
+func (l *lotLedger) uniqueID(id string) string {
+	unique := id
+	for n := 2; l.ids[unique]; n++ {
+		unique = fmt.Sprintf("%s-%d", id, n)
+	}
+	l.ids[unique] = true
+	return unique
+}
+
+// holdingTerm classifies a holding period as long-term when the shares were held more than one year
+func holdingTerm(holdingDate, soldDate string) string {
+	start, _ := time.Parse(DateLayout, holdingDate)
+	sold, _ := time.Parse(DateLayout, soldDate)
+	if sold.After(start.AddDate(1, 0, 0)) {
+		return LongTerm
+	}
+	return ShortTerm
+}
@@ -0,0 +1,243 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PerformanceRequest describes the holdings history and the date range to evaluate.
+// StartDate defaults to the first transaction and EndDate to the last available price.
+// Splits among Actions adjust the shares held on their ex-dates.
+type PerformanceRequest struct {
+	Transactions []Transaction     `json:"transactions"`
+	Actions      []CorporateAction `json:"actions,omitempty"`
+	StartDate    string            `json:"startDate,omitempty"`
+	EndDate      string            `json:"endDate,omitempty"`
+	Benchmark    string            `json:"benchmark,omitempty"`
+}
+
+// PerformancePoint is the portfolio's value and cumulative returns at the close of a trading day
+type PerformancePoint struct {
+	Date             string  `json:"date"`
+	Value            float64 `json:"value"`
+	NetFlow          float64 `json:"netFlow"`
+	CumulativeReturn float64 `json:"cumulativeReturn"`
+	BenchmarkReturn  float64 `json:"benchmarkReturn,omitempty"`
+}
+
+// BenchmarkComparison compares the portfolio's time-weighted return to a benchmark's price return
+type BenchmarkComparison struct {
+	Symbol           string  `json:"symbol"`
+	Return           float64 `json:"return"`
+	AnnualizedReturn float64 `json:"annualizedReturn"`
+	ExcessReturn     float64 `json:"excessReturn"`
+}
+
+// Performance holds portfolio returns over a date range and the daily series for charting.
+// MoneyWeightedReturn is the annual XIRR and is zero when it is undefined.
+type Performance struct {
+	StartDate           string               `json:"startDate"`
+	EndDate             string               `json:"endDate"`
+	StartValue          float64              `json:"startValue"`
+	EndValue            float64              `json:"endValue"`
+	NetContributions    float64              `json:"netContributions"`
+	Gain                float64              `json:"gain"`
+	TimeWeightedReturn  float64              `json:"timeWeightedReturn"`
+	AnnualizedReturn    float64              `json:"annualizedReturn"`
+	MoneyWeightedReturn float64              `json:"moneyWeightedReturn"`
+	Benchmark           *BenchmarkComparison `json:"benchmark,omitempty"`
+	Series              []PerformancePoint   `json:"series"`
+}
+
+// CalculatePerformance values the holdings on every trading day in the range using prices
+// keyed by symbol, and computes time-weighted, annualized and money-weighted returns.
+// Transactions up to the first trading day form the opening holdings; later buys and sells are cash
+// flows at the close of their day. Missing prices fall back to the last trade price.
+// Prices are expected unadjusted for splits, which are applied to the shares held instead.
+func CalculatePerformance(req PerformanceRequest, prices map[string][]PricePoint) (*Performance, error) {
+	actions, err := sortedActions(req.Actions)
+	if err != nil {
+		return nil, err
+	}
+	splits := splitsOnly(actions)
+	transactions, err := sortedTransactions(req.Transactions, splits)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make(map[string][]PricePoint, len(prices))
+	for symbol, points := range prices {
+		series[strings.ToUpper(symbol)] = sortedPrices(points)
+	}
+	benchmark := strings.ToUpper(strings.TrimSpace(req.Benchmark))
+
+	start := req.StartDate
+	if start == "" {
+		start = transactions[0].Date
+	}
+	end := req.EndDate
+	if end == "" {
+		end = transactions[len(transactions)-1].Date
+		for _, points := range series {
+			if len(points) > 0 && points[len(points)-1].Date > end {
+				end = points[len(points)-1].Date
+			}
+		}
+	}
+	for _, date := range []string{start, end} {
+		if _, err := time.Parse(DateLayout, date); err != nil {
+			return nil, fmt.Errorf("%w: dates must be in YYYY-MM-DD format", ErrInvalidPortfolio)
+		}
+	}
+	if start > end {
+		return nil, fmt.Errorf("%w: start date must not be after end date", ErrInvalidPortfolio)
+	}
+
+	dates := tradingDates(series, transactions, start, end)
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("%w: no prices between %s and %s", ErrInvalidPortfolio, start, end)
+	}
+
+	held := make(map[string]float64)
+	lastTrade := make(map[string]float64)
+	value := func(date string) float64 {
+		total := 0.0
+		for symbol, quantity := range held {
+			price, ok := closeOn(series[symbol], date)
+			if !ok {
+				price = lastTrade[symbol]
+			}
+			total += quantity * price
+		}
+		return total
+	}
+
+	perf := &Performance{
+		StartDate: dates[0],
+		EndDate:   dates[len(dates)-1],
+		Series:    make([]PerformancePoint, 0, len(dates)),
+	}
+	cursor := splitCursor{splits: splits}
+	split := func(split CorporateAction) {
+		held[split.Symbol] *= split.Ratio
+		lastTrade[split.Symbol] /= split.Ratio
+	}
+
+	var cashFlows []CashFlow
+	growth, previous, next := 1.0, 0.0, 0
+	for i, date := range dates {
+		flow := 0.0
+		for ; next < len(transactions) && transactions[next].Date <= date; next++ {
+			t := transactions[next]
+			cursor.through(t.Date, split)
+			if t.Type == Sell {
+				held[t.Symbol] -= t.Quantity
+			} else {
+				held[t.Symbol] += t.Quantity
+			}
+			lastTrade[t.Symbol] = t.Price
+			flow += t.CashFlow()
+		}
+		cursor.through(date, split)
+
+		current := value(date)
+		if i == 0 {
+			// Holdings up to and including the first day form the opening value
+			perf.StartValue = current
+			flow = 0
+			if current > 0 {
+				cashFlows = append(cashFlows, CashFlow{Date: date, Amount: -current})
+			}
+		} else {
+			switch {
+			case previous > 0:
+				growth *= (current - flow) / previous
+			case flow > 0:
+				// Money invested into an empty portfolio earns from the trade price
+				growth *= current / flow
+			}
+			perf.NetContributions += flow
+			if flow != 0 {
+				cashFlows = append(cashFlows, CashFlow{Date: date, Amount: -flow})
+			}
+		}
+		previous = current
+
+		perf.Series = append(perf.Series, PerformancePoint{
+			Date:             date,
+			Value:            roundTo(current, 2),
+			NetFlow:          roundTo(flow, 2),
+			CumulativeReturn: roundTo(growth-1, 6),
+		})
+	}
+
+	years := yearsBetween(perf.StartDate, perf.EndDate)
+	perf.EndValue = previous
+	perf.Gain = roundTo(perf.EndValue-perf.StartValue-perf.NetContributions, 2)
+	perf.TimeWeightedReturn = roundTo(growth-1, 6)
+	perf.AnnualizedReturn = roundTo(annualize(growth-1, years), 6)
+	cashFlows = append(cashFlows, CashFlow{Date: perf.EndDate, Amount: perf.EndValue})
+	if xirr, err := XIRR(cashFlows); err == nil {
+		perf.MoneyWeightedReturn = roundTo(xirr, 6)
+	}
+	perf.StartValue = roundTo(perf.StartValue, 2)
+	perf.EndValue = roundTo(perf.EndValue, 2)
+	perf.NetContributions = roundTo(perf.NetContributions, 2)
+
+	if benchmark != "" {
+		comparison, err := compareBenchmark(perf, benchmark, series[benchmark], years)
+		if err != nil {
+			return nil, err
+		}
+		perf.Benchmark = comparison
+	}
+	return perf, nil
+}
+
+// compareBenchmark fills the benchmark's cumulative return into the series and summarizes it
+func compareBenchmark(perf *Performance, symbol string, prices []PricePoint, years float64) (*BenchmarkComparison, error) {
+	base, ok := closeOn(prices, perf.StartDate)
+	if !ok || base == 0 {
+		return nil, fmt.Errorf("%w: no %s prices on or before %s", ErrInvalidPortfolio, symbol, perf.StartDate)
+	}
+
+	for i := range perf.Series {
+		price, _ := closeOn(prices, perf.Series[i].Date)
+		perf.Series[i].BenchmarkReturn = roundTo(price/base-1, 6)
+	}
+
+	total := perf.Series[len(perf.Series)-1].BenchmarkReturn
+	return &BenchmarkComparison{
+		Symbol:           symbol,
+		Return:           total,
+		AnnualizedReturn: roundTo(annualize(total, years), 6),
+		ExcessReturn:     roundTo(perf.TimeWeightedReturn-total, 6),
+	}, nil
+}
+
+// tradingDates returns the distinct price dates of traded symbols within the range,
+// plus transaction dates in the range so flows on days without prices are not lost
+func tradingDates(series map[string][]PricePoint, transactions []Transaction, start, end string) []string {
+	seen := make(map[string]bool)
+	for _, symbol := range Symbols(transactions) {
+		for _, point := range series[symbol] {
+			if point.Date >= start && point.Date <= end {
+				seen[point.Date] = true
+			}
+		}
+	}
+	for _, t := range transactions {
+		if t.Date >= start && t.Date <= end {
+			seen[t.Date] = true
+		}
+	}
+
+	dates := make([]string, 0, len(seen))
+	for date := range seen {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates
+}
@@ -0,0 +1,174 @@
+// Package portfolio provides holdings-based portfolio analytics: time-weighted and
+// money-weighted (XIRR) returns and benchmark comparison over arbitrary date ranges,
+// allocation drift against targets with rebalancing suggestions, tax lot accounting, and
+// dividend income and stock splits.
+//
+// Returns are expressed as decimals (0.05 = 5%). The portfolio holds securities only,
+// so buys are treated as contributions and sells as withdrawals.
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidPortfolio is returned when transactions or date ranges are missing or inconsistent
+var ErrInvalidPortfolio = errors.New("invalid portfolio")
+
+// DateLayout is the date format used for transactions and prices
+const DateLayout = "2006-01-02"
+
+// Transaction types
+const (
+	Buy  = "buy"
+	Sell = "sell"
+)
+
+// Transaction is a buy or sell of a security. ID optionally names the tax lot a buy opens,
+// and Lots selects the lots a sell relieves under specific identification.
+type Transaction struct {
+	ID       string         `json:"id,omitempty"`
+	Date     string         `json:"date"`
+	Symbol   string         `json:"symbol"`
+	Type     string         `json:"type"`
+	Quantity float64        `json:"quantity"`
+	Price    float64        `json:"price"`
+	Fees     float64        `json:"fees"`
+	Lots     []LotSelection `json:"lots,omitempty"`
+}
+
+// PricePoint is a daily closing price
+type PricePoint struct {
+	Date  string  `json:"date"`
+	Close float64 `json:"close"`
+}
+
+// Validate checks that the transaction is complete
+func (t Transaction) Validate() error {
+	if _, err := time.Parse(DateLayout, t.Date); err != nil {
+		return fmt.Errorf("%w: transaction date must be in YYYY-MM-DD format", ErrInvalidPortfolio)
+	}
+	switch {
+	case strings.TrimSpace(t.Symbol) == "":
+		return fmt.Errorf("%w: transaction symbol is required", ErrInvalidPortfolio)
+	case t.Type != Buy && t.Type != Sell:
+		return fmt.Errorf("%w: transaction type must be buy or sell", ErrInvalidPortfolio)
+	case t.Quantity <= 0:
+		return fmt.Errorf("%w: transaction quantity must be positive", ErrInvalidPortfolio)
+	case t.Price < 0 || t.Fees < 0:
+		return fmt.Errorf("%w: transaction price and fees cannot be negative", ErrInvalidPortfolio)
+	case len(t.Lots) > 0 && t.Type != Sell:
+		return fmt.Errorf("%w: lots can only be selected on sells", ErrInvalidPortfolio)
+	}
+	for _, selection := range t.Lots {
+		if selection.LotID == "" || selection.Quantity <= 0 {
+			return fmt.Errorf("%w: selected lots need an ID and a positive quantity", ErrInvalidPortfolio)
+		}
+	}
+	return nil
+}
+
+// CashFlow returns the amount contributed to the portfolio by a buy, or withdrawn (negative) by a sell
+func (t Transaction) CashFlow() float64 {
+	if t.Type == Sell {
+		return -(t.Quantity*t.Price - t.Fees)
+	}
+	return t.Quantity*t.Price + t.Fees
+}
+
+// Symbols returns the distinct symbols traded, in order of first appearance
+func Symbols(transactions []Transaction) []string {
+	seen := make(map[string]bool)
+	var symbols []string
+	for _, t := range transactions {
+		symbol := strings.ToUpper(t.Symbol)
+		if !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// sortedTransactions validates transactions and returns them in date order with upper-case symbols.
+// Sells may not exceed the quantity held at the time, after any date-ordered splits.
+func sortedTransactions(transactions []Transaction, splits []CorporateAction) ([]Transaction, error) {
+	if len(transactions) == 0 {
+		return nil, fmt.Errorf("%w: at least one transaction is required", ErrInvalidPortfolio)
+	}
+
+	sorted := make([]Transaction, len(transactions))
+	for i, t := range transactions {
+		if err := t.Validate(); err != nil {
+			return nil, err
+		}
+		t.Symbol = strings.ToUpper(strings.TrimSpace(t.Symbol))
+		sorted[i] = t
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+
+	held := make(map[string]float64)
+	cursor := splitCursor{splits: splits}
+	for _, t := range sorted {
+		cursor.through(t.Date, func(split CorporateAction) {
+			held[split.Symbol] *= split.Ratio
+		})
+		if t.Type == Sell {
+			if t.Quantity > held[t.Symbol]+1e-9 {
+				return nil, fmt.Errorf("%w: %s sell of %g on %s exceeds %g shares held",
+					ErrInvalidPortfolio, t.Symbol, t.Quantity, t.Date, held[t.Symbol])
+			}
+			held[t.Symbol] -= t.Quantity
+		} else {
+			held[t.Symbol] += t.Quantity
+		}
+	}
+	return sorted, nil
+}
+
+// sortedPrices returns a copy of the price series in ascending date order
+func sortedPrices(prices []PricePoint) []PricePoint {
+	sorted := append([]PricePoint{}, prices...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+	return sorted
+}
+
+// closeOn returns the last close on or before date from an ascending series
+func closeOn(prices []PricePoint, date string) (float64, bool) {
+	i := sort.Search(len(prices), func(i int) bool {
+		return prices[i].Date > date
+	})
+	if i == 0 {
+		return 0, false
+	}
+	return prices[i-1].Close, true
+}
+
+// yearsBetween returns the number of 365-day years between two dates
+func yearsBetween(start, end string) float64 {
+	s, _ := time.Parse(DateLayout, start)
+	e, _ := time.Parse(DateLayout, end)
+	return e.Sub(s).Hours() / 24 / 365
+}
+
+// annualize converts a cumulative return to an annual rate. Periods shorter
+// than a year are not annualized and return the cumulative figure.
+func annualize(cumulative, years float64) float64 {
+	if years < 1 || cumulative <= -1 {
+		return cumulative
+	}
+	return math.Pow(1+cumulative, 1/years) - 1
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,273 @@
+package portfolio
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// dimensionNames are the position attributes grouped by each dimension, for error messages
+var dimensionNames = map[string]string{
+	ByAssetClass: "asset class",
+	BySector:     "sector",
+	BySymbol:     "symbol",
+}
+
+// RebalanceRequest describes the holdings to rebalance toward the targets. Cash is uninvested
+// money available for buys. AvoidGains never sells shares trading above their cost basis,
+// WholeShares trades whole shares only and orders smaller than MinTrade are skipped.
+type RebalanceRequest struct {
+	Targets     AllocationTargets `json:"targets"`
+	Positions   []Position        `json:"positions"`
+	Cash        float64           `json:"cash"`
+	AvoidGains  bool              `json:"avoidGains"`
+	WholeShares bool              `json:"wholeShares"`
+	MinTrade    float64           `json:"minTrade,omitempty"`
+}
+
+// Order is a proposed trade in one symbol. RealizedGain is estimated from the average cost basis.
+type Order struct {
+	Symbol       string  `json:"symbol"`
+	Group        string  `json:"group"`
+	Side         string  `json:"side"`
+	Quantity     float64 `json:"quantity"`
+	Price        float64 `json:"price"`
+	Amount       float64 `json:"amount"`
+	RealizedGain float64 `json:"realizedGain,omitempty"`
+}
+
+// RebalancePlan holds the proposed orders with the drift before and after trading.
+// Cash is the uninvested amount left once the orders are filled, and Balanced reports
+// whether every group ends within its band.
+type RebalancePlan struct {
+	TotalValue   float64  `json:"totalValue"`
+	Cash         float64  `json:"cash"`
+	RealizedGain float64  `json:"realizedGain"`
+	Balanced     bool     `json:"balanced"`
+	Orders       []Order  `json:"orders"`
+	Drift        []Drift  `json:"drift"`
+	Projected    []Drift  `json:"projected"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+// PlanRebalance proposes the orders that bring groups outside their drift band back to target.
+// Groups within their band are left alone to keep the number of trades down. Sells draw on the
+// positions with the smallest gains first, each group's buys go to its largest position, and
+// buys are scaled down pro rata when sale proceeds and cash cannot fund them all.
+// Proceeds not needed for buys remain in cash.
+func PlanRebalance(req RebalanceRequest) (*RebalancePlan, error) {
+	targets := req.Targets
+	if err := targets.Normalize(); err != nil {
+		return nil, err
+	}
+	positions, err := normalizePositions(req.Positions, targets)
+	if err != nil {
+		return nil, err
+	}
+	if req.Cash < 0 || req.MinTrade < 0 {
+		return nil, fmt.Errorf("%w: cash and minimum trade cannot be negative", ErrInvalidAllocation)
+	}
+
+	drifts, total := CalculateDrift(targets, positions, req.Cash)
+	if total <= 0 {
+		return nil, fmt.Errorf("%w: portfolio has no value to rebalance", ErrInvalidAllocation)
+	}
+
+	planner := &rebalancer{
+		req:       req,
+		targets:   targets,
+		positions: positions,
+		cash:      req.Cash,
+		plan: &RebalancePlan{
+			TotalValue: roundTo(total, 2),
+			Orders:     []Order{},
+			Drift:      drifts,
+		},
+	}
+
+	var buys []Drift
+	needed := 0.0
+	for _, drift := range drifts {
+		if !drift.OutOfBand {
+			continue
+		}
+		amount := drift.TargetWeight*total - drift.Value
+		if amount < 0 {
+			planner.sell(drift.Key, -amount)
+		} else {
+			buys = append(buys, drift)
+			needed += amount
+		}
+	}
+
+	scale := 1.0
+	if needed > planner.cash {
+		scale = planner.cash / needed
+		planner.warn("not enough cash to fund every buy; buys scaled to %.1f%% of the amount needed", scale*100)
+	}
+	for _, drift := range buys {
+		planner.buy(drift.Key, (drift.TargetWeight*total-drift.Value)*scale)
+	}
+
+	plan := planner.plan
+	plan.Projected, _ = CalculateDrift(targets, planner.positions, planner.cash)
+	plan.Balanced = true
+	for _, drift := range plan.Projected {
+		plan.Balanced = plan.Balanced && !drift.OutOfBand
+	}
+	plan.Cash = roundTo(planner.cash, 2)
+	plan.RealizedGain = roundTo(plan.RealizedGain, 2)
+	return plan, nil
+}
+
+// rebalancer tracks positions and cash as orders are added to a plan
+type rebalancer struct {
+	req       RebalanceRequest
+	targets   AllocationTargets
+	positions []Position
+	cash      float64
+	plan      *RebalancePlan
+}
+
+// sell adds sell orders worth up to amount from a group, drawing on the smallest gains first
+func (r *rebalancer) sell(group string, amount float64) {
+	var candidates []int
+	for i, p := range r.positions {
+		if p.Quantity > 0 && r.targets.groupOf(p) == group {
+			candidates = append(candidates, i)
+		}
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return r.gainRate(candidates[a]) < r.gainRate(candidates[b])
+	})
+
+	remaining := amount
+	limited := false
+	for _, i := range candidates {
+		if remaining < 0.005 {
+			break
+		}
+		p := &r.positions[i]
+		if r.req.AvoidGains && r.gainRate(i) > 0 {
+			limited = true
+			continue
+		}
+
+		quantity := math.Min(r.shares(remaining/p.Price, true), p.Quantity)
+		value := quantity * p.Price
+		if quantity <= 0 || value < r.req.MinTrade {
+			continue
+		}
+		cost := p.CostBasis * quantity / p.Quantity
+		gain := value - cost
+		if p.CostBasis == 0 {
+			cost, gain = 0, 0
+		}
+
+		p.Quantity -= quantity
+		p.CostBasis -= cost
+		r.cash += value
+		remaining -= value
+		r.plan.RealizedGain += gain
+		r.plan.Orders = append(r.plan.Orders, Order{
+			Symbol:       p.Symbol,
+			Group:        group,
+			Side:         Sell,
+			Quantity:     quantity,
+			Price:        p.Price,
+			Amount:       roundTo(value, 2),
+			RealizedGain: roundTo(gain, 2),
+		})
+	}
+	if limited && remaining >= 0.005 {
+		r.warn("%s: sales limited to avoid realizing gains", group)
+	}
+}
+
+// buy adds a buy order worth up to amount in the group's largest position
+func (r *rebalancer) buy(group string, amount float64) {
+	best := -1
+	for i, p := range r.positions {
+		if r.targets.groupOf(p) != group {
+			continue
+		}
+		if best < 0 || p.Quantity*p.Price > r.positions[best].Quantity*r.positions[best].Price {
+			best = i
+		}
+	}
+	if best < 0 {
+		r.warn("%s: no position to buy; list a symbol with a price to invest in this group", group)
+		return
+	}
+
+	p := &r.positions[best]
+	quantity := r.shares(amount/p.Price, false)
+	value := quantity * p.Price
+	if quantity <= 0 || value < r.req.MinTrade {
+		return
+	}
+
+	p.Quantity += quantity
+	p.CostBasis += value
+	r.cash -= value
+	r.plan.Orders = append(r.plan.Orders, Order{
+		Symbol:   p.Symbol,
+		Group:    group,
+		Side:     Buy,
+		Quantity: quantity,
+		Price:    p.Price,
+		Amount:   roundTo(value, 2),
+	})
+}
+
+// shares rounds a share quantity, up for sells so the group reaches its target and down for buys
+// so orders never spend more than is available
+func (r *rebalancer) shares(quantity float64, roundUp bool) float64 {
+	places := 1e6
+	if r.req.WholeShares {
+		places = 1
+	}
+	if roundUp {
+		return math.Ceil(quantity*places-1e-6) / places
+	}
+	return math.Floor(quantity*places+1e-6) / places
+}
+
+// gainRate returns a position's unrealized gain as a fraction of its market value
+func (r *rebalancer) gainRate(i int) float64 {
+	p := r.positions[i]
+	value := p.Quantity * p.Price
+	if p.CostBasis == 0 || value == 0 {
+		return 0
+	}
+	return (value - p.CostBasis) / value
+}
+
+func (r *rebalancer) warn(format string, args ...interface{}) {
+	r.plan.Warnings = append(r.plan.Warnings, fmt.Sprintf(format, args...))
+}
+
+// normalizePositions validates positions and returns a copy with upper-case symbols
+func normalizePositions(positions []Position, targets AllocationTargets) ([]Position, error) {
+	normalized := make([]Position, len(positions))
+	seen := make(map[string]bool)
+	for i, p := range positions {
+		p.Symbol = strings.ToUpper(strings.TrimSpace(p.Symbol))
+		switch {
+		case p.Symbol == "":
+			return nil, fmt.Errorf("%w: position symbol is required", ErrInvalidAllocation)
+		case seen[p.Symbol]:
+			return nil, fmt.Errorf("%w: duplicate position %s", ErrInvalidAllocation, p.Symbol)
+		case p.Quantity < 0 || p.CostBasis < 0:
+			return nil, fmt.Errorf("%w: %s quantity and cost basis cannot be negative", ErrInvalidAllocation, p.Symbol)
+		case p.Price <= 0:
+			return nil, fmt.Errorf("%w: %s price must be positive", ErrInvalidAllocation, p.Symbol)
+		case targets.groupOf(p) == "":
+			return nil, fmt.Errorf("%w: %s has no %s", ErrInvalidAllocation, p.Symbol, dimensionNames[targets.Dimension])
+		}
+		seen[p.Symbol] = true
+		normalized[i] = p
+	}
+	return normalized, nil
+}
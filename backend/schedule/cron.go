@@ -0,0 +1,201 @@
+// Package schedule parses cron-like schedules for background jobs. Schedules use the five standard
+// fields (minute, hour, day of month, month and day of week) with lists, ranges and steps, the
+// @hourly, @daily, @weekly, @monthly and @yearly shorthands, or @every followed by a Go duration.
+// Times are matched in the location of the time passed to Next.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule is returned for schedules that cannot be parsed
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// maxSearchYears bounds the search for the next matching time, so impossible dates such as
+// February 30th end instead of looping forever
+const maxSearchYears = 5
+
+var shorthands = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field is the range of values a cron field accepts
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Schedule is a parsed schedule
+type Schedule struct {
+	spec  string
+	every time.Duration
+
+	minute, hour, dom, month, dow uint64
+	// Days match either day field when both are restricted, as in cron
+	domAny, dowAny bool
+}
+
+// Parse parses a cron expression, shorthand or @every interval
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if interval, ok := strings.CutPrefix(spec, "@every "); ok {
+		every, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || every <= 0 {
+			return nil, fmt.Errorf("%w: %q needs a positive duration", ErrInvalidSchedule, spec)
+		}
+		return &Schedule{spec: spec, every: every}, nil
+	}
+
+	expr := spec
+	if expanded, ok := shorthands[strings.ToLower(spec)]; ok {
+		expr = expanded
+	}
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: %q needs minute, hour, day of month, month and day of week", ErrInvalidSchedule, spec)
+	}
+
+	masks := make([]uint64, len(fields))
+	for i, part := range parts {
+		mask, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidSchedule, spec, err)
+		}
+		masks[i] = mask
+	}
+	// Sunday is both 0 and 7
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1
+	}
+
+	return &Schedule{
+		spec:   spec,
+		minute: masks[0],
+		hour:   masks[1],
+		dom:    masks[2],
+		month:  masks[3],
+		dow:    masks[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}, nil
+}
+
+// String returns the schedule as it was written
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time after t that matches the schedule, or the zero time if none
+// does within five years
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Add(s.every)
+	}
+
+	loc := t.Location()
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(maxSearchYears, 0, 0)
+	for next.Before(limit) {
+		var candidate time.Time
+		switch {
+		case s.month&(1<<uint(next.Month())) == 0:
+			candidate = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(next):
+			candidate = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(next.Hour())) == 0:
+			candidate = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<uint(next.Minute())) == 0:
+			candidate = next.Add(time.Minute)
+		default:
+			return next
+		}
+		// Local times skipped by a daylight saving change can normalize backwards
+		if !candidate.After(next) {
+			candidate = next.Add(time.Minute)
+		}
+		next = candidate
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// parseField parses a comma-separated list of values, ranges and steps into a bit mask
+func parseField(part string, f field) (uint64, error) {
+	var mask uint64
+	for _, item := range strings.Split(part, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepPart)
+			}
+			step = n
+		}
+
+		start, end := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			low, high, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseValue(low, f); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(high, f); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rangePart)
+			}
+		default:
+			value, err := parseValue(rangePart, f)
+			if err != nil {
+				return 0, err
+			}
+			start = value
+			if !hasStep {
+				end = value
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+func parseValue(value string, f field) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s %q must be between %d and %d", f.name, value, f.min, f.max)
+	}
+	return n, nil
+}
@@ -0,0 +1,82 @@
+// Package server runs the REST API with a graceful shutdown: once its context is cancelled, the
+// server stops accepting connections and gives the requests in flight a deadline to finish.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long requests in flight are given to finish on shutdown
+const DefaultShutdownTimeout = 15 * time.Second
+
+// ShutdownTimeoutFromEnv returns the SHUTDOWN_TIMEOUT duration, e.g. "30s", or
+// DefaultShutdownTimeout when it is not set
+func ShutdownTimeoutFromEnv() (time.Duration, error) {
+	value := os.Getenv("SHUTDOWN_TIMEOUT")
+	if value == "" {
+		return DefaultShutdownTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return DefaultShutdownTimeout, fmt.Errorf("invalid SHUTDOWN_TIMEOUT %q, expected a duration such as 30s", value)
+	}
+	return timeout, nil
+}
+
+// Serve listens on the server's address and serves until the context is cancelled, then shuts
+// the server down as ServeListener does
+func Serve(ctx context.Context, srv *http.Server, timeout time.Duration) error {
+	addr := srv.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return ServeListener(ctx, srv, ln, timeout)
+}
+
+// ServeListener serves connections from ln until the context is cancelled. It then stops
+// accepting connections and waits up to timeout for the requests in flight. Requests still
+// running at the deadline have their contexts cancelled and their connections closed.
+func ServeListener(ctx context.Context, srv *http.Server, ln net.Listener, timeout time.Duration) error {
+	// Request contexts outlive the server's context so that draining requests can finish
+	requests, cancelRequests := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRequests()
+	if srv.BaseContext == nil {
+		srv.BaseContext = func(net.Listener) context.Context { return requests }
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-served:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.InfoContext(ctx, "Shutting down API server", "timeout", timeout.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		cancelRequests()
+		srv.Close()
+		return fmt.Errorf("failed to drain requests within %s: %w", timeout, err)
+	}
+	slog.InfoContext(ctx, "API server stopped")
+	return nil
+}
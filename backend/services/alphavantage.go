@@ -1,15 +1,20 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"financehub/models"
+	"financehub/transport"
 )
 
 // AlphaVantageService handles Alpha Vantage API calls
@@ -22,19 +27,17 @@ type AlphaVantageService struct {
 // NewAlphaVantageService creates a new Alpha Vantage service
 func NewAlphaVantageService() *AlphaVantageService {
 	return &AlphaVantageService{
-		APIKey:  os.Getenv("ALPHA_VANTAGE_API_KEY"),
-		BaseURL: "https://www.alphavantage.co/query",
-		HTTPClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		APIKey:     os.Getenv("ALPHA_VANTAGE_API_KEY"),
+		BaseURL:    "https://www.alphavantage.co/query",
+		HTTPClient: transport.NewClient("alphavantage"),
 	}
 }
 
 // GetStockQuote retrieves real-time stock quote
-func (s *AlphaVantageService) GetStockQuote(symbol string) (*models.StockQuote, error) {
+func (s *AlphaVantageService) GetStockQuote(ctx context.Context, symbol string) (*models.StockQuote, error) {
 	url := fmt.Sprintf("%s?function=GLOBAL_QUOTE&symbol=%s&apikey=%s", s.BaseURL, symbol, s.APIKey)
 
-	resp, err := s.HTTPClient.Get(url)
+	resp, err := httpGet(ctx, s.HTTPClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch stock quote: %w", err)
 	}
@@ -79,11 +82,15 @@ func (s *AlphaVantageService) GetStockQuote(symbol string) (*models.StockQuote,
 	}, nil
 }
 
-// GetTimeSeriesDaily retrieves daily time series data
-func (s *AlphaVantageService) GetTimeSeriesDaily(symbol string, limit int) ([]models.TimeSeriesData, error) {
+// GetTimeSeriesDaily retrieves the most recent daily bars, newest first.
+// Limits above 100 request the full history.
+func (s *AlphaVantageService) GetTimeSeriesDaily(ctx context.Context, symbol string, limit int) ([]models.TimeSeriesData, error) {
 	url := fmt.Sprintf("%s?function=TIME_SERIES_DAILY&symbol=%s&apikey=%s", s.BaseURL, symbol, s.APIKey)
+	if limit > 100 {
+		url += "&outputsize=full"
+	}
 
-	resp, err := s.HTTPClient.Get(url)
+	resp, err := httpGet(ctx, s.HTTPClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch time series: %w", err)
 	}
@@ -105,12 +112,7 @@ func (s *AlphaVantageService) GetTimeSeriesDaily(symbol string, limit int) ([]mo
 	}
 
 	var data []models.TimeSeriesData
-	count := 0
 	for date, values := range timeSeries {
-		if count >= limit {
-			break
-		}
-
 		valueMap := values.(map[string]interface{})
 		open, _ := strconv.ParseFloat(fmt.Sprintf("%v", valueMap["1. open"]), 64)
 		high, _ := strconv.ParseFloat(fmt.Sprintf("%v", valueMap["2. high"]), 64)
@@ -126,18 +128,67 @@ func (s *AlphaVantageService) GetTimeSeriesDaily(symbol string, limit int) ([]mo
 			Close:  close,
 			Volume: volume,
 		})
-		count++
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Date > data[j].Date
+	})
+	if len(data) > limit {
+		data = data[:limit]
 	}
 
 	return data, nil
 }
 
+// GetCorporateActions retrieves the full history of dividends and splits from
+// daily-adjusted time series data, oldest first
+func (s *AlphaVantageService) GetCorporateActions(ctx context.Context, symbol string) ([]models.CorporateAction, error) {
+	result, err := s.query(ctx, "TIME_SERIES_DAILY_ADJUSTED", map[string]string{
+		"symbol":     symbol,
+		"outputsize": "full",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeSeries, ok := result["Time Series (Daily)"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid time series data")
+	}
+
+	var actions []models.CorporateAction
+	for date, values := range timeSeries {
+		valueMap, ok := values.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		dividend := parseNumber(valueMap["7. dividend amount"])
+		split := parseNumber(valueMap["8. split coefficient"])
+		if split == 0 {
+			split = 1
+		}
+		if dividend == 0 && split == 1 {
+			continue
+		}
+		actions = append(actions, models.CorporateAction{
+			Date:             date,
+			DividendAmount:   dividend,
+			SplitCoefficient: split,
+		})
+	}
+
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].Date < actions[j].Date
+	})
+	return actions, nil
+}
+
 // GetCurrencyExchangeRate retrieves currency exchange rate
-func (s *AlphaVantageService) GetCurrencyExchangeRate(fromCurrency, toCurrency string) (*models.CurrencyRate, error) {
+func (s *AlphaVantageService) GetCurrencyExchangeRate(ctx context.Context, fromCurrency, toCurrency string) (*models.CurrencyRate, error) {
 	url := fmt.Sprintf("%s?function=CURRENCY_EXCHANGE_RATE&from_currency=%s&to_currency=%s&apikey=%s",
 		s.BaseURL, fromCurrency, toCurrency, s.APIKey)
 
-	resp, err := s.HTTPClient.Get(url)
+	resp, err := httpGet(ctx, s.HTTPClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch exchange rate: %w", err)
 	}
@@ -171,3 +222,111 @@ func (s *AlphaVantageService) GetCurrencyExchangeRate(fromCurrency, toCurrency s
 		LastUpdated:  time.Now().Format(time.RFC3339),
 	}, nil
 }
+
+// GetTopGainersLosers retrieves the top gaining, losing and most actively traded US equities.
+// Only the equity lists of the returned overview are populated.
+func (s *AlphaVantageService) GetTopGainersLosers(ctx context.Context) (*models.MarketOverview, error) {
+	result, err := s.query(ctx, "TOP_GAINERS_LOSERS", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	gainers, ok := result["top_gainers"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid market movers data")
+	}
+	losers, _ := result["top_losers"].([]interface{})
+	mostActive, _ := result["most_actively_traded"].([]interface{})
+	lastUpdated := getString(result["last_updated"])
+
+	return &models.MarketOverview{
+		TopGainers:  parseMovers(gainers, lastUpdated),
+		TopLosers:   parseMovers(losers, lastUpdated),
+		MostActive:  parseMovers(mostActive, lastUpdated),
+		LastUpdated: lastUpdated,
+	}, nil
+}
+
+// parseMovers converts TOP_GAINERS_LOSERS entries into stock quotes
+func parseMovers(items []interface{}, lastUpdated string) []models.StockQuote {
+	quotes := []models.StockQuote{}
+	for _, item := range items {
+		mover, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		volume, _ := strconv.ParseInt(getString(mover["volume"]), 10, 64)
+		quotes = append(quotes, models.StockQuote{
+			Symbol:        getString(mover["ticker"]),
+			Price:         parseNumber(mover["price"]),
+			Change:        parseNumber(mover["change_amount"]),
+			ChangePercent: parseNumber(mover["change_percentage"]),
+			Volume:        volume,
+			LastUpdated:   lastUpdated,
+		})
+	}
+	return quotes
+}
+
+// query performs an Alpha Vantage request for the given function and returns the decoded payload
+func (s *AlphaVantageService) query(ctx context.Context, function string, params map[string]string) (map[string]interface{}, error) {
+	values := url.Values{}
+	values.Set("function", function)
+	for key, value := range params {
+		values.Set(key, value)
+	}
+	values.Set("apikey", s.APIKey)
+
+	resp, err := httpGet(ctx, s.HTTPClient, s.BaseURL+"?"+values.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", function, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Alpha Vantage reports errors and rate limiting with HTTP 200 and a message field
+	for _, key := range []string{"Error Message", "Note", "Information"} {
+		if msg, ok := result[key].(string); ok {
+			return nil, fmt.Errorf("alpha vantage %s: %s", function, msg)
+		}
+	}
+
+	return result, nil
+}
+
+// parseNumber converts an Alpha Vantage string value to float64, treating "None" and "-" as zero
+func parseNumber(val interface{}) float64 {
+	if val == nil {
+		return 0
+	}
+	if f, ok := val.(float64); ok {
+		return f
+	}
+	str := strings.TrimSuffix(fmt.Sprintf("%v", val), "%")
+	if str == "None" || str == "-" || str == "" {
+		return 0
+	}
+	f, _ := strconv.ParseFloat(str, 64)
+	return f
+}
+
+// getString safely extracts a string value
+func getString(val interface{}) string {
+	if val == nil {
+		return ""
+	}
+	str := fmt.Sprintf("%v", val)
+	if str == "None" {
+		return ""
+	}
+	return str
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"financehub/importer"
+	"financehub/models"
+)
+
+// ErrInvalidBudget is returned when a category, budget or expense fails validation
+var ErrInvalidBudget = errors.New("invalid budget data")
+
+// ErrCategoryNotFound is returned when a budget category does not exist
+var ErrCategoryNotFound = errors.New("category not found")
+
+// ErrCategoryExists is returned when adding a category whose ID is already taken
+var ErrCategoryExists = errors.New("category already exists")
+
+// ErrCategoryInUse is returned when deleting a category that still has recorded transactions
+var ErrCategoryInUse = errors.New("category has recorded transactions")
+
+// ErrExpenseNotFound is returned when a recorded transaction does not exist
+var ErrExpenseNotFound = errors.New("transaction not found")
+
+// ErrDuplicateTransaction is returned when recording an imported transaction that was already recorded
+var ErrDuplicateTransaction = errors.New("transaction already recorded")
+
+const (
+	monthLayout = "2006-01"
+	dateLayout  = "2006-01-02"
+)
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// budgetData is the persisted state of the budget tracker
+type budgetData struct {
+	Categories    []models.Category `json:"categories"`
+	Budgets       []models.Budget   `json:"budgets"`
+	Expenses      []models.Expense  `json:"expenses"`
+	Rules         []importer.Rule   `json:"rules"`
+	NextExpenseID int64             `json:"nextExpenseId"`
+}
+
+// BudgetService manages budget categories, monthly budgets and transactions.
+// State is persisted as JSON to Path after every change; an empty Path keeps it in memory only.
+type BudgetService struct {
+	Path string
+
+	mu   sync.RWMutex
+	data budgetData
+}
+
+// NewBudgetService creates a budget service, loading existing data from path if present
+func NewBudgetService(path string) (*BudgetService, error) {
+	s := &BudgetService{
+		Path: path,
+		data: budgetData{NextExpenseID: 1},
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	if _, err := readJSON(path, &s.data, "budget data"); err != nil {
+		return nil, err
+	}
+	if s.data.NextExpenseID < 1 {
+		s.data.NextExpenseID = 1
+	}
+	return s, nil
+}
+
+// ListCategories returns all categories sorted by kind and name
+func (s *BudgetService) ListCategories() []models.Category {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	categories := append([]models.Category{}, s.data.Categories...)
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Kind != categories[j].Kind {
+			return categories[i].Kind == models.CategoryIncome
+		}
+		return categories[i].Name < categories[j].Name
+	})
+	return categories
+}
+
+// AddCategory creates a category. The ID defaults to a slug of the name,
+// the kind defaults to expense and the rollover rule defaults to none.
+func (s *BudgetService) AddCategory(category models.Category) (*models.Category, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return nil, fmt.Errorf("%w: category name is required", ErrInvalidBudget)
+	}
+	if category.ID == "" {
+		category.ID = strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(category.Name), "-"), "-")
+	}
+	if category.ID == "" {
+		return nil, fmt.Errorf("%w: category ID is required", ErrInvalidBudget)
+	}
+	if category.Kind == "" {
+		category.Kind = models.CategoryExpense
+	}
+	if category.Kind != models.CategoryIncome && category.Kind != models.CategoryExpense {
+		return nil, fmt.Errorf("%w: kind must be income or expense", ErrInvalidBudget)
+	}
+	if category.Rollover == "" {
+		category.Rollover = models.RolloverNone
+	}
+	if !contains([]string{models.RolloverNone, models.RolloverSurplus, models.RolloverFull}, category.Rollover) {
+		return nil, fmt.Errorf("%w: rollover must be none, surplus or full", ErrInvalidBudget)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findCategory(category.ID) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCategoryExists, category.ID)
+	}
+	s.data.Categories = append(s.data.Categories, category)
+	if err := s.save(); err != nil {
+		s.data.Categories = s.data.Categories[:len(s.data.Categories)-1]
+		return nil, err
+	}
+	return &category, nil
+}
+
+// DeleteCategory removes a category and its budgets. Categories with transactions cannot be deleted.
+func (s *BudgetService) DeleteCategory(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findCategory(id) == nil {
+		return fmt.Errorf("%w: %s", ErrCategoryNotFound, id)
+	}
+	for _, expense := range s.data.Expenses {
+		if expense.CategoryID == id {
+			return fmt.Errorf("%w: %s", ErrCategoryInUse, id)
+		}
+	}
+
+	previous := s.data
+	s.data.Categories = nil
+	for _, category := range previous.Categories {
+		if category.ID != id {
+			s.data.Categories = append(s.data.Categories, category)
+		}
+	}
+	s.data.Budgets = nil
+	for _, budget := range previous.Budgets {
+		if budget.CategoryID != id {
+			s.data.Budgets = append(s.data.Budgets, budget)
+		}
+	}
+	if err := s.save(); err != nil {
+		s.data = previous
+		return err
+	}
+	return nil
+}
+
+// ListBudgets returns all budgets sorted by category and effective month
+func (s *BudgetService) ListBudgets() []models.Budget {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	budgets := append([]models.Budget{}, s.data.Budgets...)
+	sort.Slice(budgets, func(i, j int) bool {
+		if budgets[i].CategoryID != budgets[j].CategoryID {
+			return budgets[i].CategoryID < budgets[j].CategoryID
+		}
+		return budgets[i].EffectiveFrom < budgets[j].EffectiveFrom
+	})
+	return budgets
+}
+
+// SetBudget sets the monthly budget for a category from a month onward.
+// EffectiveFrom defaults to the current month and replaces any budget set for the same month.
+func (s *BudgetService) SetBudget(budget models.Budget) (*models.Budget, error) {
+	if budget.EffectiveFrom == "" {
+		budget.EffectiveFrom = time.Now().Format(monthLayout)
+	}
+	if _, err := time.Parse(monthLayout, budget.EffectiveFrom); err != nil {
+		return nil, fmt.Errorf("%w: effectiveFrom must be in YYYY-MM format", ErrInvalidBudget)
+	}
+	if budget.Amount < 0 {
+		return nil, fmt.Errorf("%w: amount cannot be negative", ErrInvalidBudget)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findCategory(budget.CategoryID) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, budget.CategoryID)
+	}
+
+	previous := append([]models.Budget{}, s.data.Budgets...)
+	replaced := false
+	for i, existing := range s.data.Budgets {
+		if existing.CategoryID == budget.CategoryID && existing.EffectiveFrom == budget.EffectiveFrom {
+			s.data.Budgets[i] = budget
+			replaced = true
+		}
+	}
+	if !replaced {
+		s.data.Budgets = append(s.data.Budgets, budget)
+	}
+	if err := s.save(); err != nil {
+		s.data.Budgets = previous
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// RecordExpense records an income or expense transaction against a category.
+// The kind defaults to the category's kind and must match it when given.
+func (s *BudgetService) RecordExpense(expense models.Expense) (*models.Expense, error) {
+	if expense.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidBudget)
+	}
+	if expense.Date == "" {
+		expense.Date = time.Now().Format(dateLayout)
+	}
+	if _, err := time.Parse(dateLayout, expense.Date); err != nil {
+		return nil, fmt.Errorf("%w: date must be in YYYY-MM-DD format", ErrInvalidBudget)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	category := s.findCategory(expense.CategoryID)
+	if category == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, expense.CategoryID)
+	}
+	if expense.Kind == "" {
+		expense.Kind = category.Kind
+	}
+	if expense.Kind != category.Kind {
+		return nil, fmt.Errorf("%w: category %s only accepts %s", ErrInvalidBudget, category.ID, category.Kind)
+	}
+	if expense.ImportID != "" && s.importedIDs()[expense.ImportID] {
+		return nil, fmt.Errorf("%w: %s", ErrDuplicateTransaction, expense.ImportID)
+	}
+
+	expense.ID = s.data.NextExpenseID
+	s.data.Expenses = append(s.data.Expenses, expense)
+	s.data.NextExpenseID++
+	if err := s.save(); err != nil {
+		s.data.Expenses = s.data.Expenses[:len(s.data.Expenses)-1]
+		s.data.NextExpenseID--
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// ListExpenses returns transactions newest first, optionally limited to a YYYY-MM month
+func (s *BudgetService) ListExpenses(month string) ([]models.Expense, error) {
+	if month != "" {
+		if _, err := time.Parse(monthLayout, month); err != nil {
+			return nil, fmt.Errorf("%w: month must be in YYYY-MM format", ErrInvalidBudget)
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	expenses := []models.Expense{}
+	for _, expense := range s.data.Expenses {
+		if month == "" || strings.HasPrefix(expense.Date, month) {
+			expenses = append(expenses, expense)
+		}
+	}
+	sort.SliceStable(expenses, func(i, j int) bool {
+		if expenses[i].Date != expenses[j].Date {
+			return expenses[i].Date > expenses[j].Date
+		}
+		return expenses[i].ID > expenses[j].ID
+	})
+	return expenses, nil
+}
+
+// DeleteExpense removes a recorded transaction
+func (s *BudgetService) DeleteExpense(id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, expense := range s.data.Expenses {
+		if expense.ID != id {
+			continue
+		}
+		previous := append([]models.Expense{}, s.data.Expenses...)
+		s.data.Expenses = append(s.data.Expenses[:i], s.data.Expenses[i+1:]...)
+		if err := s.save(); err != nil {
+			s.data.Expenses = previous
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %d", ErrExpenseNotFound, id)
+}
+
+// findCategory returns the category with the given ID. Callers must hold the lock.
+func (s *BudgetService) findCategory(id string) *models.Category {
+	for i := range s.data.Categories {
+		if s.data.Categories[i].ID == id {
+			return &s.data.Categories[i]
+		}
+	}
+	return nil
+}
+
+// save writes the current state to Path atomically. Callers must hold the write lock.
+func (s *BudgetService) save() error {
+	if s.Path == "" {
+		return nil
+	}
+
+	return writeJSONAtomic(s.Path, s.data, "budget data")
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"fmt"
+	"math"
+
+	"financehub/importer"
+	"financehub/models"
+)
+
+// ListRules returns the statement categorization rules in the order they are applied
+func (s *BudgetService) ListRules() []importer.Rule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]importer.Rule{}, s.data.Rules...)
+}
+
+// SetRules replaces the statement categorization rules. Rules are applied in order and the first match wins.
+func (s *BudgetService) SetRules(rules []importer.Rule) ([]importer.Rule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBudget, err)
+		}
+		if s.findCategory(rule.CategoryID) == nil {
+			return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, rule.CategoryID)
+		}
+	}
+
+	previous := s.data.Rules
+	s.data.Rules = append([]importer.Rule{}, rules...)
+	if err := s.save(); err != nil {
+		s.data.Rules = previous
+		return nil, err
+	}
+	return s.data.Rules, nil
+}
+
+// ImportTransactions records parsed statement transactions. Transactions already imported are
+// reported as duplicates, and those that no rule categorizes, or whose sign does not match the
+// category kind, are returned as uncategorized without being recorded. Zero amounts are skipped.
+func (s *BudgetService) ImportTransactions(transactions []importer.Transaction) (*models.ImportResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	transactions = append([]importer.Transaction{}, transactions...)
+	if err := importer.Categorize(transactions, s.data.Rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBudget, err)
+	}
+	fresh, duplicates := importer.Deduplicate(transactions, s.importedIDs())
+
+	result := &models.ImportResult{
+		Imported:      []models.Expense{},
+		Duplicates:    []models.Expense{},
+		Uncategorized: []models.Expense{},
+	}
+	for _, t := range duplicates {
+		result.Duplicates = append(result.Duplicates, importedExpense(t))
+	}
+
+	previous := s.data
+	for _, t := range fresh {
+		if t.Amount == 0 {
+			continue
+		}
+		expense := importedExpense(t)
+		category := s.findCategory(expense.CategoryID)
+		if category == nil || category.Kind != expense.Kind {
+			expense.CategoryID = ""
+			result.Uncategorized = append(result.Uncategorized, expense)
+			continue
+		}
+
+		expense.ID = s.data.NextExpenseID
+		s.data.NextExpenseID++
+		s.data.Expenses = append(s.data.Expenses, expense)
+		result.Imported = append(result.Imported, expense)
+	}
+
+	if len(result.Imported) > 0 {
+		if err := s.save(); err != nil {
+			s.data = previous
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// importedIDs returns the import IDs of recorded transactions. Callers must hold the lock.
+func (s *BudgetService) importedIDs() map[string]bool {
+	ids := make(map[string]bool)
+	for _, expense := range s.data.Expenses {
+		if expense.ImportID != "" {
+			ids[expense.ImportID] = true
+		}
+	}
+	return ids
+}
+
+// importedExpense converts a statement transaction to an expense, using the sign to pick its kind
+func importedExpense(t importer.Transaction) models.Expense {
+	kind := models.CategoryIncome
+	if t.Amount < 0 {
+		kind = models.CategoryExpense
+	}
+	return models.Expense{
+		CategoryID:  t.CategoryID,
+		Kind:        kind,
+		Amount:      math.Abs(t.Amount),
+		Date:        t.Date,
+		Description: t.Description,
+		ImportID:    t.ID,
+	}
+}
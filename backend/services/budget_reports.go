@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"financehub/models"
+)
+
+const (
+	defaultTrendMonths = 6
+	maxTrendMonths     = 60
+)
+
+// GetMonthlyRollup returns income, expenses and per-category budget status for a YYYY-MM month.
+// The month defaults to the current month. Rollover only applies to expense categories.
+func (s *BudgetService) GetMonthlyRollup(month string) (*models.MonthlyRollup, error) {
+	month, err := parseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := s.ListCategories()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	actuals := s.monthlyActuals()
+	rollup := &models.MonthlyRollup{
+		Month:      month,
+		Categories: []models.CategoryRollup{},
+	}
+	for _, category := range categories {
+		budgeted := s.budgetFor(category.ID, month)
+		carried := s.carriedOver(category, month, actuals)
+		actual := actuals[category.ID][month]
+		available := budgeted + carried
+
+		rollup.Categories = append(rollup.Categories, models.CategoryRollup{
+			CategoryID:  category.ID,
+			Name:        category.Name,
+			Kind:        category.Kind,
+			Budgeted:    roundTo(budgeted, 2),
+			CarriedOver: roundTo(carried, 2),
+			Available:   roundTo(available, 2),
+			Actual:      roundTo(actual, 2),
+			Remaining:   roundTo(available-actual, 2),
+			PercentUsed: roundTo(safeDivide(actual, available)*100, 2),
+			OverBudget:  category.Kind == models.CategoryExpense && actual > available,
+		})
+
+		if category.Kind == models.CategoryIncome {
+			rollup.TotalIncome += actual
+		} else {
+			rollup.TotalExpenses += actual
+			rollup.TotalBudgeted += budgeted
+		}
+	}
+
+	rollup.Net = roundTo(rollup.TotalIncome-rollup.TotalExpenses, 2)
+	rollup.SavingsRate = roundTo(safeDivide(rollup.TotalIncome-rollup.TotalExpenses, rollup.TotalIncome)*100, 2)
+	rollup.TotalIncome = roundTo(rollup.TotalIncome, 2)
+	rollup.TotalExpenses = roundTo(rollup.TotalExpenses, 2)
+	rollup.TotalBudgeted = roundTo(rollup.TotalBudgeted, 2)
+	return rollup, nil
+}
+
+// GetTrends returns monthly totals and per-category activity for the months ending at endMonth, oldest first.
+// endMonth defaults to the current month and months defaults to 6.
+func (s *BudgetService) GetTrends(endMonth string, months int) (*models.BudgetTrends, error) {
+	endMonth, err := parseMonth(endMonth)
+	if err != nil {
+		return nil, err
+	}
+	if months == 0 {
+		months = defaultTrendMonths
+	}
+	if months < 1 || months > maxTrendMonths {
+		return nil, fmt.Errorf("%w: months must be between 1 and %d", ErrInvalidBudget, maxTrendMonths)
+	}
+
+	end, _ := time.Parse(monthLayout, endMonth)
+	monthList := make([]string, months)
+	for i := range monthList {
+		monthList[i] = end.AddDate(0, i-months+1, 0).Format(monthLayout)
+	}
+
+	categories := s.ListCategories()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	actuals := s.monthlyActuals()
+	trends := &models.BudgetTrends{
+		Months:     make([]models.MonthlyTotals, months),
+		Categories: []models.CategoryTrend{},
+	}
+	for i, month := range monthList {
+		trends.Months[i].Month = month
+	}
+
+	for _, category := range categories {
+		trend := models.CategoryTrend{
+			CategoryID: category.ID,
+			Name:       category.Name,
+			Kind:       category.Kind,
+			Monthly:    make([]float64, months),
+		}
+		total := 0.0
+		for i, month := range monthList {
+			actual := actuals[category.ID][month]
+			trend.Monthly[i] = roundTo(actual, 2)
+			total += actual
+			if category.Kind == models.CategoryIncome {
+				trends.Months[i].Income += actual
+			} else {
+				trends.Months[i].Expenses += actual
+			}
+		}
+		trend.Average = roundTo(total/float64(months), 2)
+		if months > 1 {
+			latest := actuals[category.ID][monthList[months-1]]
+			priorAverage := (total - latest) / float64(months-1)
+			trend.ChangePercent = roundTo(safeDivide(latest-priorAverage, priorAverage)*100, 2)
+		}
+		trends.Categories = append(trends.Categories, trend)
+	}
+
+	for i := range trends.Months {
+		totals := &trends.Months[i]
+		net := totals.Income - totals.Expenses
+		trends.AverageIncome += totals.Income / float64(months)
+		trends.AverageExpenses += totals.Expenses / float64(months)
+		trends.AverageNet += net / float64(months)
+
+		totals.SavingsRate = roundTo(safeDivide(net, totals.Income)*100, 2)
+		totals.Net = roundTo(net, 2)
+		totals.Income = roundTo(totals.Income, 2)
+		totals.Expenses = roundTo(totals.Expenses, 2)
+	}
+	trends.AverageIncome = roundTo(trends.AverageIncome, 2)
+	trends.AverageExpenses = roundTo(trends.AverageExpenses, 2)
+	trends.AverageNet = roundTo(trends.AverageNet, 2)
+	return trends, nil
+}
+
+// monthlyActuals totals transactions by category and month. Callers must hold the lock.
+func (s *BudgetService) monthlyActuals() map[string]map[string]float64 {
+	actuals := make(map[string]map[string]float64)
+	for _, expense := range s.data.Expenses {
+		month := expense.Date[:len(monthLayout)]
+		if actuals[expense.CategoryID] == nil {
+			actuals[expense.CategoryID] = make(map[string]float64)
+		}
+		actuals[expense.CategoryID][month] += expense.Amount
+	}
+	return actuals
+}
+
+// budgetFor returns the budget in effect for a category in a month. Callers must hold the lock.
+func (s *BudgetService) budgetFor(categoryID, month string) float64 {
+	amount, effectiveFrom := 0.0, ""
+	for _, budget := range s.data.Budgets {
+		if budget.CategoryID == categoryID && budget.EffectiveFrom <= month && budget.EffectiveFrom >= effectiveFrom {
+			amount, effectiveFrom = budget.Amount, budget.EffectiveFrom
+		}
+	}
+	return amount
+}
+
+// carriedOver returns the amount carried into month under the category's rollover rule,
+// replaying every month from the category's first budget or transaction. Callers must hold the lock.
+func (s *BudgetService) carriedOver(category models.Category, month string, actuals map[string]map[string]float64) float64 {
+	if category.Kind != models.CategoryExpense || category.Rollover == models.RolloverNone {
+		return 0
+	}
+
+	start := month
+	for _, budget := range s.data.Budgets {
+		if budget.CategoryID == category.ID && budget.EffectiveFrom < start {
+			start = budget.EffectiveFrom
+		}
+	}
+	for m := range actuals[category.ID] {
+		if m < start {
+			start = m
+		}
+	}
+
+	carried := 0.0
+	current, _ := time.Parse(monthLayout, start)
+	for m := start; m < month; m = current.Format(monthLayout) {
+		remaining := s.budgetFor(category.ID, m) + carried - actuals[category.ID][m]
+		if category.Rollover == models.RolloverSurplus {
+			carried = math.Max(remaining, 0)
+		} else {
+			carried = remaining
+		}
+		current = current.AddDate(0, 1, 0)
+	}
+	return carried
+}
+
+// parseMonth validates a YYYY-MM month, defaulting to the current month
+func parseMonth(month string) (string, error) {
+	if month == "" {
+		return time.Now().Format(monthLayout), nil
+	}
+	if _, err := time.Parse(monthLayout, month); err != nil {
+		return "", fmt.Errorf("%w: month must be in YYYY-MM format", ErrInvalidBudget)
+	}
+	return month, nil
+}
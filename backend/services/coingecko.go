@@ -1,13 +1,16 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"time"
 
 	"financehub/models"
+	"financehub/transport"
 )
 
 // CoinGeckoService handles CoinGecko API calls
@@ -19,19 +22,17 @@ type CoinGeckoService struct {
 // NewCoinGeckoService creates a new CoinGecko service
 func NewCoinGeckoService() *CoinGeckoService {
 	return &CoinGeckoService{
-		BaseURL: "https://api.coingecko.com/api/v3",
-		HTTPClient: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		BaseURL:    "https://api.coingecko.com/api/v3",
+		HTTPClient: transport.NewClient("coingecko"),
 	}
 }
 
 // GetCryptoPrice retrieves cryptocurrency price data
-func (s *CoinGeckoService) GetCryptoPrice(coinID string) (*models.CryptoPrice, error) {
+func (s *CoinGeckoService) GetCryptoPrice(ctx context.Context, coinID string) (*models.CryptoPrice, error) {
 	url := fmt.Sprintf("%s/coins/markets?vs_currency=usd&ids=%s&order=market_cap_desc&per_page=1&page=1&sparkline=false&price_change_percentage=24h",
 		s.BaseURL, coinID)
 
-	resp, err := s.HTTPClient.Get(url)
+	resp, err := httpGet(ctx, s.HTTPClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch crypto price: %w", err)
 	}
@@ -70,11 +71,11 @@ func (s *CoinGeckoService) GetCryptoPrice(coinID string) (*models.CryptoPrice, e
 }
 
 // GetTopCryptos retrieves top cryptocurrencies by market cap
-func (s *CoinGeckoService) GetTopCryptos(limit int) ([]models.CryptoPrice, error) {
+func (s *CoinGeckoService) GetTopCryptos(ctx context.Context, limit int) ([]models.CryptoPrice, error) {
 	url := fmt.Sprintf("%s/coins/markets?vs_currency=usd&order=market_cap_desc&per_page=%d&page=1&sparkline=false&price_change_percentage=24h",
 		s.BaseURL, limit)
 
-	resp, err := s.HTTPClient.Get(url)
+	resp, err := httpGet(ctx, s.HTTPClient, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch top cryptos: %w", err)
 	}
@@ -111,6 +112,110 @@ func (s *CoinGeckoService) GetTopCryptos(limit int) ([]models.CryptoPrice, error
 	return cryptos, nil
 }
 
+// GetGlobalMarket retrieves aggregate cryptocurrency market data
+func (s *CoinGeckoService) GetGlobalMarket(ctx context.Context) (*models.GlobalCryptoMarket, error) {
+	url := fmt.Sprintf("%s/global", s.BaseURL)
+
+	resp, err := httpGet(ctx, s.HTTPClient, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch global market data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid global market data")
+	}
+
+	totalMarketCap, _ := data["total_market_cap"].(map[string]interface{})
+	totalVolume, _ := data["total_volume"].(map[string]interface{})
+	percentages, _ := data["market_cap_percentage"].(map[string]interface{})
+
+	marketCapPercentage := make(map[string]float64, len(percentages))
+	for symbol, value := range percentages {
+		marketCapPercentage[symbol] = getFloat(value)
+	}
+
+	return &models.GlobalCryptoMarket{
+		TotalMarketCap:         getFloat(totalMarketCap["usd"]),
+		TotalVolume24h:         getFloat(totalVolume["usd"]),
+		MarketCapChange24h:     getFloat(data["market_cap_change_percentage_24h_usd"]),
+		MarketCapPercentage:    marketCapPercentage,
+		ActiveCryptocurrencies: getInt(data["active_cryptocurrencies"]),
+		LastUpdated:            time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+// GetDailyPrices retrieves daily USD closing prices for a coin over the last days, newest first.
+// Only one price is reported per day, so open, high and low equal the close.
+// The most recent point may be an intraday price.
+func (s *CoinGeckoService) GetDailyPrices(ctx context.Context, coinID string, days int) ([]models.TimeSeriesData, error) {
+	url := fmt.Sprintf("%s/coins/%s/market_chart?vs_currency=usd&days=%d&interval=daily", s.BaseURL, coinID, days)
+
+	resp, err := httpGet(ctx, s.HTTPClient, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch crypto price history: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var result struct {
+		Prices       [][]float64 `json:"prices"`
+		TotalVolumes [][]float64 `json:"total_volumes"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(result.Prices) == 0 {
+		return nil, fmt.Errorf("no price history for %s", coinID)
+	}
+
+	volumes := make(map[string]float64, len(result.TotalVolumes))
+	for _, point := range result.TotalVolumes {
+		if len(point) == 2 {
+			volumes[time.UnixMilli(int64(point[0])).UTC().Format("2006-01-02")] = point[1]
+		}
+	}
+
+	// Keep the last price reported for each day
+	byDate := make(map[string]float64, len(result.Prices))
+	for _, point := range result.Prices {
+		if len(point) == 2 {
+			byDate[time.UnixMilli(int64(point[0])).UTC().Format("2006-01-02")] = point[1]
+		}
+	}
+
+	data := make([]models.TimeSeriesData, 0, len(byDate))
+	for date, price := range byDate {
+		data = append(data, models.TimeSeriesData{
+			Date:   date,
+			Open:   price,
+			High:   price,
+			Low:    price,
+			Close:  price,
+			Volume: int64(volumes[date]),
+		})
+	}
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].Date > data[j].Date
+	})
+	return data, nil
+}
+
 // Helper functions to safely extract values
 func getFloat(val interface{}) float64 {
 	if val == nil {
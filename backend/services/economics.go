@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"financehub/models"
+)
+
+// ErrUnknownIndicator is returned when an economic indicator ID is not supported
+var ErrUnknownIndicator = errors.New("unknown economic indicator")
+
+// ErrInvalidIndicatorOption is returned when an interval or maturity is not supported by an indicator
+var ErrInvalidIndicatorOption = errors.New("invalid indicator option")
+
+// economicIndicator maps an indicator ID to its Alpha Vantage function and supported options.
+// The first interval and maturity are used as defaults.
+type economicIndicator struct {
+	function   string
+	name       string
+	intervals  []string
+	maturities []string
+}
+
+var economicIndicators = map[string]economicIndicator{
+	"real-gdp": {
+		function:  "REAL_GDP",
+		name:      "Real Gross Domestic Product",
+		intervals: []string{"annual", "quarterly"},
+	},
+	"cpi": {
+		function:  "CPI",
+		name:      "Consumer Price Index",
+		intervals: []string{"monthly", "semiannual"},
+	},
+	"inflation": {
+		function:  "INFLATION",
+		name:      "Inflation",
+		intervals: []string{"annual"},
+	},
+	"federal-funds-rate": {
+		function:  "FEDERAL_FUNDS_RATE",
+		name:      "Federal Funds Rate",
+		intervals: []string{"monthly", "weekly", "daily"},
+	},
+	"treasury-yield": {
+		function:   "TREASURY_YIELD",
+		name:       "Treasury Yield",
+		intervals:  []string{"monthly", "weekly", "daily"},
+		maturities: []string{"10year", "3month", "2year", "5year", "7year", "30year"},
+	},
+	"unemployment": {
+		function:  "UNEMPLOYMENT",
+		name:      "Unemployment Rate",
+		intervals: []string{"monthly"},
+	},
+	"retail-sales": {
+		function:  "RETAIL_SALES",
+		name:      "Retail Sales",
+		intervals: []string{"monthly"},
+	},
+}
+
+// EconomicsService provides macroeconomic indicator data
+type EconomicsService struct {
+	AlphaVantage *AlphaVantageService
+}
+
+// NewEconomicsService creates a new economics service
+func NewEconomicsService(alphaVantage *AlphaVantageService) *EconomicsService {
+	return &EconomicsService{
+		AlphaVantage: alphaVantage,
+	}
+}
+
+// ListIndicators returns all supported economic indicators
+func (s *EconomicsService) ListIndicators() []models.EconomicIndicatorInfo {
+	var indicators []models.EconomicIndicatorInfo
+	for id, indicator := range economicIndicators {
+		indicators = append(indicators, models.EconomicIndicatorInfo{
+			ID:         id,
+			Name:       indicator.name,
+			Intervals:  indicator.intervals,
+			Maturities: indicator.maturities,
+		})
+	}
+	sort.Slice(indicators, func(i, j int) bool {
+		return indicators[i].ID < indicators[j].ID
+	})
+	return indicators
+}
+
+// GetIndicator retrieves the time series for an indicator. Empty interval and maturity
+// select the indicator defaults, and a positive limit keeps only the most recent points.
+func (s *EconomicsService) GetIndicator(ctx context.Context, id, interval, maturity string, limit int) (*models.EconomicSeries, error) {
+	indicator, ok := economicIndicators[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownIndicator, id)
+	}
+
+	params := map[string]string{}
+	if interval == "" {
+		interval = indicator.intervals[0]
+	}
+	if !contains(indicator.intervals, interval) {
+		return nil, fmt.Errorf("%w: interval %q is not supported by %s", ErrInvalidIndicatorOption, interval, id)
+	}
+	params["interval"] = interval
+
+	if len(indicator.maturities) > 0 {
+		if maturity == "" {
+			maturity = indicator.maturities[0]
+		}
+		if !contains(indicator.maturities, maturity) {
+			return nil, fmt.Errorf("%w: maturity %q is not supported by %s", ErrInvalidIndicatorOption, maturity, id)
+		}
+		params["maturity"] = maturity
+	} else {
+		maturity = ""
+	}
+
+	result, err := s.AlphaVantage.query(ctx, indicator.function, params)
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := result["data"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid %s data", indicator.function)
+	}
+
+	series := &models.EconomicSeries{
+		Indicator: id,
+		Name:      getString(result["name"]),
+		Interval:  interval,
+		Unit:      getString(result["unit"]),
+		Maturity:  maturity,
+		Data:      []models.EconomicDataPoint{},
+	}
+	if series.Name == "" {
+		series.Name = indicator.name
+	}
+
+	for _, item := range items {
+		point, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// Alpha Vantage marks missing observations with "."
+		value := strings.TrimSpace(getString(point["value"]))
+		if value == "." || value == "" {
+			continue
+		}
+		series.Data = append(series.Data, models.EconomicDataPoint{
+			Date:  getString(point["date"]),
+			Value: parseNumber(value),
+		})
+		if limit > 0 && len(series.Data) >= limit {
+			break
+		}
+	}
+
+	return series, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// readJSON decodes the JSON file at path into v, describing the data as what in errors.
+// It returns false without an error when the file does not exist.
+func readJSON(path string, v any, what string) (bool, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", what, err)
+	}
+	if err := json.Unmarshal(content, v); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", what, err)
+	}
+	return true, nil
+}
+
+// writeJSONAtomic writes v to path as indented JSON, describing the data as what in errors
+func writeJSONAtomic(path string, v any, what string) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", what, err)
+	}
+	return writeFileAtomic(path, content, what)
+}
+
+// writeFileAtomic replaces the file at path with content through a temporary file, so that
+// readers never see a partial write
+func writeFileAtomic(path string, content []byte, what string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0644); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"financehub/portfolio"
+)
+
+// fullHistoryLimit requests every available daily bar
+const fullHistoryLimit = 100000
+
+// PortfolioService computes portfolio analytics from holdings and daily price history.
+// Requests without corporate actions use the dividends and splits recorded in Actions;
+// an explicit empty list turns them off.
+type PortfolioService struct {
+	Prices  *TimeSeriesStore
+	Actions *CorporateActionService
+}
+
+// NewPortfolioService creates a new portfolio service
+func NewPortfolioService(prices *TimeSeriesStore, actions *CorporateActionService) *PortfolioService {
+	return &PortfolioService{Prices: prices, Actions: actions}
+}
+
+// GetPerformance fetches daily closes for every traded symbol and the benchmark,
+// then computes time-weighted and money-weighted returns over the requested range
+func (s *PortfolioService) GetPerformance(ctx context.Context, req portfolio.PerformanceRequest) (*portfolio.Performance, error) {
+	req.Actions = s.recordedActions(req.Actions, req.Transactions)
+	symbols := portfolio.Symbols(req.Transactions)
+	if benchmark := strings.ToUpper(strings.TrimSpace(req.Benchmark)); benchmark != "" && !contains(symbols, benchmark) {
+		symbols = append(symbols, benchmark)
+	}
+
+	prices := make(map[string][]portfolio.PricePoint, len(symbols))
+	for _, symbol := range symbols {
+		history, err := s.PriceHistory(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		prices[symbol] = history
+	}
+
+	return portfolio.CalculatePerformance(req, prices)
+}
+
+// GetRealizedGains relieves tax lots, adjusted for recorded splits, and reports realized gains
+func (s *PortfolioService) GetRealizedGains(req portfolio.GainsRequest) (*portfolio.GainsReport, error) {
+	req.Actions = s.recordedActions(req.Actions, req.Transactions)
+	return portfolio.CalculateRealizedGains(req)
+}
+
+// GetIncome reports dividend income per holding and projected annual income from recorded dividends and splits
+func (s *PortfolioService) GetIncome(req portfolio.IncomeRequest) (*portfolio.IncomeReport, error) {
+	req.Actions = s.recordedActions(req.Actions, req.Transactions)
+	return portfolio.CalculateIncome(req)
+}
+
+// recordedActions returns the requested actions, or the traded symbols' recorded actions when none were given
+func (s *PortfolioService) recordedActions(actions []portfolio.CorporateAction, transactions []portfolio.Transaction) []portfolio.CorporateAction {
+	if actions != nil || s.Actions == nil {
+		return actions
+	}
+	return s.Actions.ListActions(portfolio.Symbols(transactions)...)
+}
+
+// PriceHistory returns a symbol's full daily closing price history, oldest first
+func (s *PortfolioService) PriceHistory(ctx context.Context, symbol string) ([]portfolio.PricePoint, error) {
+	series, err := s.Prices.Daily(ctx, symbol, PriceSourceStock, fullHistoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]portfolio.PricePoint, len(series))
+	for i, bar := range series {
+		history[len(series)-1-i] = portfolio.PricePoint{Date: bar.Date, Close: bar.Close}
+	}
+	return history, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+
+	"financehub/portfolio"
+	"financehub/risk"
+)
+
+// RiskService computes risk metrics for holdings using daily stock and crypto price history
+type RiskService struct {
+	Prices *TimeSeriesStore
+}
+
+// NewRiskService creates a new risk service
+func NewRiskService(prices *TimeSeriesStore) *RiskService {
+	return &RiskService{Prices: prices}
+}
+
+// Analyze fetches daily closes for every holding and the benchmark and computes their risk metrics
+func (s *RiskService) Analyze(ctx context.Context, req risk.Request) (*risk.Report, error) {
+	req.Holdings = append([]risk.Holding{}, req.Holdings...)
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+
+	assets := make(map[string]string, len(req.Holdings)+1)
+	for _, h := range req.Holdings {
+		assets[h.Symbol] = h.Source
+	}
+	if req.Benchmark != "" {
+		assets[req.Benchmark] = req.BenchmarkSource
+	}
+
+	prices := make(map[string][]portfolio.PricePoint, len(assets))
+	for symbol, source := range assets {
+		history, err := s.dailyCloses(ctx, symbol, source, req.Days+1)
+		if err != nil {
+			return nil, err
+		}
+		prices[symbol] = history
+	}
+
+	return risk.Analyze(req, prices)
+}
+
+// dailyCloses returns at least the last observations trading days of closes for a stock or coin
+func (s *RiskService) dailyCloses(ctx context.Context, symbol, source string, observations int) ([]portfolio.PricePoint, error) {
+	if source == risk.SourceCrypto {
+		// Crypto trades every day, so cover enough calendar days to overlap stock trading days
+		observations = observations*365/252 + 7
+	}
+	series, err := s.Prices.Daily(ctx, symbol, source, observations)
+	if err != nil {
+		return nil, err
+	}
+
+	closes := make([]portfolio.PricePoint, len(series))
+	for i, bar := range series {
+		closes[i] = portfolio.PricePoint{Date: bar.Date, Close: bar.Close}
+	}
+	return closes, nil
+}
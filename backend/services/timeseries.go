@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"financehub/models"
+)
+
+// Time series price sources
+const (
+	PriceSourceStock  = "stock"
+	PriceSourceCrypto = "crypto"
+)
+
+// IntervalDaily is the interval of daily bars
+const IntervalDaily = "daily"
+
+const (
+	// compactBars is the number of recent bars Alpha Vantage returns without a full-history request
+	compactBars = 100
+	// compactWindow is the longest gap since the last stored bar that a compact request still covers
+	compactWindow = 120 * 24 * time.Hour
+)
+
+// StoredSeries is a symbol's price history at one interval, oldest first. Complete is set once
+// the earliest available bar has been fetched.
+type StoredSeries struct {
+	Symbol    string                  `json:"symbol"`
+	Source    string                  `json:"source"`
+	Interval  string                  `json:"interval"`
+	UpdatedAt time.Time               `json:"updatedAt"`
+	Complete  bool                    `json:"complete"`
+	Bars      []models.TimeSeriesData `json:"bars"`
+}
+
+// SeriesInfo summarises a stored series
+type SeriesInfo struct {
+	Symbol    string    `json:"symbol"`
+	Source    string    `json:"source"`
+	Interval  string    `json:"interval"`
+	Bars      int       `json:"bars"`
+	FirstDate string    `json:"firstDate"`
+	LastDate  string    `json:"lastDate"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Complete  bool      `json:"complete"`
+}
+
+// TimeSeriesStore keeps daily stock and crypto bars on disk, keyed by source, symbol and interval,
+// so that history is downloaded once. Series are served from the store while they are younger than
+// MaxAge and cover the requested bars; otherwise only the missing recent dates are fetched and merged.
+// When a refresh fails the stored bars are served, so analytics keep working offline.
+// Series are saved as JSON files under Dir; an empty Dir keeps them in memory only.
+type TimeSeriesStore struct {
+	Dir          string
+	MaxAge       time.Duration
+	AlphaVantage *AlphaVantageService
+	CoinGecko    *CoinGeckoService
+
+	mu     sync.Mutex
+	series map[string]*StoredSeries
+}
+
+// NewTimeSeriesStore creates a time series store that keeps series under dir
+func NewTimeSeriesStore(dir string, alphaVantage *AlphaVantageService, coinGecko *CoinGeckoService) *TimeSeriesStore {
+	return &TimeSeriesStore{
+		Dir:          dir,
+		MaxAge:       6 * time.Hour,
+		AlphaVantage: alphaVantage,
+		CoinGecko:    coinGecko,
+		series:       make(map[string]*StoredSeries),
+	}
+}
+
+// Daily returns up to limit of a stock's or coin's most recent daily bars, newest first.
+// Stock symbols are upper-cased and coin IDs lower-cased.
+func (s *TimeSeriesStore) Daily(ctx context.Context, symbol, source string, limit int) ([]models.TimeSeriesData, error) {
+	symbol, source = normalizeSeries(symbol, source)
+	stored, err := s.load(source, symbol, IntervalDaily)
+	if err != nil {
+		slog.WarnContext(ctx, "Stored prices unavailable, fetching them again", "symbol", symbol, "error", err)
+	}
+	covered := stored != nil && (stored.Complete || len(stored.Bars) >= limit)
+	if covered && time.Since(stored.UpdatedAt) < s.MaxAge {
+		return newestBars(stored.Bars, limit), nil
+	}
+
+	updated, err := s.update(ctx, symbol, source, stored, covered, limit)
+	if err != nil {
+		if stored != nil && len(stored.Bars) > 0 {
+			slog.WarnContext(ctx, "Serving stored prices, refresh failed", "symbol", symbol, "error", err)
+			return newestBars(stored.Bars, limit), nil
+		}
+		return nil, err
+	}
+	return newestBars(updated.Bars, limit), nil
+}
+
+// Refresh fetches the dates missing from a stored series regardless of its age, or limit bars of
+// a series that has not been stored. Unlike Daily it reports failed fetches.
+func (s *TimeSeriesStore) Refresh(ctx context.Context, symbol, source string, limit int) error {
+	symbol, source = normalizeSeries(symbol, source)
+	stored, err := s.load(source, symbol, IntervalDaily)
+	if err != nil {
+		slog.WarnContext(ctx, "Stored prices unavailable, fetching them again", "symbol", symbol, "error", err)
+	}
+	_, err = s.update(ctx, symbol, source, stored, stored != nil, limit)
+	return err
+}
+
+// Prune drops series kept in memory that are also saved under Dir; they are read again when needed
+func (s *TimeSeriesStore) Prune() int {
+	if s.Dir == "" {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pruned := len(s.series)
+	s.series = make(map[string]*StoredSeries)
+	return pruned
+}
+
+// update fetches the bars missing from a series, merges them into the stored bars and saves the result
+func (s *TimeSeriesStore) update(ctx context.Context, symbol, source string, stored *StoredSeries, covered bool, limit int) (*StoredSeries, error) {
+	bars, complete, err := s.fetch(ctx, symbol, source, stored, covered, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &StoredSeries{
+		Symbol:    symbol,
+		Source:    source,
+		Interval:  IntervalDaily,
+		UpdatedAt: time.Now().UTC(),
+		Complete:  complete,
+		Bars:      bars,
+	}
+	if stored != nil {
+		updated.Complete = complete || stored.Complete
+		updated.Bars = mergeBars(stored.Bars, bars)
+	}
+	if err := s.save(updated); err != nil {
+		slog.WarnContext(ctx, "Time series store unavailable, prices will not be kept", "symbol", symbol, "error", err)
+	}
+	return updated, nil
+}
+
+// List summarises the stored series, sorted by source and symbol
+func (s *TimeSeriesStore) List() ([]SeriesInfo, error) {
+	if s.Dir != "" {
+		files, err := filepath.Glob(filepath.Join(s.Dir, "*", "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list time series: %w", err)
+		}
+		for _, file := range files {
+			if _, err := s.read(file); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos := make([]SeriesInfo, 0, len(s.series))
+	for _, series := range s.series {
+		info := SeriesInfo{
+			Symbol:    series.Symbol,
+			Source:    series.Source,
+			Interval:  series.Interval,
+			Bars:      len(series.Bars),
+			UpdatedAt: series.UpdatedAt,
+			Complete:  series.Complete,
+		}
+		if len(series.Bars) > 0 {
+			info.FirstDate = series.Bars[0].Date
+			info.LastDate = series.Bars[len(series.Bars)-1].Date
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].Source != infos[j].Source {
+			return infos[i].Source < infos[j].Source
+		}
+		if infos[i].Symbol != infos[j].Symbol {
+			return infos[i].Symbol < infos[j].Symbol
+		}
+		return infos[i].Interval < infos[j].Interval
+	})
+	return infos, nil
+}
+
+// fetch downloads the bars missing from a stored series. Covered series only need the dates since
+// their last bar; otherwise as much history as limit requires is fetched. Fetched bars always reach
+// back to the last stored bar, so that merging them leaves no gap, which takes the full history
+// once that bar is older than compactWindow. complete reports whether the earliest available bar
+// was reached.
+func (s *TimeSeriesStore) fetch(ctx context.Context, symbol, source string, stored *StoredSeries, covered bool, limit int) (bars []models.TimeSeriesData, complete bool, err error) {
+	var since time.Duration
+	merging := stored != nil && len(stored.Bars) > 0
+	if merging {
+		last, _ := time.Parse("2006-01-02", stored.Bars[len(stored.Bars)-1].Date)
+		since = time.Since(last)
+	} else {
+		covered = false
+	}
+
+	if source == PriceSourceCrypto {
+		days := limit
+		if covered {
+			days = int(since.Hours()/24) + 1
+		} else if merging {
+			days = max(days, int(since.Hours()/24)+1)
+		}
+		days = max(days, 2)
+		bars, err = s.CoinGecko.GetDailyPrices(ctx, symbol, days)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to fetch %s prices: %w", symbol, err)
+		}
+		return oldestFirst(bars), !covered && len(bars) < days, nil
+	}
+
+	request := limit
+	switch {
+	case merging && since >= compactWindow:
+		request = fullHistoryLimit
+	case covered:
+		request = compactBars
+	case merging:
+		request = max(request, compactBars)
+	}
+	if request > compactBars {
+		request = fullHistoryLimit
+	}
+	bars, err = s.AlphaVantage.GetTimeSeriesDaily(ctx, symbol, request)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to fetch %s prices: %w", symbol, err)
+	}
+	return oldestFirst(bars), request == fullHistoryLimit || len(bars) < request, nil
+}
+
+// load returns a stored series from memory or disk, or nil if it has not been stored
+func (s *TimeSeriesStore) load(source, symbol, interval string) (*StoredSeries, error) {
+	key := seriesKey(source, symbol, interval)
+	s.mu.Lock()
+	series, ok := s.series[key]
+	s.mu.Unlock()
+	if ok || s.Dir == "" {
+		return series, nil
+	}
+
+	series, err := s.read(s.path(source, symbol, interval))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return series, err
+}
+
+// read loads a series file into memory
+func (s *TimeSeriesStore) read(path string) (*StoredSeries, error) {
+	var series StoredSeries
+	found, err := readJSON(path, &series, "time series "+filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, os.ErrNotExist
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := seriesKey(series.Source, series.Symbol, series.Interval)
+	if cached, ok := s.series[key]; ok {
+		return cached, nil
+	}
+	s.series[key] = &series
+	return &series, nil
+}
+
+// save keeps a series in memory and writes it to its file atomically
+func (s *TimeSeriesStore) save(series *StoredSeries) error {
+	s.mu.Lock()
+	s.series[seriesKey(series.Source, series.Symbol, series.Interval)] = series
+	s.mu.Unlock()
+	if s.Dir == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(series)
+	if err != nil {
+		return fmt.Errorf("failed to encode time series: %w", err)
+	}
+	return writeFileAtomic(s.path(series.Source, series.Symbol, series.Interval), content, "time series")
+}
+
+// path returns the file of a series, with characters that are unsafe in file names replaced
+func (s *TimeSeriesStore) path(source, symbol, interval string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, symbol)
+	return filepath.Join(s.Dir, source, name+"_"+interval+".json")
+}
+
+// normalizeSeries upper-cases stock symbols and lower-cases coin IDs
+func normalizeSeries(symbol, source string) (string, string) {
+	symbol = strings.TrimSpace(symbol)
+	if source == PriceSourceCrypto {
+		return strings.ToLower(symbol), source
+	}
+	return strings.ToUpper(symbol), PriceSourceStock
+}
+
+func seriesKey(source, symbol, interval string) string {
+	return source + "/" + symbol + "/" + interval
+}
+
+// mergeBars combines stored and fetched bars by date, oldest first. Fetched bars replace stored
+// bars of the same date, which may have been taken intraday.
+func mergeBars(stored, fetched []models.TimeSeriesData) []models.TimeSeriesData {
+	byDate := make(map[string]models.TimeSeriesData, len(stored)+len(fetched))
+	for _, bar := range stored {
+		byDate[bar.Date] = bar
+	}
+	for _, bar := range fetched {
+		byDate[bar.Date] = bar
+	}
+
+	merged := make([]models.TimeSeriesData, 0, len(byDate))
+	for _, bar := range byDate {
+		merged = append(merged, bar)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Date < merged[j].Date
+	})
+	return merged
+}
+
+// oldestFirst sorts bars by date, oldest first
+func oldestFirst(bars []models.TimeSeriesData) []models.TimeSeriesData {
+	sort.Slice(bars, func(i, j int) bool {
+		return bars[i].Date < bars[j].Date
+	})
+	return bars
+}
+
+// newestBars returns up to limit of the most recent bars of an oldest-first series, newest first
+func newestBars(bars []models.TimeSeriesData, limit int) []models.TimeSeriesData {
+	count := min(limit, len(bars))
+	newest := make([]models.TimeSeriesData, count)
+	for i := range newest {
+		newest[i] = bars[len(bars)-1-i]
+	}
+	return newest
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"financehub/models"
+)
+
+// ErrNoYieldCurveData is returned when no Treasury yields are available on or before the requested date
+var ErrNoYieldCurveData = errors.New("no yield curve data for date")
+
+// treasuryTenor maps a curve tenor to its Alpha Vantage maturity
+type treasuryTenor struct {
+	tenor    string
+	maturity string
+	years    float64
+}
+
+var treasuryTenors = []treasuryTenor{
+	{tenor: "3M", maturity: "3month", years: 0.25},
+	{tenor: "2Y", maturity: "2year", years: 2},
+	{tenor: "5Y", maturity: "5year", years: 5},
+	{tenor: "7Y", maturity: "7year", years: 7},
+	{tenor: "10Y", maturity: "10year", years: 10},
+	{tenor: "30Y", maturity: "30year", years: 30},
+}
+
+// interpolatedTenors are filled in between the observed maturities
+var interpolatedTenors = []struct {
+	tenor string
+	years float64
+}{
+	{tenor: "1Y", years: 1},
+	{tenor: "3Y", years: 3},
+	{tenor: "20Y", years: 20},
+}
+
+// yieldSpreads lists the spreads reported on each curve as long/short tenor pairs
+var yieldSpreads = [][2]string{
+	{"10Y", "2Y"},
+	{"10Y", "3M"},
+	{"30Y", "5Y"},
+}
+
+// YieldCurveService builds Treasury yield curves from economic indicator data and caches
+// them per date and interval, as each curve takes one request per maturity
+type YieldCurveService struct {
+	Economics *EconomicsService
+	TTL       time.Duration
+
+	mu     sync.RWMutex
+	curves map[string]cachedYieldCurve
+}
+
+// maxCachedYieldCurves caps the number of cached curves, as dates are chosen by callers
+const maxCachedYieldCurves = 64
+
+type cachedYieldCurve struct {
+	curve     *models.YieldCurve
+	updatedAt time.Time
+}
+
+// NewYieldCurveService creates a new yield curve service
+func NewYieldCurveService(economics *EconomicsService) *YieldCurveService {
+	return &YieldCurveService{
+		Economics: economics,
+		TTL:       time.Hour,
+		curves:    make(map[string]cachedYieldCurve),
+	}
+}
+
+// GetYieldCurve returns the Treasury yield curve for date (YYYY-MM-DD), an empty date selecting
+// the most recent one. Cached curves are refreshed once older than the TTL; a stale curve is
+// returned if the refresh fails.
+func (s *YieldCurveService) GetYieldCurve(ctx context.Context, date, interval string) (*models.YieldCurve, error) {
+	if interval == "" {
+		interval = "daily"
+	}
+	key := date + "|" + interval
+
+	s.mu.RLock()
+	cached, ok := s.curves[key]
+	s.mu.RUnlock()
+	if ok && time.Since(cached.updatedAt) < s.TTL {
+		return cached.curve, nil
+	}
+
+	curve, err := s.fetchYieldCurve(ctx, date, interval)
+	if err != nil {
+		if ok {
+			return cached.curve, nil
+		}
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.evictCurves(time.Now())
+	s.curves[key] = cachedYieldCurve{curve: curve, updatedAt: time.Now()}
+	s.mu.Unlock()
+	return curve, nil
+}
+
+// evictCurves drops cached curves older than the TTL and, while the cache is still full, the
+// oldest ones, making room for another curve. Callers must hold the write lock.
+func (s *YieldCurveService) evictCurves(now time.Time) {
+	for key, cached := range s.curves {
+		if now.Sub(cached.updatedAt) >= s.TTL {
+			delete(s.curves, key)
+		}
+	}
+	for len(s.curves) >= maxCachedYieldCurves {
+		oldest := ""
+		for key, cached := range s.curves {
+			if oldest == "" || cached.updatedAt.Before(s.curves[oldest].updatedAt) {
+				oldest = key
+			}
+		}
+		delete(s.curves, oldest)
+	}
+}
+
+// fetchYieldCurve fetches Treasury yields for every maturity and builds the curve for date
+func (s *YieldCurveService) fetchYieldCurve(ctx context.Context, date, interval string) (*models.YieldCurve, error) {
+	observations := make(map[string][]models.EconomicDataPoint, len(treasuryTenors))
+	for _, t := range treasuryTenors {
+		series, err := s.Economics.GetIndicator(ctx, "treasury-yield", interval, t.maturity, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s treasury yield: %w", t.tenor, err)
+		}
+		observations[t.tenor] = series.Data
+	}
+
+	return BuildYieldCurve(date, observations)
+}
+
+// BuildYieldCurve builds a curve snapshot from per-tenor observations keyed by tenor (e.g. "10Y").
+// The snapshot is taken on the latest date on or before date that every observed tenor has, so
+// that the curve does not mix days; without such a date each tenor uses its latest observation
+// on or before date, as reported by its ObservedOn. Missing tenors are interpolated.
+func BuildYieldCurve(date string, observations map[string][]models.EconomicDataPoint) (*models.YieldCurve, error) {
+	if date == "" {
+		for _, points := range observations {
+			for _, point := range points {
+				if point.Date > date {
+					date = point.Date
+				}
+			}
+		}
+	}
+	if common, ok := commonObservationDate(observations, date); ok {
+		date = common
+	}
+
+	var points []models.YieldCurvePoint
+	for _, t := range treasuryTenors {
+		observation, ok := latestObservation(observations[t.tenor], date)
+		if !ok {
+			continue
+		}
+		points = append(points, models.YieldCurvePoint{
+			Tenor:      t.tenor,
+			Years:      t.years,
+			Yield:      observation.Value,
+			ObservedOn: observation.Date,
+		})
+	}
+	if len(points) < 2 {
+		return nil, fmt.Errorf("%w %s", ErrNoYieldCurveData, date)
+	}
+
+	observed := points
+	for _, t := range interpolatedTenors {
+		yield, ok := InterpolateYield(observed, t.years)
+		if !ok {
+			continue
+		}
+		points = append(points, models.YieldCurvePoint{
+			Tenor:        t.tenor,
+			Years:        t.years,
+			Yield:        roundTo(yield, 4),
+			Interpolated: true,
+		})
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Years < points[j].Years
+	})
+
+	curve := &models.YieldCurve{
+		Date:    date,
+		Points:  points,
+		Spreads: []models.YieldSpread{},
+	}
+
+	yields := make(map[string]float64, len(points))
+	for _, point := range points {
+		yields[point.Tenor] = point.Yield
+	}
+	for _, pair := range yieldSpreads {
+		long, hasLong := yields[pair[0]]
+		short, hasShort := yields[pair[1]]
+		if !hasLong || !hasShort {
+			continue
+		}
+		value := roundTo(long-short, 4)
+		curve.Spreads = append(curve.Spreads, models.YieldSpread{
+			Name:        pair[0] + "-" + pair[1],
+			Long:        pair[0],
+			Short:       pair[1],
+			Value:       value,
+			BasisPoints: roundTo(value*100, 2),
+			Inverted:    value < 0,
+		})
+		if pair == yieldSpreads[0] && value < 0 {
+			curve.Inverted = true
+		}
+	}
+
+	curve.Shape = classifyCurve(observed)
+	return curve, nil
+}
+
+// InterpolateYield linearly interpolates the yield at years from points sorted by maturity.
+// It returns false when years falls outside the observed range.
+func InterpolateYield(points []models.YieldCurvePoint, years float64) (float64, bool) {
+	for i := 0; i < len(points)-1; i++ {
+		lower, upper := points[i], points[i+1]
+		if years >= lower.Years && years <= upper.Years {
+			if upper.Years == lower.Years {
+				return lower.Yield, true
+			}
+			weight := (years - lower.Years) / (upper.Years - lower.Years)
+			return lower.Yield + weight*(upper.Yield-lower.Yield), true
+		}
+	}
+	return 0, false
+}
+
+// latestObservation returns the newest observation on or before date
+func latestObservation(points []models.EconomicDataPoint, date string) (models.EconomicDataPoint, bool) {
+	var latest models.EconomicDataPoint
+	found := false
+	for _, point := range points {
+		if point.Date <= date && (!found || point.Date > latest.Date) {
+			latest = point
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// commonObservationDate returns the latest date on or before date observed by every tenor
+// with observations up to then
+func commonObservationDate(observations map[string][]models.EconomicDataPoint, date string) (string, bool) {
+	counts := make(map[string]int)
+	tenors := 0
+	for _, t := range treasuryTenors {
+		seen := make(map[string]bool)
+		for _, point := range observations[t.tenor] {
+			if point.Date <= date && !seen[point.Date] {
+				seen[point.Date] = true
+				counts[point.Date]++
+			}
+		}
+		if len(seen) > 0 {
+			tenors++
+		}
+	}
+
+	common := ""
+	for day, count := range counts {
+		if count == tenors && day > common {
+			common = day
+		}
+	}
+	return common, common != ""
+}
+
+// classifyCurve describes the curve as normal, inverted, flat or humped using observed points
+func classifyCurve(points []models.YieldCurvePoint) string {
+	short, long := points[0].Yield, points[len(points)-1].Yield
+
+	peak := math.Inf(-1)
+	for _, point := range points[1 : len(points)-1] {
+		peak = math.Max(peak, point.Yield)
+	}
+
+	switch {
+	case math.Abs(long-short) < 0.25:
+		if peak > math.Max(short, long)+0.25 {
+			return "humped"
+		}
+		return "flat"
+	case long < short:
+		return "inverted"
+	case peak > long:
+		return "humped"
+	default:
+		return "normal"
+	}
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
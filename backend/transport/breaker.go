@@ -0,0 +1,174 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned for requests to a provider whose circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Breaker states
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half-open"
+)
+
+// Default breaker settings
+const (
+	DefaultThreshold = 5
+	DefaultCooldown  = 30 * time.Second
+)
+
+// BreakerState reports the state of a provider's circuit breaker
+type BreakerState struct {
+	Provider  string `json:"provider"`
+	State     string `json:"state"`
+	Failures  int    `json:"failures"`
+	LastError string `json:"lastError,omitempty"`
+	OpenedAt  string `json:"openedAt,omitempty"`
+	RetryAt   string `json:"retryAt,omitempty"`
+}
+
+// Breaker stops requests to a provider after Threshold consecutive failures. Once Cooldown
+// has passed a single probe request is let through: its success closes the breaker again,
+// its failure reopens it.
+type Breaker struct {
+	Name      string
+	Threshold int
+	Cooldown  time.Duration
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	lastError string
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// NewBreaker creates a closed breaker with the default threshold and cooldown
+func NewBreaker(name string) *Breaker {
+	return &Breaker{
+		Name:      name,
+		Threshold: DefaultThreshold,
+		Cooldown:  DefaultCooldown,
+		state:     StateClosed,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a request may be sent, moving an open breaker to half-open once its
+// cooldown has passed. Every allowed request must be followed by Success, Failure or Release.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && !b.now().Before(b.openedAt.Add(b.Cooldown)) {
+		b.state = StateHalfOpen
+	}
+	switch b.state {
+	case StateOpen:
+		return fmt.Errorf("%s: %w until %s", b.Name, ErrCircuitOpen, b.openedAt.Add(b.Cooldown).Format(time.RFC3339))
+	case StateHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%s: %w while a probe request is in flight", b.Name, ErrCircuitOpen)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Success records a request the provider answered, closing the breaker
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateClosed {
+		slog.Info("Circuit breaker closed", "provider", b.Name)
+	}
+	b.state = StateClosed
+	b.failures = 0
+	b.lastError = ""
+	b.probing = false
+}
+
+// Failure records a request the provider failed, opening the breaker at the threshold or
+// when the half-open probe fails
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if err != nil {
+		b.lastError = err.Error()
+	}
+	if b.state == StateHalfOpen || (b.state == StateClosed && b.failures >= b.Threshold) {
+		b.state = StateOpen
+		b.openedAt = b.now()
+		slog.Warn("Circuit breaker opened", "provider", b.Name, "failures", b.failures,
+			"cooldown", b.Cooldown.String(), "error", b.lastError)
+	}
+	b.probing = false
+}
+
+// Release ends an allowed request without an outcome, e.g. one the caller cancelled
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// State returns a snapshot of the breaker
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := BreakerState{
+		Provider:  b.Name,
+		State:     b.state,
+		Failures:  b.failures,
+		LastError: b.lastError,
+	}
+	if b.state != StateClosed {
+		state.OpenedAt = b.openedAt.Format(time.RFC3339)
+		state.RetryAt = b.openedAt.Add(b.Cooldown).Format(time.RFC3339)
+	}
+	return state
+}
+
+var breakers = struct {
+	sync.Mutex
+	byName map[string]*Breaker
+}{byName: make(map[string]*Breaker)}
+
+// BreakerFor returns the breaker shared by all clients of the named provider
+func BreakerFor(name string) *Breaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+
+	b, ok := breakers.byName[name]
+	if !ok {
+		b = NewBreaker(name)
+		breakers.byName[name] = b
+	}
+	return b
+}
+
+// States returns the state of every provider's shared breaker, sorted by provider
+func States() []BreakerState {
+	breakers.Lock()
+	states := make([]BreakerState, 0, len(breakers.byName))
+	for _, b := range breakers.byName {
+		states = append(states, b.State())
+	}
+	breakers.Unlock()
+
+	sort.Slice(states, func(i, j int) bool { return states[i].Provider < states[j].Provider })
+	return states
+}
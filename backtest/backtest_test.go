@@ -0,0 +1,155 @@
+package backtest
+
+import (
+	"testing"
+	"time"
+
+	"financehub/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testBars builds daily bars from closes, opening each bar at the previous close
+func testBars(closes ...float64) []models.TimeSeriesData {
+	start := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	bars := make([]models.TimeSeriesData, len(closes))
+	for i, close := range closes {
+		open := close
+		if i > 0 {
+			open = closes[i-1]
+		}
+		bars[i] = models.TimeSeriesData{Date: start.AddDate(0, 0, i).Format(dateLayout), Open: open, Close: close}
+	}
+	return bars
+}
+
+func TestIndicators(t *testing.T) {
+	bars := testBars(1, 2, 3, 4, 5)
+	assert.Equal(t, 4.0, SMA(bars, 3))
+	assert.Equal(t, 0.0, SMA(bars, 6))
+
+	assert.Equal(t, 100.0, RSI(bars, 3))
+	assert.Equal(t, 50.0, RSI(bars, 5))
+	// Two gains of 1 and two losses of 1 over four changes
+	assert.InDelta(t, 50.0, RSI(testBars(10, 11, 10, 11, 10), 4), 1e-9)
+	// Averages of 2/3 gain and 1/3 loss are smoothed to 4/9 and 5/9, then to 17/27 and 10/27
+	assert.InDelta(t, 100-100/(1+17.0/10), RSI(testBars(10, 11, 12, 11, 10, 11), 3), 1e-9)
+}
+
+func TestBuyAndHold(t *testing.T) {
+	strategy, err := NewStrategy(BuyAndHold, Params{})
+	assert.NoError(t, err)
+
+	// Bars are sorted by date, so reversing them gives the same result
+	bars := testBars(100, 100, 110, 99, 121)
+	reversed := make([]models.TimeSeriesData, len(bars))
+	for i, bar := range bars {
+		reversed[len(bars)-1-i] = bar
+	}
+
+	result, err := Run(strategy, reversed, Settings{StartingCash: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, BuyAndHold, result.Strategy)
+	assert.Equal(t, "2023-01-02", result.StartDate)
+	// The first bar's buy fills at the second bar's open of 100
+	assert.Len(t, result.Trades, 1)
+	assert.Equal(t, "2023-01-03", result.Trades[0].EntryDate)
+	assert.True(t, result.Trades[0].Open)
+	assert.Equal(t, 10.0, result.Trades[0].Quantity)
+	assert.Equal(t, 1210.0, result.EndingEquity)
+	assert.Equal(t, 0.21, result.TotalReturn)
+	assert.Equal(t, 0.21, result.BuyAndHoldReturn)
+	assert.Equal(t, 0.1, result.MaxDrawdown)
+	assert.Equal(t, 0.8, result.Exposure)
+	assert.Equal(t, 0.0, result.WinRate)
+	assert.Equal(t, []float64{1000, 1000, 1100, 990, 1210}, equities(result))
+	assert.Equal(t, 0.1, result.EquityCurve[3].Drawdown)
+}
+
+func TestSMACrossover(t *testing.T) {
+	strategy, err := NewStrategy(SMACrossover, Params{FastPeriod: 2, SlowPeriod: 3})
+	assert.NoError(t, err)
+
+	// The fast average rises above the slow one on the fifth bar and falls below it on the eighth,
+	// and each signal fills at the next bar's open
+	bars := testBars(10, 9, 8, 10, 12, 14, 10, 8, 8)
+	result, err := Run(strategy, bars, Settings{StartingCash: 1200, Commission: 1, CommissionRate: 0.01})
+	assert.NoError(t, err)
+
+	assert.Len(t, result.Trades, 1)
+	trade := result.Trades[0]
+	assert.Equal(t, "2023-01-07", trade.EntryDate)
+	assert.Equal(t, 12.0, trade.EntryPrice)
+	assert.Equal(t, "2023-01-10", trade.ExitDate)
+	assert.Equal(t, 8.0, trade.ExitPrice)
+	assert.False(t, trade.Open)
+	// 1,199 after the flat fee buys 98.927393 shares at 12 plus 1%, and the exit pays 1 plus 1% of 791.42
+	assert.InDelta(t, 98.927393, trade.Quantity, 1e-6)
+	assert.Equal(t, 21.79, trade.Fees)
+	assert.Equal(t, -417.5, trade.Profit)
+	assert.Equal(t, 0.0, result.WinRate)
+	assert.Equal(t, 782.5, result.EndingEquity)
+	assert.Equal(t, 21.79, result.TotalFees)
+}
+
+func TestRSIReversion(t *testing.T) {
+	strategy, err := NewStrategy(RSIReversion, Params{RSIPeriod: 2, Oversold: 20, Overbought: 80})
+	assert.NoError(t, err)
+
+	// The RSI falls to 0 on the third bar and rises to 87.5 on the seventh
+	result, err := Run(strategy, testBars(10, 9, 8, 7, 8, 9, 10, 9), Settings{StartingCash: 800})
+	assert.NoError(t, err)
+	assert.Len(t, result.Trades, 1)
+	assert.Equal(t, 8.0, result.Trades[0].EntryPrice)
+	assert.Equal(t, 10.0, result.Trades[0].ExitPrice)
+	assert.Equal(t, 200.0, result.Trades[0].Profit)
+	assert.Equal(t, 0.25, result.Trades[0].Return)
+	assert.Equal(t, 1.0, result.WinRate)
+	assert.Equal(t, 1000.0, result.EndingEquity)
+}
+
+func TestValidation(t *testing.T) {
+	strategies := []struct {
+		name   string
+		params Params
+	}{
+		{"momentum", Params{}},
+		{SMACrossover, Params{FastPeriod: 20, SlowPeriod: 10}},
+		{SMACrossover, Params{SlowPeriod: 2000}},
+		{RSIReversion, Params{Oversold: 80, Overbought: 70}},
+		{RSIReversion, Params{RSIPeriod: 1}},
+	}
+	for _, tt := range strategies {
+		_, err := NewStrategy(tt.name, tt.params)
+		assert.ErrorIs(t, err, ErrInvalidBacktest, tt.name)
+	}
+
+	requests := []Request{
+		{Strategy: BuyAndHold},
+		{Symbol: "AAPL", Source: "bonds", Strategy: BuyAndHold},
+		{Symbol: "AAPL", Strategy: BuyAndHold, Days: 1},
+		{Symbol: "AAPL", Strategy: BuyAndHold, Settings: Settings{Slippage: 1}},
+	}
+	for _, req := range requests {
+		assert.ErrorIs(t, req.Normalize(), ErrInvalidBacktest)
+	}
+
+	req := Request{Symbol: " bitcoin ", Source: SourceCrypto, Strategy: "SMA-Crossover"}
+	assert.NoError(t, req.Normalize())
+	assert.Equal(t, "bitcoin", req.Symbol)
+	assert.Equal(t, SMACrossover, req.Strategy)
+	assert.Equal(t, 365.0, req.Settings.PeriodsPerYear)
+	assert.Equal(t, 10000.0, req.Settings.StartingCash)
+
+	strategy, _ := NewStrategy(BuyAndHold, Params{})
+	_, err := Run(strategy, testBars(100), Settings{})
+	assert.ErrorIs(t, err, ErrInvalidBacktest)
+}
+
+func equities(result *Result) []float64 {
+	values := make([]float64, len(result.EquityCurve))
+	for i, point := range result.EquityCurve {
+		values[i] = point.Equity
+	}
+	return values
+}
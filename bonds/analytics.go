@@ -0,0 +1,127 @@
+package bonds
+
+import (
+	"fmt"
+	"time"
+)
+
+// AnalyticsRequest describes a bond and either its yield or its price.
+// When Yield is set it takes precedence; otherwise the yield is solved from Price.
+// Call and accrued-interest fields are optional.
+type AnalyticsRequest struct {
+	Bond           Bond     `json:"bond"`
+	Yield          *float64 `json:"yield,omitempty"`
+	Price          *float64 `json:"price,omitempty"`
+	CallPrice      float64  `json:"callPrice,omitempty"`
+	YearsToCall    float64  `json:"yearsToCall,omitempty"`
+	DayCount       DayCount `json:"dayCount,omitempty"`
+	LastCouponDate string   `json:"lastCouponDate,omitempty"`
+	NextCouponDate string   `json:"nextCouponDate,omitempty"`
+	SettlementDate string   `json:"settlementDate,omitempty"`
+}
+
+// Analytics holds the computed bond measures
+type Analytics struct {
+	Price            float64  `json:"price"`
+	YieldToMaturity  float64  `json:"yieldToMaturity"`
+	YieldToCall      *float64 `json:"yieldToCall,omitempty"`
+	CurrentYield     float64  `json:"currentYield"`
+	MacaulayDuration float64  `json:"macaulayDuration"`
+	ModifiedDuration float64  `json:"modifiedDuration"`
+	Convexity        float64  `json:"convexity"`
+	AccruedInterest  float64  `json:"accruedInterest"`
+	DirtyPrice       float64  `json:"dirtyPrice"`
+}
+
+// AccruedInterestRequest describes an accrued interest calculation
+type AccruedInterestRequest struct {
+	Bond           Bond     `json:"bond"`
+	DayCount       DayCount `json:"dayCount"`
+	LastCouponDate string   `json:"lastCouponDate"`
+	NextCouponDate string   `json:"nextCouponDate"`
+	SettlementDate string   `json:"settlementDate"`
+}
+
+// Analyze computes price, yields, duration, convexity and accrued interest for a request.
+// The price is treated as the clean price; DirtyPrice adds accrued interest.
+func Analyze(req AnalyticsRequest) (*Analytics, error) {
+	if err := req.Bond.Validate(); err != nil {
+		return nil, err
+	}
+
+	analytics := &Analytics{}
+	switch {
+	case req.Yield != nil:
+		analytics.YieldToMaturity = *req.Yield
+		analytics.Price, _ = Price(req.Bond, *req.Yield)
+	case req.Price != nil:
+		ytm, err := YieldToMaturity(req.Bond, *req.Price)
+		if err != nil {
+			return nil, err
+		}
+		analytics.Price = *req.Price
+		analytics.YieldToMaturity = ytm
+	default:
+		return nil, fmt.Errorf("%w: either yield or price is required", ErrInvalidBond)
+	}
+
+	if req.CallPrice > 0 || req.YearsToCall > 0 {
+		ytc, err := YieldToCall(req.Bond, analytics.Price, req.CallPrice, req.YearsToCall)
+		if err != nil {
+			return nil, err
+		}
+		analytics.YieldToCall = &ytc
+	}
+
+	analytics.CurrentYield = req.Bond.FaceValue * req.Bond.CouponRate / analytics.Price
+	analytics.MacaulayDuration, _ = MacaulayDuration(req.Bond, analytics.YieldToMaturity)
+	analytics.ModifiedDuration, _ = ModifiedDuration(req.Bond, analytics.YieldToMaturity)
+	analytics.Convexity, _ = Convexity(req.Bond, analytics.YieldToMaturity)
+
+	if req.SettlementDate != "" {
+		accrued, err := CalculateAccruedInterest(AccruedInterestRequest{
+			Bond:           req.Bond,
+			DayCount:       req.DayCount,
+			LastCouponDate: req.LastCouponDate,
+			NextCouponDate: req.NextCouponDate,
+			SettlementDate: req.SettlementDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		analytics.AccruedInterest = accrued
+	}
+	analytics.DirtyPrice = analytics.Price + analytics.AccruedInterest
+
+	return analytics, nil
+}
+
+// CalculateAccruedInterest parses the request dates and returns the accrued interest
+func CalculateAccruedInterest(req AccruedInterestRequest) (float64, error) {
+	lastCoupon, err := time.Parse(DateLayout, req.LastCouponDate)
+	if err != nil {
+		return 0, fmt.Errorf("%w: lastCouponDate must be YYYY-MM-DD", ErrInvalidBond)
+	}
+	settlement, err := time.Parse(DateLayout, req.SettlementDate)
+	if err != nil {
+		return 0, fmt.Errorf("%w: settlementDate must be YYYY-MM-DD", ErrInvalidBond)
+	}
+
+	// The next coupon date is only required for ACT/ACT; default it from the frequency
+	nextCoupon := lastCoupon
+	if req.Bond.Frequency > 0 {
+		nextCoupon = lastCoupon.AddDate(0, 12/req.Bond.Frequency, 0)
+	}
+	if req.NextCouponDate != "" {
+		nextCoupon, err = time.Parse(DateLayout, req.NextCouponDate)
+		if err != nil {
+			return 0, fmt.Errorf("%w: nextCouponDate must be YYYY-MM-DD", ErrInvalidBond)
+		}
+	}
+
+	convention := req.DayCount
+	if convention == "" {
+		convention = Thirty360
+	}
+	return AccruedInterest(req.Bond, convention, lastCoupon, nextCoupon, settlement)
+}
@@ -0,0 +1,142 @@
+package bonds
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		bond     Bond
+		yield    float64
+		expected float64
+	}{
+		{
+			name:     "Premium bond - 10 year 8% semiannual at 6%",
+			bond:     Bond{FaceValue: 1000, CouponRate: 0.08, Frequency: 2, YearsToMaturity: 10},
+			yield:    0.06,
+			expected: 1148.77,
+		},
+		{
+			name:     "Discount bond - 18 year 6% semiannual at 9.5%",
+			bond:     Bond{FaceValue: 1000, CouponRate: 0.06, Frequency: 2, YearsToMaturity: 18},
+			yield:    0.095,
+			expected: 700.89,
+		},
+		{
+			name:     "Par bond",
+			bond:     Bond{FaceValue: 1000, CouponRate: 0.05, Frequency: 1, YearsToMaturity: 5},
+			yield:    0.05,
+			expected: 1000,
+		},
+		{
+			name:     "Zero yield",
+			bond:     Bond{FaceValue: 100, CouponRate: 0.04, Frequency: 2, YearsToMaturity: 2},
+			yield:    0,
+			expected: 108,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := Price(tt.bond, tt.yield)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, price, 0.01)
+		})
+	}
+}
+
+func TestYieldToMaturity(t *testing.T) {
+	bond := Bond{FaceValue: 1000, CouponRate: 0.06, Frequency: 2, YearsToMaturity: 18}
+
+	ytm, err := YieldToMaturity(bond, 700.89)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.095, ytm, 1e-5)
+
+	// Round trip through price
+	premium := Bond{FaceValue: 1000, CouponRate: 0.08, Frequency: 2, YearsToMaturity: 10}
+	price, _ := Price(premium, 0.0625)
+	ytm, err = YieldToMaturity(premium, price)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.0625, ytm, 1e-9)
+}
+
+func TestYieldToCall(t *testing.T) {
+	bond := Bond{FaceValue: 1000, CouponRate: 0.11, Frequency: 2, YearsToMaturity: 18}
+
+	ytc, err := YieldToCall(bond, 1168.97, 1055, 13)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.09, ytc, 1e-4)
+
+	_, err = YieldToCall(bond, 1168.97, 1055, 20)
+	assert.True(t, errors.Is(err, ErrInvalidBond), "Call date after maturity should be rejected")
+}
+
+func TestDurationAndConvexity(t *testing.T) {
+	bond := Bond{FaceValue: 1000, CouponRate: 0.10, Frequency: 1, YearsToMaturity: 3}
+
+	macaulay, err := MacaulayDuration(bond, 0.10)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.7355, macaulay, 1e-4)
+
+	modified, err := ModifiedDuration(bond, 0.10)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.4869, modified, 1e-4)
+
+	convexity, err := Convexity(bond, 0.10)
+	assert.NoError(t, err)
+	assert.InDelta(t, 8.7562, convexity, 1e-4)
+
+	zero := Bond{FaceValue: 1000, CouponRate: 0, Frequency: 2, YearsToMaturity: 5}
+	macaulay, err = MacaulayDuration(zero, 0.04)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5.0, macaulay, 1e-9, "Zero-coupon duration equals maturity")
+}
+
+func TestBondValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		bond Bond
+	}{
+		{name: "Zero face value", bond: Bond{FaceValue: 0, CouponRate: 0.05, Frequency: 2, YearsToMaturity: 5}},
+		{name: "Negative coupon", bond: Bond{FaceValue: 1000, CouponRate: -0.01, Frequency: 2, YearsToMaturity: 5}},
+		{name: "Unsupported frequency", bond: Bond{FaceValue: 1000, CouponRate: 0.05, Frequency: 3, YearsToMaturity: 5}},
+		{name: "No coupon periods", bond: Bond{FaceValue: 1000, CouponRate: 0.05, Frequency: 1, YearsToMaturity: 0.2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Price(tt.bond, 0.05)
+			assert.True(t, errors.Is(err, ErrInvalidBond))
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	price := 1148.77
+	analytics, err := Analyze(AnalyticsRequest{
+		Bond:           Bond{FaceValue: 1000, CouponRate: 0.08, Frequency: 2, YearsToMaturity: 10},
+		Price:          &price,
+		CallPrice:      1040,
+		YearsToCall:    5,
+		DayCount:       Thirty360,
+		LastCouponDate: "2024-01-15",
+		SettlementDate: "2024-03-15",
+	})
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.06, analytics.YieldToMaturity, 1e-5)
+	assert.NotNil(t, analytics.YieldToCall)
+	assert.InDelta(t, 0.0696, analytics.CurrentYield, 1e-4)
+	assert.Greater(t, analytics.MacaulayDuration, analytics.ModifiedDuration)
+	assert.InDelta(t, 13.3333, analytics.AccruedInterest, 1e-4)
+	assert.InDelta(t, price+13.3333, analytics.DirtyPrice, 1e-4)
+
+	_, err = Analyze(AnalyticsRequest{Bond: Bond{FaceValue: 1000, CouponRate: 0.08, Frequency: 2, YearsToMaturity: 10}})
+	assert.True(t, errors.Is(err, ErrInvalidBond), "Either yield or price is required")
+}
@@ -0,0 +1,67 @@
+package bonds
+
+import (
+	"fmt"
+	"time"
+)
+
+// DayCount identifies a day-count convention
+type DayCount string
+
+// Supported day-count conventions
+const (
+	Thirty360    DayCount = "30/360"
+	ActualActual DayCount = "ACT/ACT"
+	Actual360    DayCount = "ACT/360"
+)
+
+// DateLayout is the date format used by bond requests
+const DateLayout = "2006-01-02"
+
+// Days30360 returns the days between start and end under the US (NASD) 30/360 convention
+func Days30360(start, end time.Time) int {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 >= 30 {
+		d2 = 30
+	}
+	return 360*(y2-y1) + 30*(int(m2)-int(m1)) + (d2 - d1)
+}
+
+// ActualDays returns the calendar days between start and end
+func ActualDays(start, end time.Time) int {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// AccruedInterest returns the coupon interest earned since the last coupon date as of settlement.
+// ACT/ACT follows the ICMA rule and needs the next coupon date to size the period.
+func AccruedInterest(b Bond, convention DayCount, lastCoupon, nextCoupon, settlement time.Time) (float64, error) {
+	if err := b.Validate(); err != nil {
+		return 0, err
+	}
+	if settlement.Before(lastCoupon) {
+		return 0, fmt.Errorf("%w: settlement is before the last coupon date", ErrInvalidBond)
+	}
+
+	annualCoupon := b.FaceValue * b.CouponRate
+	switch convention {
+	case Thirty360:
+		return annualCoupon * float64(Days30360(lastCoupon, settlement)) / 360, nil
+	case Actual360:
+		return annualCoupon * float64(ActualDays(lastCoupon, settlement)) / 360, nil
+	case ActualActual:
+		periodDays := ActualDays(lastCoupon, nextCoupon)
+		if periodDays <= 0 || settlement.After(nextCoupon) {
+			return 0, fmt.Errorf("%w: settlement must fall within the coupon period", ErrInvalidBond)
+		}
+		return b.Coupon() * float64(ActualDays(lastCoupon, settlement)) / float64(periodDays), nil
+	default:
+		return 0, fmt.Errorf("%w: unsupported day count %q", ErrInvalidBond, convention)
+	}
+}
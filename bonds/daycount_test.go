@@ -0,0 +1,73 @@
+package bonds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(value string) time.Time {
+	t, _ := time.Parse(DateLayout, value)
+	return t
+}
+
+func TestDays30360(t *testing.T) {
+	tests := []struct {
+		start    string
+		end      string
+		expected int
+	}{
+		{start: "2024-01-15", end: "2024-03-20", expected: 65},
+		{start: "2024-01-31", end: "2024-03-01", expected: 31},
+		{start: "2024-01-30", end: "2024-03-31", expected: 60},
+		{start: "2024-01-15", end: "2024-03-31", expected: 76},
+		{start: "2023-07-15", end: "2024-01-15", expected: 180},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.start+" to "+tt.end, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Days30360(date(tt.start), date(tt.end)))
+		})
+	}
+}
+
+func TestAccruedInterest(t *testing.T) {
+	bond := Bond{FaceValue: 1000, CouponRate: 0.06, Frequency: 2, YearsToMaturity: 10}
+
+	tests := []struct {
+		name       string
+		convention DayCount
+		last       string
+		next       string
+		settlement string
+		expected   float64
+	}{
+		{name: "30/360", convention: Thirty360, last: "2024-01-31", next: "2024-07-31", settlement: "2024-03-01", expected: 5.1667},
+		{name: "ACT/360", convention: Actual360, last: "2024-01-31", next: "2024-07-31", settlement: "2024-03-01", expected: 5.0},
+		{name: "ACT/ACT", convention: ActualActual, last: "2024-01-31", next: "2024-07-31", settlement: "2024-03-01", expected: 4.9451},
+		{name: "ACT/ACT mid period", convention: ActualActual, last: "2024-01-15", next: "2024-07-15", settlement: "2024-03-20", expected: 10.7143},
+		{name: "On coupon date", convention: Thirty360, last: "2024-01-15", next: "2024-07-15", settlement: "2024-01-15", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accrued, err := AccruedInterest(bond, tt.convention, date(tt.last), date(tt.next), date(tt.settlement))
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, accrued, 1e-4)
+		})
+	}
+}
+
+func TestAccruedInterestErrors(t *testing.T) {
+	bond := Bond{FaceValue: 1000, CouponRate: 0.06, Frequency: 2, YearsToMaturity: 10}
+
+	_, err := AccruedInterest(bond, "ACT/365L", date("2024-01-15"), date("2024-07-15"), date("2024-03-20"))
+	assert.Error(t, err, "Unsupported convention")
+
+	_, err = AccruedInterest(bond, Thirty360, date("2024-01-15"), date("2024-07-15"), date("2024-01-01"))
+	assert.Error(t, err, "Settlement before last coupon")
+
+	_, err = CalculateAccruedInterest(AccruedInterestRequest{Bond: bond, LastCouponDate: "01/15/2024", SettlementDate: "2024-03-20"})
+	assert.Error(t, err, "Invalid date format")
+}
@@ -0,0 +1,62 @@
+package calculators
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrIRRNotFound is returned when cash flows have no internal rate of return
+var ErrIRRNotFound = errors.New("internal rate of return not found")
+
+// NPV returns the net present value of periodic cash flows, the first occurring at period zero
+func NPV(rate float64, cashFlows []float64) float64 {
+	var npv float64
+	for i, cashFlow := range cashFlows {
+		npv += cashFlow / math.Pow(1+rate, float64(i))
+	}
+	return npv
+}
+
+// IRR returns the periodic internal rate of return of cash flows, the first occurring at period zero.
+// Cash flows must contain at least one negative and one positive value.
+func IRR(cashFlows []float64) (float64, error) {
+	var hasNegative, hasPositive bool
+	for _, cashFlow := range cashFlows {
+		hasNegative = hasNegative || cashFlow < 0
+		hasPositive = hasPositive || cashFlow > 0
+	}
+	if !hasNegative || !hasPositive {
+		return 0, ErrIRRNotFound
+	}
+
+	rate, ok := SolveRate(func(rate float64) float64 { return NPV(rate, cashFlows) })
+	if !ok {
+		return 0, ErrIRRNotFound
+	}
+	return rate, nil
+}
+
+// SolveRate finds the rate above -100% at which npv is zero by bracketing a sign change of npv,
+// then bisecting. It returns false when no sign change is found.
+func SolveRate(npv func(rate float64) float64) (float64, bool) {
+	low, high := -0.9999, 1.0
+	for npv(low)*npv(high) > 0 {
+		high *= 2
+		if high > 1e6 {
+			return 0, false
+		}
+	}
+
+	for i := 0; i < 300; i++ {
+		mid := (low + high) / 2
+		if npv(low)*npv(mid) <= 0 {
+			high = mid
+		} else {
+			low = mid
+		}
+		if high-low < 1e-12 {
+			break
+		}
+	}
+	return (low + high) / 2, true
+}
@@ -0,0 +1,167 @@
+package calculators
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthlyPayment(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal float64
+		rate      float64
+		months    int
+		expected  float64
+	}{
+		{name: "30 year at 6%", principal: 200000, rate: 0.06, months: 360, expected: 1199.10},
+		{name: "15 year at 5%", principal: 100000, rate: 0.05, months: 180, expected: 790.79},
+		{name: "Zero interest", principal: 12000, rate: 0, months: 12, expected: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.expected, MonthlyPayment(tt.principal, tt.rate, tt.months), 0.005)
+		})
+	}
+}
+
+func TestCalculateMortgageFixed(t *testing.T) {
+	schedule, err := CalculateMortgage(MortgageRequest{
+		LoanAmount: 200000,
+		AnnualRate: 0.06,
+		TermYears:  30,
+		StartDate:  "2024-01-01",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1199.10, schedule.Payment)
+	assert.Len(t, schedule.Rows, 360)
+	assert.Equal(t, 360, schedule.PayoffMonths)
+	assert.Equal(t, 1000.0, schedule.Rows[0].Interest)
+	assert.Equal(t, 199.10, schedule.Rows[0].Principal)
+	assert.Equal(t, "2024-02-01", schedule.Rows[1].Date)
+	assert.Equal(t, 0.0, schedule.Rows[359].Balance)
+	assert.InDelta(t, 231676, schedule.TotalInterest, 5)
+	assert.Nil(t, schedule.Comparison)
+}
+
+func TestCalculateMortgageExtraPayments(t *testing.T) {
+	schedule, err := CalculateMortgage(MortgageRequest{
+		LoanAmount:   200000,
+		AnnualRate:   0.06,
+		TermYears:    30,
+		ExtraPayment: 200,
+		LumpSums:     []LumpSum{{Period: 12, Amount: 10000}},
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, schedule.Comparison)
+	assert.Equal(t, 10200.0, schedule.Rows[11].Extra)
+	assert.Less(t, schedule.PayoffMonths, 360)
+	assert.Greater(t, schedule.Comparison.InterestSaved, 80000.0)
+	assert.Equal(t, 360-schedule.PayoffMonths, schedule.Comparison.MonthsSaved)
+}
+
+func TestCalculateMortgageBiweekly(t *testing.T) {
+	schedule, err := CalculateMortgage(MortgageRequest{
+		LoanAmount: 200000,
+		AnnualRate: 0.06,
+		TermYears:  30,
+		Biweekly:   true,
+		StartDate:  "2024-01-05",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 26, schedule.PaymentsPerYear)
+	assert.Equal(t, 599.55, schedule.Payment)
+	assert.Equal(t, "2024-01-19", schedule.Rows[1].Date)
+	// Accelerated biweekly payments retire a 30-year loan roughly six years early
+	assert.InDelta(t, 24*12, schedule.PayoffMonths, 12)
+	assert.Greater(t, schedule.Comparison.InterestSaved, 0.0)
+}
+
+func TestCalculateMortgagePMI(t *testing.T) {
+	schedule, err := CalculateMortgage(MortgageRequest{
+		HomePrice:   250000,
+		DownPayment: 25000,
+		AnnualRate:  0.06,
+		TermYears:   30,
+		PMIRate:     0.005,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 225000.0, schedule.LoanAmount)
+	assert.Equal(t, 93.75, schedule.Rows[0].PMI)
+	assert.Greater(t, schedule.PMIDropOffPeriod, 1)
+
+	dropOff := schedule.PMIDropOffPeriod
+	assert.LessOrEqual(t, schedule.Rows[dropOff-2].Balance, 250000*0.78)
+	assert.Greater(t, schedule.Rows[dropOff-3].Balance, 250000*0.78)
+	assert.Equal(t, 0.0, schedule.Rows[dropOff-1].PMI)
+	assert.InDelta(t, float64(dropOff-1)*93.75, schedule.TotalPMI, 0.01)
+
+	noPMI, err := CalculateMortgage(MortgageRequest{HomePrice: 250000, DownPayment: 50000, AnnualRate: 0.06, TermYears: 30, PMIRate: 0.005})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, noPMI.TotalPMI, "No PMI at 80% loan-to-value")
+}
+
+func TestCalculateMortgageAdjustable(t *testing.T) {
+	schedule, err := CalculateMortgage(MortgageRequest{
+		LoanAmount: 300000,
+		AnnualRate: 0.04,
+		TermYears:  30,
+		Adjustable: &AdjustableRate{
+			InitialYears:            5,
+			AdjustmentIntervalYears: 1,
+			IndexRate:               0.05,
+			Margin:                  0.025,
+			PeriodicCap:             0.02,
+			LifetimeCap:             0.05,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.04, schedule.Rows[59].Rate)
+	assert.InDelta(t, 0.06, schedule.Rows[60].Rate, 1e-9, "First reset limited by the periodic cap")
+	assert.InDelta(t, 0.075, schedule.Rows[72].Rate, 1e-9, "Second reset reaches index plus margin")
+	assert.Greater(t, schedule.Rows[60].Payment, schedule.Rows[59].Payment)
+	assert.Len(t, schedule.Rows, 360)
+	assert.Equal(t, 0.0, schedule.Rows[359].Balance)
+}
+
+func TestCalculateMortgageValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  MortgageRequest
+	}{
+		{name: "No loan amount", req: MortgageRequest{AnnualRate: 0.05, TermYears: 30}},
+		{name: "Negative rate", req: MortgageRequest{LoanAmount: 100000, AnnualRate: -0.01, TermYears: 30}},
+		{name: "Zero term", req: MortgageRequest{LoanAmount: 100000, AnnualRate: 0.05}},
+		{name: "Bad start date", req: MortgageRequest{LoanAmount: 100000, AnnualRate: 0.05, TermYears: 30, StartDate: "01/2024"}},
+		{name: "ARM longer than term", req: MortgageRequest{LoanAmount: 100000, AnnualRate: 0.05, TermYears: 5, Adjustable: &AdjustableRate{InitialYears: 7}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CalculateMortgage(tt.req)
+			assert.True(t, errors.Is(err, ErrInvalidLoan))
+		})
+	}
+}
+
+func TestWriteScheduleCSV(t *testing.T) {
+	schedule, err := CalculateMortgage(MortgageRequest{LoanAmount: 12000, AnnualRate: 0, TermYears: 1, StartDate: "2024-01-01"})
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteScheduleCSV(&buf, schedule))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 13)
+	assert.Equal(t, "Period,Date,Rate,Payment,Principal,Interest,Extra,PMI,Balance", lines[0])
+	assert.Equal(t, "1,2024-01-01,0,1000.00,1000.00,0.00,0.00,0.00,11000.00", lines[1])
+}
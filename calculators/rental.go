@@ -0,0 +1,208 @@
+package calculators
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidProperty is returned when rental property inputs are missing or out of range
+var ErrInvalidProperty = errors.New("invalid property")
+
+// RentalRequest describes a rental property deal. Percentages are annual decimals;
+// MaintenanceRate and ManagementRate are fractions of effective rent.
+type RentalRequest struct {
+	PurchasePrice      float64 `json:"purchasePrice"`
+	ClosingCosts       float64 `json:"closingCosts"`
+	RehabCosts         float64 `json:"rehabCosts"`
+	DownPaymentRate    float64 `json:"downPaymentRate"`
+	InterestRate       float64 `json:"interestRate"`
+	LoanTermYears      int     `json:"loanTermYears"`
+	MonthlyRent        float64 `json:"monthlyRent"`
+	OtherMonthlyIncome float64 `json:"otherMonthlyIncome"`
+	VacancyRate        float64 `json:"vacancyRate"`
+	PropertyTax        float64 `json:"propertyTax"`
+	Insurance          float64 `json:"insurance"`
+	HOA                float64 `json:"hoa"`
+	OtherExpenses      float64 `json:"otherExpenses"`
+	MaintenanceRate    float64 `json:"maintenanceRate"`
+	ManagementRate     float64 `json:"managementRate"`
+	AppreciationRate   float64 `json:"appreciationRate"`
+	RentGrowthRate     float64 `json:"rentGrowthRate"`
+	ExpenseGrowthRate  float64 `json:"expenseGrowthRate"`
+	HoldingYears       int     `json:"holdingYears"`
+	SellingCostRate    float64 `json:"sellingCostRate"`
+}
+
+// RentalYear is the projected performance of a single year
+type RentalYear struct {
+	Year               int     `json:"year"`
+	GrossRent          float64 `json:"grossRent"`
+	VacancyLoss        float64 `json:"vacancyLoss"`
+	EffectiveIncome    float64 `json:"effectiveIncome"`
+	OperatingExpenses  float64 `json:"operatingExpenses"`
+	NOI                float64 `json:"noi"`
+	DebtService        float64 `json:"debtService"`
+	CashFlow           float64 `json:"cashFlow"`
+	CumulativeCashFlow float64 `json:"cumulativeCashFlow"`
+	PropertyValue      float64 `json:"propertyValue"`
+	LoanBalance        float64 `json:"loanBalance"`
+	Equity             float64 `json:"equity"`
+	DSCR               float64 `json:"dscr"`
+}
+
+// RentalAnalysis holds year-one metrics, the sale at the end of the holding period and projections
+type RentalAnalysis struct {
+	LoanAmount          float64      `json:"loanAmount"`
+	CashInvested        float64      `json:"cashInvested"`
+	MonthlyMortgage     float64      `json:"monthlyMortgage"`
+	NOI                 float64      `json:"noi"`
+	CapRate             float64      `json:"capRate"`
+	CashFlow            float64      `json:"cashFlow"`
+	CashOnCash          float64      `json:"cashOnCash"`
+	DSCR                float64      `json:"dscr"`
+	GrossRentMultiplier float64      `json:"grossRentMultiplier"`
+	SalePrice           float64      `json:"salePrice"`
+	SaleProceeds        float64      `json:"saleProceeds"`
+	TotalProfit         float64      `json:"totalProfit"`
+	IRR                 float64      `json:"irr"`
+	Projections         []RentalYear `json:"projections"`
+}
+
+// Validate checks that the rental deal inputs are usable
+func (r RentalRequest) Validate() error {
+	switch {
+	case r.PurchasePrice <= 0:
+		return fmt.Errorf("%w: purchase price must be positive", ErrInvalidProperty)
+	case r.DownPaymentRate < 0 || r.DownPaymentRate > 1:
+		return fmt.Errorf("%w: down payment rate must be between 0 and 1", ErrInvalidProperty)
+	case r.DownPaymentRate < 1 && r.LoanTermYears <= 0:
+		return fmt.Errorf("%w: financed purchases need a loan term", ErrInvalidProperty)
+	case r.InterestRate < 0 || r.MonthlyRent < 0:
+		return fmt.Errorf("%w: interest rate and rent cannot be negative", ErrInvalidProperty)
+	case r.VacancyRate < 0 || r.VacancyRate > 1:
+		return fmt.Errorf("%w: vacancy rate must be between 0 and 1", ErrInvalidProperty)
+	case r.HoldingYears < 0 || r.HoldingYears > 50:
+		return fmt.Errorf("%w: holding period must be between 0 and 50 years", ErrInvalidProperty)
+	}
+	return nil
+}
+
+// AnalyzeRental computes cap rate, cash-on-cash return, NOI, DSCR, IRR and yearly projections.
+// The property is assumed sold at the end of the holding period (default 10 years).
+func AnalyzeRental(req RentalRequest) (*RentalAnalysis, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	holdingYears := req.HoldingYears
+	if holdingYears == 0 {
+		holdingYears = 10
+	}
+
+	loanAmount := req.PurchasePrice * (1 - req.DownPaymentRate)
+	cashInvested := req.PurchasePrice*req.DownPaymentRate + req.ClosingCosts + req.RehabCosts
+	monthlyRate := req.InterestRate / 12
+	var monthlyMortgage float64
+	if loanAmount > 0 {
+		monthlyMortgage = round2(MonthlyPayment(loanAmount, req.InterestRate, req.LoanTermYears*12))
+	}
+
+	analysis := &RentalAnalysis{
+		LoanAmount:      round2(loanAmount),
+		CashInvested:    round2(cashInvested),
+		MonthlyMortgage: monthlyMortgage,
+		Projections:     []RentalYear{},
+	}
+
+	cashFlows := []float64{-cashInvested}
+	var cumulative float64
+	for year := 1; year <= holdingYears; year++ {
+		rentGrowth := math.Pow(1+req.RentGrowthRate, float64(year-1))
+		expenseGrowth := math.Pow(1+req.ExpenseGrowthRate, float64(year-1))
+
+		grossRent := (req.MonthlyRent + req.OtherMonthlyIncome) * 12 * rentGrowth
+		vacancyLoss := grossRent * req.VacancyRate
+		effectiveIncome := grossRent - vacancyLoss
+		expenses := (req.PropertyTax+req.Insurance+req.HOA+req.OtherExpenses)*expenseGrowth +
+			effectiveIncome*(req.MaintenanceRate+req.ManagementRate)
+		noi := effectiveIncome - expenses
+
+		months := year * 12
+		if months > req.LoanTermYears*12 {
+			months = req.LoanTermYears * 12
+		}
+		paymentsThisYear := months - (year-1)*12
+		if paymentsThisYear < 0 {
+			paymentsThisYear = 0
+		}
+		debtService := monthlyMortgage * float64(paymentsThisYear)
+		cashFlow := noi - debtService
+		cumulative += cashFlow
+
+		value := req.PurchasePrice * math.Pow(1+req.AppreciationRate, float64(year))
+		balance := loanBalance(loanAmount, monthlyRate, monthlyMortgage, months)
+
+		analysis.Projections = append(analysis.Projections, RentalYear{
+			Year:               year,
+			GrossRent:          round2(grossRent),
+			VacancyLoss:        round2(vacancyLoss),
+			EffectiveIncome:    round2(effectiveIncome),
+			OperatingExpenses:  round2(expenses),
+			NOI:                round2(noi),
+			DebtService:        round2(debtService),
+			CashFlow:           round2(cashFlow),
+			CumulativeCashFlow: round2(cumulative),
+			PropertyValue:      round2(value),
+			LoanBalance:        round2(balance),
+			Equity:             round2(value - balance),
+			DSCR:               roundRatio(safeDivide(noi, debtService)),
+		})
+		cashFlows = append(cashFlows, cashFlow)
+	}
+
+	first := analysis.Projections[0]
+	last := analysis.Projections[len(analysis.Projections)-1]
+
+	analysis.NOI = first.NOI
+	analysis.CapRate = roundRatio(first.NOI / req.PurchasePrice)
+	analysis.CashFlow = first.CashFlow
+	analysis.CashOnCash = roundRatio(safeDivide(first.CashFlow, cashInvested))
+	analysis.DSCR = first.DSCR
+	analysis.GrossRentMultiplier = roundRatio(safeDivide(req.PurchasePrice, first.GrossRent))
+
+	analysis.SalePrice = last.PropertyValue
+	analysis.SaleProceeds = round2(last.PropertyValue*(1-req.SellingCostRate) - last.LoanBalance)
+	analysis.TotalProfit = round2(last.CumulativeCashFlow + analysis.SaleProceeds - cashInvested)
+
+	cashFlows[len(cashFlows)-1] += analysis.SaleProceeds
+	if irr, err := IRR(cashFlows); err == nil {
+		analysis.IRR = roundRatio(irr)
+	}
+
+	return analysis, nil
+}
+
+// loanBalance returns the remaining principal after the given number of monthly payments
+func loanBalance(principal, monthlyRate, payment float64, months int) float64 {
+	if principal <= 0 {
+		return 0
+	}
+	if monthlyRate == 0 {
+		return math.Max(principal-payment*float64(months), 0)
+	}
+	growth := math.Pow(1+monthlyRate, float64(months))
+	return math.Max(principal*growth-payment*(growth-1)/monthlyRate, 0)
+}
+
+// safeDivide returns a/b, or zero when b is zero
+func safeDivide(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
+
+func roundRatio(value float64) float64 {
+	return math.Round(value*1e6) / 1e6
+}
@@ -0,0 +1,118 @@
+package calculators
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIRR(t *testing.T) {
+	tests := []struct {
+		name      string
+		cashFlows []float64
+		expected  float64
+	}{
+		{name: "Single period", cashFlows: []float64{-100, 110}, expected: 0.10},
+		{name: "Level annuity", cashFlows: []float64{-1000, 300, 400, 500}, expected: 0.0889633947},
+		{name: "Loss", cashFlows: []float64{-100, 50, 40}, expected: -0.0699265},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			irr, err := IRR(tt.cashFlows)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, irr, 1e-6)
+		})
+	}
+
+	_, err := IRR([]float64{100, 200})
+	assert.True(t, errors.Is(err, ErrIRRNotFound))
+}
+
+func TestAnalyzeRental(t *testing.T) {
+	analysis, err := AnalyzeRental(RentalRequest{
+		PurchasePrice:   200000,
+		ClosingCosts:    5000,
+		DownPaymentRate: 0.25,
+		InterestRate:    0.07,
+		LoanTermYears:   30,
+		MonthlyRent:     2000,
+		VacancyRate:     0.05,
+		PropertyTax:     2400,
+		Insurance:       1200,
+		MaintenanceRate: 0.05,
+		ManagementRate:  0.08,
+		HoldingYears:    5,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 150000.0, analysis.LoanAmount)
+	assert.Equal(t, 55000.0, analysis.CashInvested)
+	assert.Equal(t, 997.95, analysis.MonthlyMortgage)
+	assert.Equal(t, 16236.0, analysis.NOI)
+	assert.InDelta(t, 0.08118, analysis.CapRate, 1e-6)
+	assert.Equal(t, 4260.6, analysis.CashFlow)
+	assert.InDelta(t, 0.077465, analysis.CashOnCash, 1e-6)
+	assert.InDelta(t, 1.355779, analysis.DSCR, 1e-6)
+	assert.Len(t, analysis.Projections, 5)
+	assert.Less(t, analysis.Projections[4].LoanBalance, analysis.Projections[0].LoanBalance)
+	assert.Greater(t, analysis.IRR, 0.0)
+}
+
+func TestAnalyzeRentalAllCash(t *testing.T) {
+	// With no growth, costs or financing, IRR equals the cap rate
+	analysis, err := AnalyzeRental(RentalRequest{
+		PurchasePrice:   100000,
+		DownPaymentRate: 1,
+		MonthlyRent:     1000,
+		HoldingYears:    10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, analysis.LoanAmount)
+	assert.Equal(t, 0.12, analysis.CapRate)
+	assert.Equal(t, 0.12, analysis.CashOnCash)
+	assert.Equal(t, 0.0, analysis.DSCR)
+	assert.InDelta(t, 0.12, analysis.IRR, 1e-6)
+	assert.Equal(t, 100000.0, analysis.SaleProceeds)
+	assert.Equal(t, 120000.0, analysis.TotalProfit)
+}
+
+func TestAnalyzeRentalGrowth(t *testing.T) {
+	analysis, err := AnalyzeRental(RentalRequest{
+		PurchasePrice:     100000,
+		DownPaymentRate:   1,
+		MonthlyRent:       1000,
+		PropertyTax:       1000,
+		AppreciationRate:  0.03,
+		RentGrowthRate:    0.02,
+		ExpenseGrowthRate: 0.05,
+		SellingCostRate:   0.06,
+		HoldingYears:      2,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12240.0, analysis.Projections[1].GrossRent)
+	assert.Equal(t, 1050.0, analysis.Projections[1].OperatingExpenses)
+	assert.Equal(t, 106090.0, analysis.SalePrice)
+	assert.Equal(t, 99724.6, analysis.SaleProceeds)
+}
+
+func TestAnalyzeRentalValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  RentalRequest
+	}{
+		{name: "No purchase price", req: RentalRequest{DownPaymentRate: 1}},
+		{name: "Financed without term", req: RentalRequest{PurchasePrice: 100000, DownPaymentRate: 0.2}},
+		{name: "Vacancy over 100%", req: RentalRequest{PurchasePrice: 100000, DownPaymentRate: 1, VacancyRate: 1.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := AnalyzeRental(tt.req)
+			assert.True(t, errors.Is(err, ErrInvalidProperty))
+		})
+	}
+}
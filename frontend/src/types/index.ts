@@ -5,6 +5,7 @@ export interface FinanceTopic {
   summary: string;
   keywords: string[];
   resources: string[];
+  indicators?: string[];
 }
 
 export interface StockQuote {
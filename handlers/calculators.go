@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+
+	"financehub/calculators"
+	"financehub/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalculateMortgage returns the amortization schedule for a mortgage.
+// Pass ?format=csv to download the schedule as CSV.
+func (h *Handler) CalculateMortgage(c *gin.Context) {
+	var req calculators.MortgageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	schedule, err := calculators.CalculateMortgage(req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, calculators.ErrInvalidLoan) {
+			status = http.StatusBadRequest
+		}
+		errorResponse(c, status, err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		var buf bytes.Buffer
+		if err := calculators.WriteScheduleCSV(&buf, schedule); err != nil {
+			errorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="mortgage_schedule.csv"`)
+		c.Data(http.StatusOK, "text/csv", buf.Bytes())
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    schedule,
+	})
+}
+
+// AnalyzeRentalProperty returns cap rate, cash-on-cash, NOI, DSCR, IRR and projections for a rental deal
+func (h *Handler) AnalyzeRentalProperty(c *gin.Context) {
+	var req calculators.RentalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	analysis, err := calculators.AnalyzeRental(req)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, calculators.ErrInvalidProperty) {
+			status = http.StatusBadRequest
+		}
+		errorResponse(c, status, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    analysis,
+	})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"financehub/models"
+	"financehub/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetEconomicIndicators returns the list of supported economic indicators
+func (h *Handler) GetEconomicIndicators(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.Economics.ListIndicators(),
+	})
+}
+
+// GetEconomicIndicator returns the time series for a macroeconomic indicator
+func (h *Handler) GetEconomicIndicator(c *gin.Context) {
+	indicator := c.Param("indicator")
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	series, err := h.Economics.GetIndicator(c.Request.Context(), indicator, c.Query("interval"), c.Query("maturity"), limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, services.ErrUnknownIndicator):
+			status = http.StatusNotFound
+		case errors.Is(err, services.ErrInvalidIndicatorOption):
+			status = http.StatusBadRequest
+		}
+		errorResponse(c, status, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    series,
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financehub/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCompanyOverview returns company profile and key statistics
+func (h *Handler) GetCompanyOverview(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	overview, err := h.AlphaVantage.GetCompanyOverview(c.Request.Context(), symbol)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    overview,
+	})
+}
+
+// GetEarnings returns annual and quarterly earnings history
+func (h *Handler) GetEarnings(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	earnings, err := h.AlphaVantage.GetEarnings(c.Request.Context(), symbol)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    earnings,
+	})
+}
+
+// GetIncomeStatement returns annual and quarterly income statements
+func (h *Handler) GetIncomeStatement(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	statements, err := h.AlphaVantage.GetIncomeStatement(c.Request.Context(), symbol)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    statements,
+	})
+}
+
+// GetBalanceSheet returns annual and quarterly balance sheets
+func (h *Handler) GetBalanceSheet(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	sheets, err := h.AlphaVantage.GetBalanceSheet(c.Request.Context(), symbol)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    sheets,
+	})
+}
+
+// GetCashFlow returns annual and quarterly cash flow statements
+func (h *Handler) GetCashFlow(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	statements, err := h.AlphaVantage.GetCashFlow(c.Request.Context(), symbol)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    statements,
+	})
+}
+
+// GetFinancialRatios returns derived valuation and profitability ratios
+func (h *Handler) GetFinancialRatios(c *gin.Context) {
+	symbol := c.Param("symbol")
+
+	ratios, err := h.AlphaVantage.GetFinancialRatios(c.Request.Context(), symbol)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    ratios,
+	})
+}
@@ -1,27 +1,127 @@
 package handlers
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
+	"os"
+	"strconv"
 
 	"financehub/models"
 	"financehub/services"
+	"financehub/transport"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Handler holds all service dependencies
 type Handler struct {
-	AlphaVantage *services.AlphaVantageService
-	CoinGecko    *services.CoinGeckoService
-	Topics       *services.TopicsService
+	AlphaVantage     *services.AlphaVantageService
+	CoinGecko        *services.CoinGeckoService
+	Topics           *services.TopicsService
+	Market           *services.MarketOverviewService
+	Economics        *services.EconomicsService
+	YieldCurve       *services.YieldCurveService
+	Budget           *services.BudgetService
+	Portfolio        *services.PortfolioService
+	Risk             *services.RiskService
+	Allocation       *services.AllocationService
+	CorporateActions *services.CorporateActionService
+	PaperTrading     *services.PaperTradingService
+	Backtest         *services.BacktestService
+	TimeSeries       *services.TimeSeriesStore
+	Sync             *services.SyncService
+	Jobs             *services.JobScheduler
 }
 
 // NewHandler creates a new handler with all services
 func NewHandler() *Handler {
+	alphaVantage := services.NewAlphaVantageService()
+	coinGecko := services.NewCoinGeckoService()
+	economics := services.NewEconomicsService(alphaVantage)
+
+	budgetPath := os.Getenv("BUDGET_DATA_FILE")
+	if budgetPath == "" {
+		budgetPath = "data/budget.json"
+	}
+	budget, err := services.NewBudgetService(budgetPath)
+	if err != nil {
+		slog.Warn("Budget data unavailable, changes will not be saved", "error", err)
+		budget, _ = services.NewBudgetService("")
+	}
+
+	actionsPath := os.Getenv("CORPORATE_ACTIONS_DATA_FILE")
+	if actionsPath == "" {
+		actionsPath = "data/corporate_actions.json"
+	}
+	actions, err := services.NewCorporateActionService(actionsPath, alphaVantage)
+	if err != nil {
+		slog.Warn("Corporate action data unavailable, changes will not be saved", "error", err)
+		actions, _ = services.NewCorporateActionService("", alphaVantage)
+	}
+
+	allocationPath := os.Getenv("ALLOCATION_DATA_FILE")
+	if allocationPath == "" {
+		allocationPath = "data/allocation.json"
+	}
+	allocation, err := services.NewAllocationService(allocationPath, alphaVantage)
+	if err != nil {
+		slog.Warn("Allocation data unavailable, changes will not be saved", "error", err)
+		allocation, _ = services.NewAllocationService("", alphaVantage)
+	}
+
+	paperTradingPath := os.Getenv("PAPER_TRADING_DATA_FILE")
+	if paperTradingPath == "" {
+		paperTradingPath = "data/paper_trading.json"
+	}
+	paperTrading, err := services.NewPaperTradingService(paperTradingPath, alphaVantage, coinGecko)
+	if err != nil {
+		slog.Warn("Paper trading data unavailable, changes will not be saved", "error", err)
+		paperTrading, _ = services.NewPaperTradingService("", alphaVantage, coinGecko)
+	}
+
+	timeSeriesDir := os.Getenv("TIMESERIES_DATA_DIR")
+	if timeSeriesDir == "" {
+		timeSeriesDir = "data/timeseries"
+	}
+	timeSeries := services.NewTimeSeriesStore(timeSeriesDir, alphaVantage, coinGecko)
+
+	dataSync := services.NewSyncService(alphaVantage, timeSeries, paperTrading)
+	dataSync.Watchlist = services.ParseList(os.Getenv("SYNC_WATCHLIST"))
+	if currencies := os.Getenv("SYNC_CURRENCIES"); currencies != "" {
+		dataSync.Currencies = services.ParseList(currencies)
+	}
+
+	jobsPath := os.Getenv("JOBS_DATA_FILE")
+	if jobsPath == "" {
+		jobsPath = "data/jobs.json"
+	}
+	jobs, err := services.NewJobScheduler(jobsPath)
+	if err != nil {
+		slog.Warn("Job data unavailable, run history will not be saved", "error", err)
+		jobs, _ = services.NewJobScheduler("")
+	}
+	if err := dataSync.Register(jobs); err != nil {
+		slog.Warn("Background jobs unavailable", "error", err)
+	}
+
 	return &Handler{
-		AlphaVantage: services.NewAlphaVantageService(),
-		CoinGecko:    services.NewCoinGeckoService(),
-		Topics:       services.NewTopicsService(),
+		AlphaVantage:     alphaVantage,
+		CoinGecko:        coinGecko,
+		Topics:           services.NewTopicsService(),
+		Market:           services.NewMarketOverviewService(alphaVantage, coinGecko),
+		Economics:        economics,
+		YieldCurve:       services.NewYieldCurveService(economics),
+		Budget:           budget,
+		Portfolio:        services.NewPortfolioService(timeSeries, actions),
+		Risk:             services.NewRiskService(timeSeries),
+		Allocation:       allocation,
+		CorporateActions: actions,
+		PaperTrading:     paperTrading,
+		Backtest:         services.NewBacktestService(timeSeries),
+		TimeSeries:       timeSeries,
+		Sync:             dataSync,
+		Jobs:             jobs,
 	}
 }
 
@@ -40,10 +140,7 @@ func (h *Handler) GetTopicByID(c *gin.Context) {
 	topic := h.Topics.GetTopicByID(topicID)
 
 	if topic == nil {
-		c.JSON(http.StatusNotFound, models.APIResponse{
-			Success: false,
-			Error:   "Topic not found",
-		})
+		errorResponse(c, http.StatusNotFound, errors.New("Topic not found"))
 		return
 	}
 
@@ -53,35 +150,47 @@ func (h *Handler) GetTopicByID(c *gin.Context) {
 	})
 }
 
-// GetStockQuote returns stock quote for a symbol
+// GetStockQuote returns stock quote for a symbol, served from the watchlist sync when fresh
 func (h *Handler) GetStockQuote(c *gin.Context) {
 	symbol := c.Param("symbol")
 
-	quote, err := h.AlphaVantage.GetStockQuote(symbol)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   err.Error(),
+	if quote, ok := h.Sync.Quote(symbol); ok {
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Data:    quote,
 		})
 		return
 	}
 
+	quote, err := h.AlphaVantage.GetStockQuote(c.Request.Context(), symbol)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Data:    quote,
 	})
 }
 
-// GetStockTimeSeries returns historical stock data
+// GetStockTimeSeries returns historical stock data, served from the time series store when fresh
 func (h *Handler) GetStockTimeSeries(c *gin.Context) {
 	symbol := c.Param("symbol")
 
-	timeSeries, err := h.AlphaVantage.GetTimeSeriesDaily(symbol, 30)
+	limit := 30
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			errorResponse(c, http.StatusBadRequest, errors.New("Invalid limit"))
+			return
+		}
+		limit = parsed
+	}
+
+	timeSeries, err := h.TimeSeries.Daily(c.Request.Context(), symbol, services.PriceSourceStock, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   err.Error(),
-		})
+		errorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -91,16 +200,27 @@ func (h *Handler) GetStockTimeSeries(c *gin.Context) {
 	})
 }
 
+// GetStoredTimeSeries lists the series kept in the time series store
+func (h *Handler) GetStoredTimeSeries(c *gin.Context) {
+	series, err := h.TimeSeries.List()
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    series,
+	})
+}
+
 // GetCryptoPrice returns cryptocurrency price
 func (h *Handler) GetCryptoPrice(c *gin.Context) {
 	coinID := c.Param("id")
 
-	price, err := h.CoinGecko.GetCryptoPrice(coinID)
+	price, err := h.CoinGecko.GetCryptoPrice(c.Request.Context(), coinID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   err.Error(),
-		})
+		errorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -112,12 +232,9 @@ func (h *Handler) GetCryptoPrice(c *gin.Context) {
 
 // GetTopCryptos returns top cryptocurrencies
 func (h *Handler) GetTopCryptos(c *gin.Context) {
-	cryptos, err := h.CoinGecko.GetTopCryptos(10)
+	cryptos, err := h.CoinGecko.GetTopCryptos(c.Request.Context(), 10)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   err.Error(),
-		})
+		errorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -127,30 +244,69 @@ func (h *Handler) GetTopCryptos(c *gin.Context) {
 	})
 }
 
-// GetCurrencyRate returns currency exchange rate
+// GetCurrencyRate returns currency exchange rate, served from the background sync when fresh
 func (h *Handler) GetCurrencyRate(c *gin.Context) {
 	from := c.Param("from")
 	to := c.Param("to")
 
-	rate, err := h.AlphaVantage.GetCurrencyExchangeRate(from, to)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.APIResponse{
-			Success: false,
-			Error:   err.Error(),
+	if rate, ok := h.Sync.Rate(from, to); ok {
+		c.JSON(http.StatusOK, models.APIResponse{
+			Success: true,
+			Data:    rate,
 		})
 		return
 	}
 
+	rate, err := h.AlphaVantage.GetCurrencyExchangeRate(c.Request.Context(), from, to)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
 		Data:    rate,
 	})
 }
 
-// HealthCheck returns API health status
+// errorResponse writes a failed API response with the error's message. The error is also
+// recorded on the context, so that the request log includes it.
+func errorResponse(c *gin.Context, status int, err error) {
+	c.Error(err)
+	c.JSON(status, models.APIResponse{
+		Success: false,
+		Error:   err.Error(),
+	})
+}
+
+// HealthCheck returns API health status along with each data provider's circuit breaker. The
+// status is degraded while any breaker is not closed.
 func (h *Handler) HealthCheck(c *gin.Context) {
+	status := "ok"
+	providers := transport.States()
+	for _, provider := range providers {
+		if provider.State != transport.StateClosed {
+			status = "degraded"
+		}
+	}
+
 	c.JSON(http.StatusOK, models.APIResponse{
 		Success: true,
+		Data:    gin.H{"status": status, "providers": providers},
 		Message: "Finance Hub API is running",
 	})
 }
+
+// GetMarketOverview returns top movers and aggregate market statistics
+func (h *Handler) GetMarketOverview(c *gin.Context) {
+	overview, err := h.Market.GetOverview(c.Request.Context())
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    overview,
+	})
+}
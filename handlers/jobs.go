@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"financehub/models"
+	"financehub/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJobs returns the background jobs with their schedules, next run and last run outcome
+func (h *Handler) GetJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.Jobs.List(),
+	})
+}
+
+// GetJob returns a background job
+func (h *Handler) GetJob(c *gin.Context) {
+	job, err := h.Jobs.Get(c.Param("name"))
+	if err != nil {
+		jobError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    job,
+	})
+}
+
+// RunJob runs a background job now and returns its outcome
+func (h *Handler) RunJob(c *gin.Context) {
+	job, err := h.Jobs.Run(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		jobError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    job,
+	})
+}
+
+// jobError writes an error response with a status code matching the job error
+func jobError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrJobNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, services.ErrJobRunning):
+		status = http.StatusConflict
+	}
+	errorResponse(c, status, err)
+}
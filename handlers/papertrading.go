@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"financehub/models"
+	"financehub/services"
+	"financehub/trading"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPaperAccounts returns every paper trading account
+func (h *Handler) GetPaperAccounts(c *gin.Context) {
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    h.PaperTrading.ListAccounts(),
+	})
+}
+
+// CreatePaperAccount opens a paper trading account with starting cash and trading costs
+func (h *Handler) CreatePaperAccount(c *gin.Context) {
+	var req services.PaperAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	account, err := h.PaperTrading.CreateAccount(req)
+	if err != nil {
+		paperTradingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    account,
+	})
+}
+
+// GetPaperAccount refreshes a paper trading account's quotes, fills triggered orders
+// and returns the account with its valuation
+func (h *Handler) GetPaperAccount(c *gin.Context) {
+	summary, err := h.PaperTrading.GetAccount(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		paperTradingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    summary,
+	})
+}
+
+// DeletePaperAccount closes a paper trading account
+func (h *Handler) DeletePaperAccount(c *gin.Context) {
+	if err := h.PaperTrading.DeleteAccount(c.Param("id")); err != nil {
+		paperTradingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Message: "Paper trading account deleted",
+	})
+}
+
+// PlacePaperOrder places a market, limit or stop order in a paper trading account
+func (h *Handler) PlacePaperOrder(c *gin.Context) {
+	var req trading.OrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	order, err := h.PaperTrading.PlaceOrder(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		paperTradingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.APIResponse{
+		Success: true,
+		Data:    order,
+	})
+}
+
+// CancelPaperOrder cancels a pending order
+func (h *Handler) CancelPaperOrder(c *gin.Context) {
+	orderID, err := strconv.ParseInt(c.Param("orderId"), 10, 64)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, errors.New("Invalid order ID"))
+		return
+	}
+
+	order, err := h.PaperTrading.CancelOrder(c.Param("id"), orderID)
+	if err != nil {
+		paperTradingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    order,
+	})
+}
+
+// RefreshPaperAccount evaluates a paper trading account's pending orders at the latest
+// quotes and returns the resulting trades
+func (h *Handler) RefreshPaperAccount(c *gin.Context) {
+	trades, err := h.PaperTrading.Refresh(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		paperTradingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    trades,
+	})
+}
+
+// paperTradingError writes an error response with a status code matching the paper trading error
+func paperTradingError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrPaperAccountNotFound), errors.Is(err, trading.ErrOrderNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, trading.ErrInsufficientFunds), errors.Is(err, trading.ErrInsufficientShares):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, trading.ErrInvalidOrder):
+		status = http.StatusBadRequest
+	}
+	errorResponse(c, status, err)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"financehub/models"
+	"financehub/planning"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectRetirement returns a deterministic compound-growth projection
+func (h *Handler) ProjectRetirement(c *gin.Context) {
+	var req planning.PlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	projection, err := planning.Project(req)
+	if err != nil {
+		errorResponse(c, planErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    projection,
+	})
+}
+
+// SimulateRetirement returns Monte Carlo percentile bands and probability of success
+func (h *Handler) SimulateRetirement(c *gin.Context) {
+	var req planning.PlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := planning.Simulate(req)
+	if err != nil {
+		errorResponse(c, planErrorStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// planErrorStatus maps planning errors to HTTP status codes
+func planErrorStatus(err error) int {
+	if errors.Is(err, planning.ErrInvalidPlan) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+// RegisterRoutes adds the REST API routes under /api
+func (h *Handler) RegisterRoutes(router gin.IRouter) {
+	api := router.Group("/api")
+	{
+		// Health check
+		api.GET("/health", h.HealthCheck)
+
+		// Topics
+		api.GET("/topics", h.GetAllTopics)
+		api.GET("/topics/:id", h.GetTopicByID)
+
+		// Stocks
+		api.GET("/stocks/:symbol", h.GetStockQuote)
+		api.GET("/stocks/:symbol/timeseries", h.GetStockTimeSeries)
+
+		// Stored price history
+		api.GET("/timeseries", h.GetStoredTimeSeries)
+
+		// Company fundamentals
+		api.GET("/stocks/:symbol/fundamentals/overview", h.GetCompanyOverview)
+		api.GET("/stocks/:symbol/fundamentals/earnings", h.GetEarnings)
+		api.GET("/stocks/:symbol/fundamentals/income-statement", h.GetIncomeStatement)
+		api.GET("/stocks/:symbol/fundamentals/balance-sheet", h.GetBalanceSheet)
+		api.GET("/stocks/:symbol/fundamentals/cash-flow", h.GetCashFlow)
+		api.GET("/stocks/:symbol/fundamentals/ratios", h.GetFinancialRatios)
+
+		// Cryptocurrencies
+		api.GET("/crypto/top", h.GetTopCryptos)
+		api.GET("/crypto/:id", h.GetCryptoPrice)
+
+		// Market overview
+		api.GET("/market/overview", h.GetMarketOverview)
+
+		// Economic indicators
+		api.GET("/economy", h.GetEconomicIndicators)
+		api.GET("/economy/:indicator", h.GetEconomicIndicator)
+
+		// Bonds
+		api.GET("/bonds/yield-curve", h.GetYieldCurve)
+		api.POST("/bonds/analytics", h.AnalyzeBond)
+		api.POST("/bonds/accrued-interest", h.CalculateAccruedInterest)
+
+		// Calculators
+		api.POST("/calculators/mortgage", h.CalculateMortgage)
+		api.POST("/calculators/rental", h.AnalyzeRentalProperty)
+
+		// Planning
+		api.POST("/planning/projection", h.ProjectRetirement)
+		api.POST("/planning/monte-carlo", h.SimulateRetirement)
+
+		// Budget tracker
+		api.GET("/budget/categories", h.GetBudgetCategories)
+		api.POST("/budget/categories", h.CreateBudgetCategory)
+		api.DELETE("/budget/categories/:id", h.DeleteBudgetCategory)
+		api.GET("/budget/budgets", h.GetBudgets)
+		api.PUT("/budget/budgets", h.SetBudget)
+		api.GET("/budget/transactions", h.GetBudgetTransactions)
+		api.POST("/budget/income", h.RecordIncome)
+		api.POST("/budget/expenses", h.RecordExpense)
+		api.DELETE("/budget/transactions/:id", h.DeleteBudgetTransaction)
+		api.GET("/budget/rollup", h.GetBudgetRollup)
+		api.GET("/budget/trends", h.GetBudgetTrends)
+		api.GET("/budget/rules", h.GetImportRules)
+		api.PUT("/budget/rules", h.SetImportRules)
+		api.POST("/budget/import", h.ImportStatement)
+
+		// Portfolio analytics
+		api.POST("/portfolio/performance", h.GetPortfolioPerformance)
+		api.POST("/portfolio/gains", h.GetRealizedGains)
+		api.POST("/portfolio/income", h.GetDividendIncome)
+
+		// Dividends and splits
+		api.GET("/corporate-actions", h.GetCorporateActions)
+		api.POST("/corporate-actions", h.CreateCorporateAction)
+		api.DELETE("/corporate-actions/:id", h.DeleteCorporateAction)
+		api.POST("/corporate-actions/:symbol/sync", h.SyncCorporateActions)
+
+		// Risk metrics
+		api.POST("/risk/analysis", h.AnalyzeRisk)
+
+		// Allocation targets and rebalancing
+		api.GET("/allocation/targets", h.GetAllocationTargets)
+		api.GET("/allocation/targets/:portfolio", h.GetPortfolioTargets)
+		api.PUT("/allocation/targets/:portfolio", h.SetPortfolioTargets)
+		api.DELETE("/allocation/targets/:portfolio", h.DeletePortfolioTargets)
+		api.POST("/allocation/targets/:portfolio/rebalance", h.PlanRebalance)
+
+		// Paper trading
+		api.GET("/paper/accounts", h.GetPaperAccounts)
+		api.POST("/paper/accounts", h.CreatePaperAccount)
+		api.GET("/paper/accounts/:id", h.GetPaperAccount)
+		api.DELETE("/paper/accounts/:id", h.DeletePaperAccount)
+		api.POST("/paper/accounts/:id/orders", h.PlacePaperOrder)
+		api.DELETE("/paper/accounts/:id/orders/:orderId", h.CancelPaperOrder)
+		api.POST("/paper/accounts/:id/refresh", h.RefreshPaperAccount)
+
+		// Strategy backtesting
+		api.POST("/backtest", h.RunBacktest)
+
+		// Background jobs
+		api.GET("/jobs", h.GetJobs)
+		api.GET("/jobs/:name", h.GetJob)
+		api.POST("/jobs/:name/run", h.RunJob)
+
+		// Currency Exchange
+		api.GET("/currency/:from/:to", h.GetCurrencyRate)
+	}
+}
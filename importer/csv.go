@@ -0,0 +1,145 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// CSVMapping maps statement columns, by header name, to transaction fields.
+// Use Amount for a single signed column, or Debit and Credit for split columns.
+// Empty fields fall back to common header names such as "Date", "Amount" and "Description".
+type CSVMapping struct {
+	Date          string `json:"date,omitempty"`
+	Amount        string `json:"amount,omitempty"`
+	Debit         string `json:"debit,omitempty"`
+	Credit        string `json:"credit,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Memo          string `json:"memo,omitempty"`
+	Reference     string `json:"reference,omitempty"`
+	Category      string `json:"category,omitempty"`
+	Delimiter     string `json:"delimiter,omitempty"`
+	InvertAmounts bool   `json:"invertAmounts,omitempty"`
+}
+
+// defaultCSVColumns lists header names tried for each field when the mapping leaves it empty
+var defaultCSVColumns = map[string][]string{
+	"date":        {"date", "transaction date", "posted date", "posting date"},
+	"amount":      {"amount", "transaction amount"},
+	"debit":       {"debit", "withdrawal", "withdrawals"},
+	"credit":      {"credit", "deposit", "deposits"},
+	"description": {"description", "payee", "name", "merchant"},
+	"memo":        {"memo", "notes"},
+	"reference":   {"reference", "check number", "check or slip #", "id"},
+	"category":    {"category"},
+}
+
+// parseCSV parses a CSV statement whose first row is a header
+func parseCSV(r io.Reader, opts Options) ([]Transaction, error) {
+	mapping := opts.CSV
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if mapping.Delimiter != "" {
+		reader.Comma = []rune(mapping.Delimiter)[0]
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: empty CSV file", ErrInvalidStatement)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	lookup := func(field, configured string) int {
+		candidates := defaultCSVColumns[field]
+		if configured != "" {
+			candidates = []string{configured}
+		}
+		for _, name := range candidates {
+			if i, ok := columns[strings.ToLower(strings.TrimSpace(name))]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+
+	dateCol := lookup("date", mapping.Date)
+	amountCol := lookup("amount", mapping.Amount)
+	debitCol := lookup("debit", mapping.Debit)
+	creditCol := lookup("credit", mapping.Credit)
+	descriptionCol := lookup("description", mapping.Description)
+	memoCol := lookup("memo", mapping.Memo)
+	referenceCol := lookup("reference", mapping.Reference)
+	categoryCol := lookup("category", mapping.Category)
+
+	if dateCol < 0 {
+		return nil, fmt.Errorf("%w: date column not found", ErrInvalidStatement)
+	}
+	if amountCol < 0 && debitCol < 0 && creditCol < 0 {
+		return nil, fmt.Errorf("%w: amount or debit/credit columns not found", ErrInvalidStatement)
+	}
+
+	transactions := []Transaction{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+		}
+		field := func(col int) string {
+			if col < 0 || col >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[col])
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		date, err := parseDate(field(dateCol), opts.DateFormat)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		var amount float64
+		if amountCol >= 0 {
+			if amount, err = parseAmount(field(amountCol)); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		} else {
+			debit, err := parseAmount(field(debitCol))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			credit, err := parseAmount(field(creditCol))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			amount = credit - math.Abs(debit)
+		}
+		if mapping.InvertAmounts {
+			amount = -amount
+		}
+
+		transactions = append(transactions, Transaction{
+			Date:        date,
+			Amount:      amount,
+			Description: field(descriptionCol),
+			Memo:        field(memoCol),
+			Reference:   field(referenceCol),
+			Category:    field(categoryCol),
+		})
+	}
+	return transactions, nil
+}
@@ -0,0 +1,204 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKTRANLIST>
+<DTSTART>20240101
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240115120000.000[-5:EST]
+<TRNAMT>-42.17
+<FITID>2024011501
+<NAME>WHOLE FOODS MARKET
+<MEMO>POS PURCHASE
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240131
+<TRNAMT>2500.00
+<FITID>2024013101
+<NAME>ACME CORP PAYROLL &amp; CO
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+const testQFXML = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX><CREDITCARDMSGSRSV1><CCSTMTTRNRS><CCSTMTRS><BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20240203</DTPOSTED><TRNAMT>-9,99</TRNAMT>
+<FITID>A1</FITID><PAYEE><NAME>NETFLIX</NAME></PAYEE></STMTTRN>
+</BANKTRANLIST></CCSTMTRS></CCSTMTTRNRS></CREDITCARDMSGSRSV1></OFX>`
+
+const testQIF = `!Type:Bank
+D1/15'24
+T-1,250.00
+PLandlord LLC
+MJanuary rent
+N1042
+LHousing:Rent
+^
+D01/31/2024
+U2,500.00
+PAcme Corp
+^
+`
+
+func TestParseCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		opts    Options
+		amounts []float64
+	}{
+		{
+			name:    "default columns",
+			content: "Date,Description,Amount\n2024-01-15,Coffee Shop,-4.50\n2024-01-16,Paycheck,\"$2,500.00\"\n",
+			opts:    Options{Format: FormatCSV},
+			amounts: []float64{-4.5, 2500},
+		},
+		{
+			name:    "debit and credit columns",
+			content: "Posted Date;Payee;Withdrawal;Deposit\n01/15/2024;Coffee Shop;4.50;\n01/16/2024;Paycheck;;2500\n",
+			opts:    Options{Format: FormatCSV, CSV: CSVMapping{Delimiter: ";"}},
+			amounts: []float64{-4.5, 2500},
+		},
+		{
+			name:    "custom mapping",
+			content: "Booked,Text,Value\n15.01.2024,Coffee Shop,4.50\n16.01.2024,Paycheck,(2500)\n",
+			opts: Options{Format: FormatCSV, DateFormat: "02.01.2006", CSV: CSVMapping{
+				Date: "Booked", Description: "Text", Amount: "Value", InvertAmounts: true,
+			}},
+			amounts: []float64{-4.5, 2500},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactions, err := Parse(strings.NewReader(tt.content), tt.opts)
+
+			assert.NoError(t, err)
+			assert.Len(t, transactions, 2)
+			assert.Equal(t, "2024-01-15", transactions[0].Date)
+			assert.Equal(t, "Coffee Shop", transactions[0].Description)
+			for i, amount := range tt.amounts {
+				assert.Equal(t, amount, transactions[i].Amount)
+				assert.NotEmpty(t, transactions[i].ID)
+			}
+		})
+	}
+}
+
+func TestParseCSVErrors(t *testing.T) {
+	_, err := Parse(strings.NewReader("Description,Amount\nCoffee,-4.50\n"), Options{Format: FormatCSV})
+	assert.ErrorIs(t, err, ErrInvalidStatement)
+
+	_, err = Parse(strings.NewReader("Date,Amount\nyesterday,-4.50\n"), Options{Format: FormatCSV})
+	assert.ErrorIs(t, err, ErrInvalidStatement)
+	assert.Contains(t, err.Error(), "line 2")
+
+	_, err = Parse(strings.NewReader(""), Options{Format: "xls"})
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestParseOFX(t *testing.T) {
+	transactions, err := Parse(strings.NewReader(testOFX), Options{Format: FormatOFX})
+
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, Transaction{
+		ID:          transactions[0].ID,
+		Date:        "2024-01-15",
+		Amount:      -42.17,
+		Description: "WHOLE FOODS MARKET",
+		Memo:        "POS PURCHASE",
+		Reference:   "2024011501",
+	}, transactions[0])
+	assert.Equal(t, "ACME CORP PAYROLL & CO", transactions[1].Description)
+	assert.Equal(t, 2500.0, transactions[1].Amount)
+
+	xml, err := Parse(strings.NewReader(testQFXML), Options{Format: FormatQFX})
+	assert.NoError(t, err)
+	assert.Len(t, xml, 1)
+	assert.Equal(t, "NETFLIX", xml[0].Description)
+	assert.Equal(t, -9.99, xml[0].Amount)
+	assert.Equal(t, "A1", xml[0].Reference)
+}
+
+func TestParseQIF(t *testing.T) {
+	transactions, err := Parse(strings.NewReader(testQIF), Options{Format: FormatQIF})
+
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, "2024-01-15", transactions[0].Date)
+	assert.Equal(t, -1250.0, transactions[0].Amount)
+	assert.Equal(t, "Landlord LLC", transactions[0].Description)
+	assert.Equal(t, "January rent", transactions[0].Memo)
+	assert.Equal(t, "1042", transactions[0].Reference)
+	assert.Equal(t, "Housing:Rent", transactions[0].Category)
+	assert.Equal(t, 2500.0, transactions[1].Amount)
+
+	_, err = Parse(strings.NewReader("!Type:Bank\nPNo date\n^\n"), Options{Format: FormatQIF})
+	assert.ErrorIs(t, err, ErrInvalidStatement)
+}
+
+func TestDetectFormat(t *testing.T) {
+	format, err := DetectFormat("Statement_2024.QFX")
+	assert.NoError(t, err)
+	assert.Equal(t, FormatQFX, format)
+
+	_, err = DetectFormat("statement.pdf")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestDeduplicate(t *testing.T) {
+	content := "Date,Description,Amount\n2024-01-15,Coffee,-4.50\n2024-01-15,Coffee,-4.50\n2024-01-16,Lunch,-12.00\n"
+	first, err := Parse(strings.NewReader(content), Options{Format: FormatCSV})
+	assert.NoError(t, err)
+	assert.NotEqual(t, first[0].ID, first[1].ID)
+
+	imported := map[string]bool{first[0].ID: true, first[1].ID: true}
+	second, err := Parse(strings.NewReader(content), Options{Format: FormatCSV})
+	assert.NoError(t, err)
+
+	fresh, duplicates := Deduplicate(second, imported)
+	assert.Len(t, duplicates, 2)
+	assert.Len(t, fresh, 1)
+	assert.Equal(t, "Lunch", fresh[0].Description)
+}
+
+func TestCategorize(t *testing.T) {
+	transactions := []Transaction{
+		{Description: "WHOLE FOODS MARKET #123"},
+		{Description: "Uber *Trip", Memo: "ride home"},
+		{Description: "Unknown merchant"},
+		{Description: "Already set", CategoryID: "misc"},
+	}
+	rules := []Rule{
+		{Contains: "whole foods", CategoryID: "groceries"},
+		{Pattern: `^uber\b`, CategoryID: "transport"},
+		{Contains: "set", CategoryID: "wrong"},
+	}
+
+	err := Categorize(transactions, rules)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "groceries", transactions[0].CategoryID)
+	assert.Equal(t, "transport", transactions[1].CategoryID)
+	assert.Empty(t, transactions[2].CategoryID)
+	assert.Equal(t, "misc", transactions[3].CategoryID)
+
+	err = Categorize(transactions, []Rule{{Pattern: "([", CategoryID: "x"}})
+	assert.ErrorIs(t, err, ErrInvalidRule)
+}
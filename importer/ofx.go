@@ -0,0 +1,109 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parseOFX parses OFX and QFX statements. Both the SGML (OFX 1.x, unclosed
+// elements) and XML (OFX 2.x) variants are supported.
+func parseOFX(r io.Reader) ([]Transaction, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+
+	body := string(content)
+	start := strings.Index(strings.ToUpper(body), "<OFX>")
+	if start < 0 {
+		return nil, fmt.Errorf("%w: missing <OFX> element", ErrInvalidStatement)
+	}
+	body = body[start:]
+
+	transactions := []Transaction{}
+	var current map[string]string
+	for len(body) > 0 {
+		open := strings.IndexByte(body, '<')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(body[open:], '>')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unterminated element", ErrInvalidStatement)
+		}
+		tag := strings.ToUpper(strings.TrimSpace(body[open+1 : open+end]))
+		body = body[open+end+1:]
+
+		value := body
+		if next := strings.IndexByte(body, '<'); next >= 0 {
+			value = body[:next]
+		}
+		value = strings.TrimSpace(value)
+
+		switch {
+		case tag == "STMTTRN":
+			current = make(map[string]string)
+		case tag == "/STMTTRN":
+			if current == nil {
+				continue
+			}
+			transaction, err := ofxTransaction(current)
+			if err != nil {
+				return nil, err
+			}
+			transactions = append(transactions, transaction)
+			current = nil
+		case current != nil && !strings.HasPrefix(tag, "/"):
+			// Payee aggregates nest their own NAME; the first NAME seen wins
+			if _, exists := current[tag]; !exists {
+				current[tag] = unescapeOFX(value)
+			}
+		}
+	}
+	return transactions, nil
+}
+
+// ofxTransaction converts the elements of an STMTTRN aggregate to a transaction
+func ofxTransaction(fields map[string]string) (Transaction, error) {
+	posted := fields["DTPOSTED"]
+	if len(posted) < 8 {
+		return Transaction{}, fmt.Errorf("%w: invalid DTPOSTED %q", ErrInvalidStatement, posted)
+	}
+	date, err := parseDate(posted[:8], "20060102")
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	// Some institutions use a comma as the decimal separator
+	amountText := fields["TRNAMT"]
+	if !strings.Contains(amountText, ".") {
+		amountText = strings.Replace(amountText, ",", ".", 1)
+	}
+	amount, err := parseAmount(amountText)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	description := fields["NAME"]
+	if description == "" {
+		description = fields["MEMO"]
+	}
+	reference := fields["FITID"]
+	if reference == "" {
+		reference = fields["CHECKNUM"]
+	}
+
+	return Transaction{
+		Date:        date,
+		Amount:      amount,
+		Description: description,
+		Memo:        fields["MEMO"],
+		Reference:   reference,
+	}, nil
+}
+
+// unescapeOFX decodes the character entities allowed in OFX element values
+func unescapeOFX(value string) string {
+	return strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&apos;", "'").Replace(value)
+}
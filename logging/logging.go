@@ -0,0 +1,139 @@
+// Package logging configures the structured logs of the API server and the background service:
+// JSON or text records at a configurable level, written to standard error or to a rotating file,
+// and tagged with the ID of the request being handled.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Log formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config selects where and how logs are written
+type Config struct {
+	// Level is the minimum level of the records written
+	Level slog.Level
+	// Format is FormatJSON or FormatText
+	Format string
+	// File is the log file, standard error is used when it is empty
+	File string
+	// MaxSize rotates the file once it would grow past this many bytes, 0 disables it
+	MaxSize int64
+	// RotateEvery rotates the file once it is older than this, 0 disables it
+	RotateEvery time.Duration
+	// MaxAge removes rotated files older than this, 0 keeps them
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files kept, 0 keeps them all
+	MaxBackups int
+}
+
+// DefaultConfig returns JSON logs at the info level, rotated daily or at 10 MB and kept for
+// 30 days, at most 5 files
+func DefaultConfig() Config {
+	return Config{
+		Level:       slog.LevelInfo,
+		Format:      FormatJSON,
+		MaxSize:     10 << 20,
+		RotateEvery: 24 * time.Hour,
+		MaxAge:      30 * 24 * time.Hour,
+		MaxBackups:  5,
+	}
+}
+
+// ConfigFromEnv returns the default configuration overridden by LOG_LEVEL, LOG_FORMAT, LOG_FILE,
+// LOG_MAX_SIZE_MB, LOG_ROTATE_HOURS, LOG_MAX_AGE_DAYS and LOG_MAX_BACKUPS. The defaults are kept
+// for the settings that are invalid, which are reported in the error.
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+	var errs []string
+
+	if level := os.Getenv("LOG_LEVEL"); level != "" {
+		if err := cfg.Level.UnmarshalText([]byte(level)); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid LOG_LEVEL %q", level))
+		}
+	}
+	switch format := strings.ToLower(os.Getenv("LOG_FORMAT")); format {
+	case "":
+	case FormatJSON, FormatText:
+		cfg.Format = format
+	default:
+		errs = append(errs, fmt.Sprintf("invalid LOG_FORMAT %q, expected json or text", format))
+	}
+	cfg.File = os.Getenv("LOG_FILE")
+
+	envInt := func(name string, set func(int)) {
+		value := os.Getenv(name)
+		if value == "" {
+			return
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			errs = append(errs, fmt.Sprintf("invalid %s %q", name, value))
+			return
+		}
+		set(n)
+	}
+	envInt("LOG_MAX_SIZE_MB", func(n int) { cfg.MaxSize = int64(n) << 20 })
+	envInt("LOG_ROTATE_HOURS", func(n int) { cfg.RotateEvery = time.Duration(n) * time.Hour })
+	envInt("LOG_MAX_AGE_DAYS", func(n int) { cfg.MaxAge = time.Duration(n) * 24 * time.Hour })
+	envInt("LOG_MAX_BACKUPS", func(n int) { cfg.MaxBackups = n })
+
+	if len(errs) > 0 {
+		return cfg, fmt.Errorf("invalid logging configuration: %s", strings.Join(errs, "; "))
+	}
+	return cfg, nil
+}
+
+// New creates a logger for a configuration. The returned closer closes the log file.
+func New(cfg Config) (*slog.Logger, io.Closer, error) {
+	var out io.WriteCloser = nopCloser{os.Stderr}
+	if cfg.File != "" {
+		file, err := OpenRotatingFile(cfg.File, cfg.MaxSize, cfg.RotateEvery, cfg.MaxAge, cfg.MaxBackups)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = file
+	}
+	return slog.New(NewHandler(out, cfg)), out, nil
+}
+
+// NewHandler creates a handler writing records of a configuration to out. Records logged with a
+// context carrying a request ID include it as request_id.
+func NewHandler(out io.Writer, cfg Config) slog.Handler {
+	opts := &slog.HandlerOptions{Level: cfg.Level}
+	var handler slog.Handler
+	if cfg.Format == FormatText {
+		handler = slog.NewTextHandler(out, opts)
+	} else {
+		handler = slog.NewJSONHandler(out, opts)
+	}
+	return contextHandler{handler}
+}
+
+// Setup creates a logger for a configuration and makes it the default, which the standard
+// library's log package writes through as well
+func Setup(cfg Config) (*slog.Logger, io.Closer, error) {
+	logger, closer, err := New(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	slog.SetDefault(logger)
+	return logger, closer, nil
+}
+
+// nopCloser keeps standard error open when the logger is closed
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
@@ -0,0 +1,118 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("LOG_FORMAT", "TEXT")
+	t.Setenv("LOG_FILE", "logs/api.log")
+	t.Setenv("LOG_MAX_SIZE_MB", "2")
+	t.Setenv("LOG_ROTATE_HOURS", "")
+	t.Setenv("LOG_MAX_AGE_DAYS", "7")
+	t.Setenv("LOG_MAX_BACKUPS", "0")
+
+	cfg, err := ConfigFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, cfg.Level)
+	assert.Equal(t, FormatText, cfg.Format)
+	assert.Equal(t, "logs/api.log", cfg.File)
+	assert.Equal(t, int64(2<<20), cfg.MaxSize)
+	assert.Equal(t, 24*time.Hour, cfg.RotateEvery)
+	assert.Equal(t, 7*24*time.Hour, cfg.MaxAge)
+	assert.Equal(t, 0, cfg.MaxBackups)
+
+	t.Setenv("LOG_LEVEL", "loud")
+	t.Setenv("LOG_MAX_SIZE_MB", "-1")
+	cfg, err = ConfigFromEnv()
+	assert.ErrorContains(t, err, "LOG_LEVEL")
+	assert.ErrorContains(t, err, "LOG_MAX_SIZE_MB")
+	assert.Equal(t, slog.LevelInfo, cfg.Level)
+	assert.Equal(t, int64(10<<20), cfg.MaxSize)
+}
+
+func TestHandlerRequestID(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(NewHandler(&out, Config{Level: slog.LevelInfo, Format: FormatJSON}))
+
+	ctx := WithRequestID(context.Background(), "abc123")
+	logger.With("component", "test").InfoContext(ctx, "with request")
+	logger.Info("without request")
+	logger.Debug("below level")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "with request", record["msg"])
+	assert.Equal(t, "abc123", record["request_id"])
+	assert.Equal(t, "test", record["component"])
+	assert.NotContains(t, lines[1], "request_id")
+
+	assert.Equal(t, "abc123", RequestID(ctx))
+	assert.Empty(t, RequestID(context.Background()))
+	assert.Len(t, NewRequestID(), 16)
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var out bytes.Buffer
+	logger := slog.New(NewHandler(&out, Config{Level: slog.LevelInfo, Format: FormatJSON}))
+
+	var seen string
+	router := gin.New()
+	router.Use(Middleware(logger))
+	router.GET("/api/items/:id", func(c *gin.Context) {
+		seen = RequestID(c.Request.Context())
+		if c.Param("id") == "missing" {
+			c.Error(assert.AnError)
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/items/1", nil))
+	id := w.Header().Get(RequestIDHeader)
+	assert.Len(t, id, 16)
+	assert.Equal(t, id, seen)
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "request", record["msg"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, id, record["request_id"])
+	assert.Equal(t, "/api/items/1", record["path"])
+	assert.Equal(t, "/api/items/:id", record["route"])
+	assert.Equal(t, float64(200), record["status"])
+
+	// A valid request ID from the client is kept, an invalid one replaced
+	out.Reset()
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/items/missing", nil)
+	req.Header.Set(RequestIDHeader, "client-id.1")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, "client-id.1", w.Header().Get(RequestIDHeader))
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, assert.AnError.Error(), record["error"])
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/api/items/1", nil)
+	req.Header.Set(RequestIDHeader, "bad id\n")
+	router.ServeHTTP(w, req)
+	assert.Len(t, w.Header().Get(RequestIDHeader), 16)
+}
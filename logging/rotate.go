@@ -0,0 +1,179 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat names rotated files so that they sort by the time they were rotated
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFile is a log file that is renamed and replaced by an empty one when it grows past
+// MaxSize or gets older than RotateEvery. Rotated files are named after the file with the time of
+// the rotation, e.g. api-2024-01-02T15-04-05.000.log, and removed once older than MaxAge or when
+// there are more than MaxBackups of them.
+type RotatingFile struct {
+	Path        string
+	MaxSize     int64
+	RotateEvery time.Duration
+	MaxAge      time.Duration
+	MaxBackups  int
+
+	mu      sync.Mutex
+	file    *os.File
+	size    int64
+	started time.Time
+	now     func() time.Time
+}
+
+// OpenRotatingFile opens a log file for appending, creating it and its directory when missing.
+// A file last written longer than rotateEvery ago is rotated first.
+func OpenRotatingFile(path string, maxSize int64, rotateEvery, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		Path:        path,
+		MaxSize:     maxSize,
+		RotateEvery: rotateEvery,
+		MaxAge:      maxAge,
+		MaxBackups:  maxBackups,
+		now:         time.Now,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 && f.expired(info.ModTime()) {
+		if err := f.rotate(); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write appends p to the file, rotating it first when p would take it past MaxSize or it has
+// been written to for longer than RotateEvery
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.size > 0 && (f.MaxSize > 0 && f.size+int64(len(p)) > f.MaxSize || f.expired(f.started)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the file
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// Backups returns the rotated files, oldest first
+func (f *RotatingFile) Backups() ([]string, error) {
+	ext := filepath.Ext(f.Path)
+	prefix := strings.TrimSuffix(f.Path, ext) + "-"
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(match, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, stamp); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+// expired reports whether a file started at started is due for rotation. Callers must hold the lock.
+func (f *RotatingFile) expired(started time.Time) bool {
+	return f.RotateEvery > 0 && f.now().Sub(started) >= f.RotateEvery
+}
+
+// open opens the file for appending. Callers must hold the lock.
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	f.started = f.now()
+	return nil
+}
+
+// rotate renames the file after the current time, opens an empty one and removes the rotated
+// files no longer kept. Callers must hold the lock.
+func (f *RotatingFile) rotate() error {
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+
+	ext := filepath.Ext(f.Path)
+	backup := strings.TrimSuffix(f.Path, ext) + "-" + f.now().Format(backupTimeFormat) + ext
+	if err := os.Rename(f.Path, backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.prune()
+	return nil
+}
+
+// prune removes the rotated files older than MaxAge and the oldest beyond MaxBackups. Callers
+// must hold the lock.
+func (f *RotatingFile) prune() {
+	backups, err := f.Backups()
+	if err != nil {
+		return
+	}
+
+	var kept []string
+	for _, backup := range backups {
+		info, err := os.Stat(backup)
+		if err == nil && f.MaxAge > 0 && f.now().Sub(info.ModTime()) > f.MaxAge {
+			os.Remove(backup)
+			continue
+		}
+		kept = append(kept, backup)
+	}
+	if f.MaxBackups > 0 && len(kept) > f.MaxBackups {
+		for _, backup := range kept[:len(kept)-f.MaxBackups] {
+			os.Remove(backup)
+		}
+	}
+}
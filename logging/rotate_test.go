@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFileSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "api.log")
+	f, err := OpenRotatingFile(path, 10, 0, 0, 2)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	f.now = func() time.Time { return now }
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := f.Write([]byte(line))
+		assert.NoError(t, err)
+		now = now.Add(time.Second)
+	}
+
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, "fourth\n", string(content))
+	backups, err := f.Backups()
+	assert.NoError(t, err)
+	// The oldest of the three rotated files is removed
+	assert.Equal(t, []string{
+		filepath.Join(filepath.Dir(path), "api-2024-01-02T15-04-07.000.log"),
+		filepath.Join(filepath.Dir(path), "api-2024-01-02T15-04-08.000.log"),
+	}, backups)
+	content, _ = os.ReadFile(backups[1])
+	assert.Equal(t, "third\n", string(content))
+
+	assert.NoError(t, f.Close())
+	_, err = f.Write([]byte("closed\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
+
+func TestRotatingFileAge(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "service.log")
+	assert.NoError(t, os.WriteFile(path, []byte("yesterday\n"), 0644))
+	old := time.Now().Add(-25 * time.Hour)
+	assert.NoError(t, os.Chtimes(path, old, old))
+
+	// Backups past MaxAge are removed on rotation
+	expired := filepath.Join(dir, "service-2020-01-01T00-00-00.000.log")
+	assert.NoError(t, os.WriteFile(expired, []byte("old\n"), 0644))
+	veryOld := time.Now().Add(-30 * 24 * time.Hour)
+	assert.NoError(t, os.Chtimes(expired, veryOld, veryOld))
+
+	// A file last written before the rotation interval is rotated when opened
+	f, err := OpenRotatingFile(path, 0, 24*time.Hour, 7*24*time.Hour, 0)
+	assert.NoError(t, err)
+	defer f.Close()
+	backups, _ := f.Backups()
+	assert.Len(t, backups, 1)
+	assert.NotEqual(t, expired, backups[0])
+	content, _ := os.ReadFile(backups[0])
+	assert.Equal(t, "yesterday\n", string(content))
+
+	_, err = f.Write([]byte("today\n"))
+	assert.NoError(t, err)
+	f.now = func() time.Time { return time.Now().Add(25 * time.Hour) }
+	_, err = f.Write([]byte("tomorrow\n"))
+	assert.NoError(t, err)
+
+	content, _ = os.ReadFile(path)
+	assert.Equal(t, "tomorrow\n", string(content))
+	backups, _ = f.Backups()
+	assert.Len(t, backups, 2)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"embed"
 	"log"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -14,6 +15,14 @@ import (
 var assets embed.FS
 
 func main() {
+	// Run a service command instead of the desktop app when one is given
+	if len(os.Args) > 1 && os.Args[1] == "service" {
+		os.Exit(runServiceCLI(os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && isServiceCommand(os.Args[1]) {
+		os.Exit(runServiceCLI(os.Args[1:], os.Stdout, os.Stderr))
+	}
+
 	// Create an instance of the app structure
 	app := NewApp()
 
@@ -0,0 +1,137 @@
+package models
+
+// CompanyOverview represents company profile and key statistics
+type CompanyOverview struct {
+	Symbol               string  `json:"symbol"`
+	Name                 string  `json:"name"`
+	Description          string  `json:"description"`
+	Exchange             string  `json:"exchange"`
+	Currency             string  `json:"currency"`
+	Country              string  `json:"country"`
+	Sector               string  `json:"sector"`
+	Industry             string  `json:"industry"`
+	FiscalYearEnd        string  `json:"fiscalYearEnd"`
+	LatestQuarter        string  `json:"latestQuarter"`
+	MarketCapitalization float64 `json:"marketCapitalization"`
+	EBITDA               float64 `json:"ebitda"`
+	PERatio              float64 `json:"peRatio"`
+	PEGRatio             float64 `json:"pegRatio"`
+	BookValue            float64 `json:"bookValue"`
+	DividendPerShare     float64 `json:"dividendPerShare"`
+	DividendYield        float64 `json:"dividendYield"`
+	EPS                  float64 `json:"eps"`
+	RevenueTTM           float64 `json:"revenueTTM"`
+	GrossProfitTTM       float64 `json:"grossProfitTTM"`
+	ProfitMargin         float64 `json:"profitMargin"`
+	OperatingMarginTTM   float64 `json:"operatingMarginTTM"`
+	ReturnOnAssetsTTM    float64 `json:"returnOnAssetsTTM"`
+	ReturnOnEquityTTM    float64 `json:"returnOnEquityTTM"`
+	AnalystTargetPrice   float64 `json:"analystTargetPrice"`
+	PriceToBookRatio     float64 `json:"priceToBookRatio"`
+	Beta                 float64 `json:"beta"`
+	Week52High           float64 `json:"week52High"`
+	Week52Low            float64 `json:"week52Low"`
+	SharesOutstanding    float64 `json:"sharesOutstanding"`
+}
+
+// EarningsReport represents reported and estimated EPS for a fiscal period
+type EarningsReport struct {
+	FiscalDateEnding   string  `json:"fiscalDateEnding"`
+	ReportedDate       string  `json:"reportedDate,omitempty"`
+	ReportedEPS        float64 `json:"reportedEPS"`
+	EstimatedEPS       float64 `json:"estimatedEPS,omitempty"`
+	Surprise           float64 `json:"surprise,omitempty"`
+	SurprisePercentage float64 `json:"surprisePercentage,omitempty"`
+}
+
+// Earnings represents annual and quarterly earnings history
+type Earnings struct {
+	Symbol    string           `json:"symbol"`
+	Annual    []EarningsReport `json:"annual"`
+	Quarterly []EarningsReport `json:"quarterly"`
+}
+
+// IncomeStatement represents a single income statement report
+type IncomeStatement struct {
+	FiscalDateEnding       string  `json:"fiscalDateEnding"`
+	ReportedCurrency       string  `json:"reportedCurrency"`
+	TotalRevenue           float64 `json:"totalRevenue"`
+	CostOfRevenue          float64 `json:"costOfRevenue"`
+	GrossProfit            float64 `json:"grossProfit"`
+	OperatingExpenses      float64 `json:"operatingExpenses"`
+	ResearchAndDevelopment float64 `json:"researchAndDevelopment"`
+	OperatingIncome        float64 `json:"operatingIncome"`
+	InterestExpense        float64 `json:"interestExpense"`
+	IncomeTaxExpense       float64 `json:"incomeTaxExpense"`
+	EBIT                   float64 `json:"ebit"`
+	EBITDA                 float64 `json:"ebitda"`
+	NetIncome              float64 `json:"netIncome"`
+}
+
+// BalanceSheet represents a single balance sheet report
+type BalanceSheet struct {
+	FiscalDateEnding             string  `json:"fiscalDateEnding"`
+	ReportedCurrency             string  `json:"reportedCurrency"`
+	TotalAssets                  float64 `json:"totalAssets"`
+	TotalCurrentAssets           float64 `json:"totalCurrentAssets"`
+	CashAndEquivalents           float64 `json:"cashAndEquivalents"`
+	Inventory                    float64 `json:"inventory"`
+	TotalLiabilities             float64 `json:"totalLiabilities"`
+	TotalCurrentLiabilities      float64 `json:"totalCurrentLiabilities"`
+	ShortTermDebt                float64 `json:"shortTermDebt"`
+	LongTermDebt                 float64 `json:"longTermDebt"`
+	TotalShareholderEquity       float64 `json:"totalShareholderEquity"`
+	RetainedEarnings             float64 `json:"retainedEarnings"`
+	CommonStockSharesOutstanding float64 `json:"commonStockSharesOutstanding"`
+}
+
+// CashFlowStatement represents a single cash flow report
+type CashFlowStatement struct {
+	FiscalDateEnding      string  `json:"fiscalDateEnding"`
+	ReportedCurrency      string  `json:"reportedCurrency"`
+	OperatingCashflow     float64 `json:"operatingCashflow"`
+	CapitalExpenditures   float64 `json:"capitalExpenditures"`
+	CashflowFromInvesting float64 `json:"cashflowFromInvesting"`
+	CashflowFromFinancing float64 `json:"cashflowFromFinancing"`
+	DividendPayout        float64 `json:"dividendPayout"`
+	NetIncome             float64 `json:"netIncome"`
+	ChangeInCash          float64 `json:"changeInCash"`
+}
+
+// IncomeStatements represents annual and quarterly income statements
+type IncomeStatements struct {
+	Symbol    string            `json:"symbol"`
+	Annual    []IncomeStatement `json:"annual"`
+	Quarterly []IncomeStatement `json:"quarterly"`
+}
+
+// BalanceSheets represents annual and quarterly balance sheets
+type BalanceSheets struct {
+	Symbol    string         `json:"symbol"`
+	Annual    []BalanceSheet `json:"annual"`
+	Quarterly []BalanceSheet `json:"quarterly"`
+}
+
+// CashFlowStatements represents annual and quarterly cash flow statements
+type CashFlowStatements struct {
+	Symbol    string              `json:"symbol"`
+	Annual    []CashFlowStatement `json:"annual"`
+	Quarterly []CashFlowStatement `json:"quarterly"`
+}
+
+// FinancialRatios represents valuation and profitability ratios derived from fundamentals
+type FinancialRatios struct {
+	Symbol           string  `json:"symbol"`
+	FiscalDateEnding string  `json:"fiscalDateEnding"`
+	Price            float64 `json:"price"`
+	MarketCap        float64 `json:"marketCap"`
+	PriceToEarnings  float64 `json:"priceToEarnings"`
+	PriceToBook      float64 `json:"priceToBook"`
+	DebtToEquity     float64 `json:"debtToEquity"`
+	GrossMargin      float64 `json:"grossMargin"`
+	OperatingMargin  float64 `json:"operatingMargin"`
+	NetMargin        float64 `json:"netMargin"`
+	ReturnOnEquity   float64 `json:"returnOnEquity"`
+	FreeCashFlow     float64 `json:"freeCashFlow"`
+	FCFYield         float64 `json:"fcfYield"`
+}
@@ -8,6 +8,7 @@ type FinanceTopic struct {
 	Summary     string   `json:"summary"`
 	Keywords    []string `json:"keywords"`
 	Resources   []string `json:"resources"`
+	Indicators  []string `json:"indicators,omitempty"`
 }
 
 // StockQuote represents stock market data
@@ -60,6 +61,14 @@ type TimeSeriesData struct {
 	Volume int64   `json:"volume"`
 }
 
+// CorporateAction represents a day with a dividend or split in daily-adjusted price data.
+// SplitCoefficient is 1 on days without a split.
+type CorporateAction struct {
+	Date             string  `json:"date"`
+	DividendAmount   float64 `json:"dividendAmount"`
+	SplitCoefficient float64 `json:"splitCoefficient"`
+}
+
 // MarketOverview represents general market statistics
 type MarketOverview struct {
 	TotalMarketCap float64      `json:"totalMarketCap"`
@@ -67,6 +76,17 @@ type MarketOverview struct {
 	TopGainers     []StockQuote `json:"topGainers"`
 	TopLosers      []StockQuote `json:"topLosers"`
 	MostActive     []StockQuote `json:"mostActive"`
+	LastUpdated    string       `json:"lastUpdated"`
+}
+
+// GlobalCryptoMarket represents aggregate cryptocurrency market data
+type GlobalCryptoMarket struct {
+	TotalMarketCap         float64            `json:"totalMarketCap"`
+	TotalVolume24h         float64            `json:"totalVolume24h"`
+	MarketCapChange24h     float64            `json:"marketCapChange24h"`
+	MarketCapPercentage    map[string]float64 `json:"marketCapPercentage"`
+	ActiveCryptocurrencies int                `json:"activeCryptocurrencies"`
+	LastUpdated            string             `json:"lastUpdated"`
 }
 
 // APIResponse is a generic response wrapper
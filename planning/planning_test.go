@@ -0,0 +1,154 @@
+package planning
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectCompoundGrowth(t *testing.T) {
+	projection, err := Project(PlanRequest{
+		CurrentAge:     30,
+		RetirementAge:  40,
+		EndAge:         40,
+		InitialBalance: 10000,
+		ExpectedReturn: 0.07,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, projection.Years, 10)
+	assert.Equal(t, 19671.51, projection.EndingBalance)
+	assert.Equal(t, 19671.51, projection.BalanceAtRetirement)
+	assert.Equal(t, 9671.51, projection.TotalGrowth)
+}
+
+func TestProjectContributions(t *testing.T) {
+	projection, err := Project(PlanRequest{
+		CurrentAge:         30,
+		RetirementAge:      40,
+		EndAge:             40,
+		AnnualContribution: 1000,
+		ExpectedReturn:     0.05,
+		InflationRate:      0.02,
+	})
+
+	assert.NoError(t, err)
+	// Future value of an ordinary annuity: 1000 * ((1.05^10 - 1) / 0.05)
+	assert.Equal(t, 12577.89, projection.EndingBalance)
+	assert.Equal(t, 10000.0, projection.TotalContributions)
+	assert.InDelta(t, 12577.89/1.2190, projection.Years[9].RealBalance, 1)
+}
+
+func TestProjectWithdrawals(t *testing.T) {
+	projection, err := Project(PlanRequest{
+		CurrentAge:       65,
+		RetirementAge:    65,
+		EndAge:           95,
+		InitialBalance:   500000,
+		AnnualWithdrawal: 60000,
+		ExpectedReturn:   0.03,
+		InflationRate:    0.03,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 500000.0, projection.BalanceAtRetirement)
+	assert.Equal(t, 61800.0, projection.Years[0].Withdrawal)
+	assert.Equal(t, 0.0, projection.EndingBalance)
+	assert.Equal(t, 74, projection.DepletionAge)
+}
+
+func TestSimulateReproducible(t *testing.T) {
+	req := PlanRequest{
+		CurrentAge:         40,
+		RetirementAge:      65,
+		EndAge:             90,
+		InitialBalance:     100000,
+		AnnualContribution: 15000,
+		AnnualWithdrawal:   60000,
+		ExpectedReturn:     0.06,
+		Volatility:         0.15,
+		InflationRate:      0.025,
+		Simulations:        500,
+		Seed:               42,
+	}
+
+	first, err := Simulate(req)
+	assert.NoError(t, err)
+	second, err := Simulate(req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, second, "Same seed should produce identical results")
+	assert.Len(t, first.Bands, 50)
+	assert.Greater(t, first.ProbabilityOfSuccess, 0.0)
+	assert.Less(t, first.ProbabilityOfSuccess, 1.0)
+	for _, band := range first.Bands {
+		assert.LessOrEqual(t, band.P10, band.P25)
+		assert.LessOrEqual(t, band.P25, band.P50)
+		assert.LessOrEqual(t, band.P50, band.P75)
+		assert.LessOrEqual(t, band.P75, band.P90)
+	}
+
+	req.Seed = 7
+	third, err := Simulate(req)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.Bands, third.Bands)
+}
+
+func TestSimulateWithoutVolatilityMatchesProjection(t *testing.T) {
+	req := PlanRequest{
+		CurrentAge:         30,
+		RetirementAge:      60,
+		EndAge:             90,
+		InitialBalance:     50000,
+		AnnualContribution: 10000,
+		AnnualWithdrawal:   20000,
+		ExpectedReturn:     0.05,
+		InflationRate:      0.02,
+		Simulations:        10,
+	}
+
+	projection, err := Project(req)
+	assert.NoError(t, err)
+	simulation, err := Simulate(req)
+	assert.NoError(t, err)
+
+	assert.InDelta(t, projection.EndingBalance, simulation.MedianEndingBalance, 0.01)
+	assert.Equal(t, 1.0, simulation.ProbabilityOfSuccess)
+	assert.Equal(t, defaultSimulations, mustSimulate(t, PlanRequest{CurrentAge: 30, RetirementAge: 31, EndAge: 32}).Simulations)
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+
+	assert.Equal(t, 1.0, Percentile(values, 0))
+	assert.Equal(t, 3.0, Percentile(values, 50))
+	assert.Equal(t, 4.6, Percentile(values, 90))
+	assert.Equal(t, 5.0, Percentile(values, 100))
+	assert.Equal(t, 0.0, Percentile(nil, 50))
+}
+
+func TestPlanValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PlanRequest
+	}{
+		{name: "End before current age", req: PlanRequest{CurrentAge: 60, RetirementAge: 60, EndAge: 50}},
+		{name: "Retirement after end", req: PlanRequest{CurrentAge: 30, RetirementAge: 100, EndAge: 90}},
+		{name: "Negative contribution", req: PlanRequest{CurrentAge: 30, RetirementAge: 60, EndAge: 90, AnnualContribution: -1}},
+		{name: "Too many simulations", req: PlanRequest{CurrentAge: 30, RetirementAge: 60, EndAge: 90, Simulations: maxSimulations + 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Simulate(tt.req)
+			assert.True(t, errors.Is(err, ErrInvalidPlan))
+		})
+	}
+}
+
+func mustSimulate(t *testing.T, req PlanRequest) *SimulationResult {
+	result, err := Simulate(req)
+	assert.NoError(t, err)
+	return result
+}
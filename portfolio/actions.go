@@ -0,0 +1,97 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Corporate action types
+const (
+	Dividend = "dividend"
+	Split    = "split"
+)
+
+// CorporateAction is a cash dividend or stock split on its ex-date. Amount is the dividend
+// per share held before the ex-date; Ratio is the number of new shares per old share
+// (2 for a 2-for-1 split, 0.1 for a 1-for-10 reverse split).
+type CorporateAction struct {
+	ID     string  `json:"id,omitempty"`
+	Symbol string  `json:"symbol"`
+	Date   string  `json:"date"`
+	Type   string  `json:"type"`
+	Amount float64 `json:"amount,omitempty"`
+	Ratio  float64 `json:"ratio,omitempty"`
+	Source string  `json:"source,omitempty"`
+}
+
+// Validate checks that the corporate action is complete
+func (a CorporateAction) Validate() error {
+	if _, err := time.Parse(DateLayout, a.Date); err != nil {
+		return fmt.Errorf("%w: corporate action date must be in YYYY-MM-DD format", ErrInvalidPortfolio)
+	}
+	switch {
+	case strings.TrimSpace(a.Symbol) == "":
+		return fmt.Errorf("%w: corporate action symbol is required", ErrInvalidPortfolio)
+	case a.Type == Dividend && a.Amount <= 0:
+		return fmt.Errorf("%w: dividend amount must be positive", ErrInvalidPortfolio)
+	case a.Type == Split && (a.Ratio <= 0 || a.Ratio == 1):
+		return fmt.Errorf("%w: split ratio must be positive and not 1", ErrInvalidPortfolio)
+	case a.Type != Dividend && a.Type != Split:
+		return fmt.Errorf("%w: corporate action type must be dividend or split", ErrInvalidPortfolio)
+	}
+	return nil
+}
+
+// sortedActions validates corporate actions and returns them in date order with upper-case symbols
+func sortedActions(actions []CorporateAction) ([]CorporateAction, error) {
+	sorted := make([]CorporateAction, len(actions))
+	for i, a := range actions {
+		if err := a.Validate(); err != nil {
+			return nil, err
+		}
+		a.Symbol = strings.ToUpper(strings.TrimSpace(a.Symbol))
+		sorted[i] = a
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date < sorted[j].Date
+	})
+	return sorted, nil
+}
+
+// splitsOnly returns the splits among date-ordered corporate actions
+func splitsOnly(actions []CorporateAction) []CorporateAction {
+	var splits []CorporateAction
+	for _, a := range actions {
+		if a.Type == Split {
+			splits = append(splits, a)
+		}
+	}
+	return splits
+}
+
+// splitCursor applies date-ordered splits to holdings as a replay reaches their ex-dates
+type splitCursor struct {
+	splits []CorporateAction
+	next   int
+}
+
+// through calls apply for every split on or before date not yet applied. Trades on a
+// split's ex-date are in post-split shares, so splits apply before that day's trades.
+func (c *splitCursor) through(date string, apply func(split CorporateAction)) {
+	for ; c.next < len(c.splits) && c.splits[c.next].Date <= date; c.next++ {
+		apply(c.splits[c.next])
+	}
+}
+
+// splitFactor returns the number of shares today per share held on date
+func splitFactor(splits []CorporateAction, symbol, date string) float64 {
+	factor := 1.0
+	for _, split := range splits {
+		if split.Symbol == symbol && split.Date > date {
+			factor *= split.Ratio
+		}
+	}
+	return factor
+}
@@ -0,0 +1,187 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidAllocation is returned when allocation targets or positions are missing or inconsistent
+var ErrInvalidAllocation = errors.New("invalid allocation")
+
+// Allocation dimensions
+const (
+	ByAssetClass = "assetClass"
+	BySector     = "sector"
+	BySymbol     = "symbol"
+)
+
+// DefaultDriftBand is the tolerance applied to targets without their own band
+const DefaultDriftBand = 0.05
+
+// weightTolerance allows target weights to sum to one within rounding
+const weightTolerance = 1e-4
+
+// Target is the desired weight of an asset class, sector or symbol. Band is the absolute drift
+// tolerated either side of the weight before rebalancing (0.05 allows 35% to 45% for a 40% target).
+type Target struct {
+	Key    string  `json:"key"`
+	Weight float64 `json:"weight"`
+	Band   float64 `json:"band,omitempty"`
+}
+
+// AllocationTargets are a portfolio's target weights along one dimension. Band is the default
+// drift band for targets that do not set their own, and defaults to DefaultDriftBand.
+type AllocationTargets struct {
+	Dimension string   `json:"dimension"`
+	Band      float64  `json:"band,omitempty"`
+	Targets   []Target `json:"targets"`
+}
+
+// Position is a current holding. CostBasis is the total cost of the shares held and is used to
+// estimate realized gains; positions without one are treated as having no unrealized gain.
+// A position with zero quantity can be listed as a candidate to buy into an empty target.
+type Position struct {
+	Symbol     string  `json:"symbol"`
+	AssetClass string  `json:"assetClass,omitempty"`
+	Sector     string  `json:"sector,omitempty"`
+	Quantity   float64 `json:"quantity"`
+	Price      float64 `json:"price"`
+	CostBasis  float64 `json:"costBasis,omitempty"`
+}
+
+// Drift compares a group's current weight to its target
+type Drift struct {
+	Key           string  `json:"key"`
+	Value         float64 `json:"value"`
+	CurrentWeight float64 `json:"currentWeight"`
+	TargetWeight  float64 `json:"targetWeight"`
+	Drift         float64 `json:"drift"`
+	Band          float64 `json:"band"`
+	OutOfBand     bool    `json:"outOfBand"`
+}
+
+// Normalize validates the targets and fills in the default dimension and bands.
+// Keys are upper-cased for the symbol dimension and weights must sum to one.
+func (a *AllocationTargets) Normalize() error {
+	if a.Dimension == "" {
+		a.Dimension = ByAssetClass
+	}
+	if a.Dimension != ByAssetClass && a.Dimension != BySector && a.Dimension != BySymbol {
+		return fmt.Errorf("%w: dimension must be assetClass, sector or symbol", ErrInvalidAllocation)
+	}
+	if a.Band == 0 {
+		a.Band = DefaultDriftBand
+	}
+	if a.Band < 0 || a.Band >= 1 {
+		return fmt.Errorf("%w: band must be between 0 and 1", ErrInvalidAllocation)
+	}
+	if len(a.Targets) == 0 {
+		return fmt.Errorf("%w: at least one target is required", ErrInvalidAllocation)
+	}
+
+	targets := make([]Target, len(a.Targets))
+	seen := make(map[string]bool)
+	total := 0.0
+	for i, target := range a.Targets {
+		target.Key = a.normalizeKey(target.Key)
+		if target.Band == 0 {
+			target.Band = a.Band
+		}
+		switch {
+		case target.Key == "":
+			return fmt.Errorf("%w: target key is required", ErrInvalidAllocation)
+		case seen[strings.ToLower(target.Key)]:
+			return fmt.Errorf("%w: duplicate target %s", ErrInvalidAllocation, target.Key)
+		case target.Weight < 0 || target.Weight > 1:
+			return fmt.Errorf("%w: target weights must be between 0 and 1", ErrInvalidAllocation)
+		case target.Band < 0 || target.Band >= 1:
+			return fmt.Errorf("%w: band must be between 0 and 1", ErrInvalidAllocation)
+		}
+		seen[strings.ToLower(target.Key)] = true
+		total += target.Weight
+		targets[i] = target
+	}
+	if math.Abs(total-1) > weightTolerance {
+		return fmt.Errorf("%w: target weights sum to %g, not 1", ErrInvalidAllocation, roundTo(total, 6))
+	}
+	a.Targets = targets
+	return nil
+}
+
+// normalizeKey trims a group key, upper-casing it for the symbol dimension
+func (a *AllocationTargets) normalizeKey(key string) string {
+	key = strings.TrimSpace(key)
+	if a.Dimension == BySymbol {
+		return strings.ToUpper(key)
+	}
+	return key
+}
+
+// groupOf returns the key of the group a position belongs to. Asset class and sector
+// keys match targets case-insensitively, so the target's spelling is used when there is one.
+func (a *AllocationTargets) groupOf(p Position) string {
+	var key string
+	switch a.Dimension {
+	case BySector:
+		key = p.Sector
+	case BySymbol:
+		key = p.Symbol
+	default:
+		key = p.AssetClass
+	}
+	key = a.normalizeKey(key)
+	for _, target := range a.Targets {
+		if strings.EqualFold(target.Key, key) {
+			return target.Key
+		}
+	}
+	return key
+}
+
+// CalculateDrift values the positions and cash and compares each group's weight to its target.
+// Held groups without a target have a target weight of zero and the default band.
+// Targets must already be normalized.
+func CalculateDrift(targets AllocationTargets, positions []Position, cash float64) ([]Drift, float64) {
+	values := make(map[string]float64)
+	total := cash
+	for _, p := range positions {
+		value := p.Quantity * p.Price
+		values[targets.groupOf(p)] += value
+		total += value
+	}
+
+	drifts := make([]Drift, 0, len(targets.Targets))
+	addDrift := func(key string, weight, band float64) {
+		drift := Drift{
+			Key:          key,
+			Value:        roundTo(values[key], 2),
+			TargetWeight: weight,
+			Band:         band,
+		}
+		if total > 0 {
+			drift.CurrentWeight = roundTo(values[key]/total, 6)
+		}
+		drift.Drift = roundTo(drift.CurrentWeight-weight, 6)
+		drift.OutOfBand = math.Abs(drift.Drift) > band+1e-9
+		drifts = append(drifts, drift)
+		delete(values, key)
+	}
+	for _, target := range targets.Targets {
+		addDrift(target.Key, target.Weight, target.Band)
+	}
+
+	untargeted := make([]string, 0, len(values))
+	for key, value := range values {
+		if value > 0 {
+			untargeted = append(untargeted, key)
+		}
+	}
+	sort.Strings(untargeted)
+	for _, key := range untargeted {
+		addDrift(key, 0, targets.Band)
+	}
+	return drifts, total
+}
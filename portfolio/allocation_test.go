@@ -0,0 +1,128 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testTargets = AllocationTargets{
+	Targets: []Target{
+		{Key: "Stocks", Weight: 0.6},
+		{Key: "Bonds", Weight: 0.4},
+	},
+}
+
+var testPositions = []Position{
+	{Symbol: "vti", AssetClass: "stocks", Quantity: 70, Price: 10, CostBasis: 500},
+	{Symbol: "VXUS", AssetClass: "Stocks", Quantity: 10, Price: 10, CostBasis: 150},
+	{Symbol: "BND", AssetClass: "Bonds", Quantity: 20, Price: 10, CostBasis: 200},
+}
+
+func TestCalculateDrift(t *testing.T) {
+	targets := testTargets
+	assert.NoError(t, targets.Normalize())
+	positions := append([]Position{{Symbol: "GLD", AssetClass: "Gold", Quantity: 1, Price: 100}}, testPositions...)
+
+	drifts, total := CalculateDrift(targets, positions, 0)
+
+	assert.Equal(t, 1100.0, total)
+	assert.Len(t, drifts, 3)
+	assert.Equal(t, "Stocks", drifts[0].Key)
+	assert.Equal(t, 800.0, drifts[0].Value)
+	assert.Equal(t, 0.127273, drifts[0].Drift)
+	assert.Equal(t, DefaultDriftBand, drifts[0].Band)
+	assert.True(t, drifts[0].OutOfBand)
+	assert.Equal(t, "Gold", drifts[2].Key)
+	assert.Equal(t, 0.0, drifts[2].TargetWeight)
+	assert.True(t, drifts[2].OutOfBand)
+}
+
+func TestPlanRebalance(t *testing.T) {
+	plan, err := PlanRebalance(RebalanceRequest{Targets: testTargets, Positions: testPositions})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.0, plan.TotalValue)
+	assert.Equal(t, []Order{
+		{Symbol: "VXUS", Group: "Stocks", Side: Sell, Quantity: 10, Price: 10, Amount: 100, RealizedGain: -50},
+		{Symbol: "VTI", Group: "Stocks", Side: Sell, Quantity: 10, Price: 10, Amount: 100, RealizedGain: 28.57},
+		{Symbol: "BND", Group: "Bonds", Side: Buy, Quantity: 20, Price: 10, Amount: 200},
+	}, plan.Orders)
+	assert.Equal(t, -21.43, plan.RealizedGain)
+	assert.Equal(t, 0.0, plan.Cash)
+	assert.True(t, plan.Balanced)
+	assert.Equal(t, 0.6, plan.Projected[0].CurrentWeight)
+	assert.Empty(t, plan.Warnings)
+}
+
+func TestPlanRebalanceAvoidGains(t *testing.T) {
+	plan, err := PlanRebalance(RebalanceRequest{Targets: testTargets, Positions: testPositions, AvoidGains: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, plan.Orders, 2)
+	assert.Equal(t, "VXUS", plan.Orders[0].Symbol)
+	assert.Equal(t, 10.0, plan.Orders[1].Quantity)
+	assert.Equal(t, -50.0, plan.RealizedGain)
+	assert.False(t, plan.Balanced)
+	assert.Len(t, plan.Warnings, 2)
+}
+
+func TestPlanRebalanceWithinBand(t *testing.T) {
+	positions := []Position{
+		{Symbol: "VTI", AssetClass: "Stocks", Quantity: 63, Price: 10},
+		{Symbol: "BND", AssetClass: "Bonds", Quantity: 37, Price: 10},
+	}
+
+	plan, err := PlanRebalance(RebalanceRequest{Targets: testTargets, Positions: positions})
+
+	assert.NoError(t, err)
+	assert.Empty(t, plan.Orders)
+	assert.True(t, plan.Balanced)
+}
+
+func TestPlanRebalanceInvestsCash(t *testing.T) {
+	positions := []Position{
+		{Symbol: "VTI", AssetClass: "Stocks", Quantity: 60, Price: 10},
+		{Symbol: "BND", AssetClass: "Bonds", Quantity: 0, Price: 30},
+	}
+
+	plan, err := PlanRebalance(RebalanceRequest{Targets: testTargets, Positions: positions, Cash: 400, WholeShares: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Order{{Symbol: "BND", Group: "Bonds", Side: Buy, Quantity: 13, Price: 30, Amount: 390}}, plan.Orders)
+	assert.Equal(t, 10.0, plan.Cash)
+	assert.True(t, plan.Balanced)
+}
+
+func TestPlanRebalanceValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  RebalanceRequest
+	}{
+		{"no targets", RebalanceRequest{Positions: testPositions}},
+		{"weights do not sum to one", RebalanceRequest{
+			Targets:   AllocationTargets{Targets: []Target{{Key: "Stocks", Weight: 0.6}}},
+			Positions: testPositions,
+		}},
+		{"invalid dimension", RebalanceRequest{
+			Targets:   AllocationTargets{Dimension: "country", Targets: testTargets.Targets},
+			Positions: testPositions,
+		}},
+		{"missing sector", RebalanceRequest{
+			Targets:   AllocationTargets{Dimension: BySector, Targets: []Target{{Key: "Tech", Weight: 1}}},
+			Positions: testPositions,
+		}},
+		{"missing price", RebalanceRequest{
+			Targets:   testTargets,
+			Positions: []Position{{Symbol: "VTI", AssetClass: "Stocks", Quantity: 1}},
+		}},
+		{"no value", RebalanceRequest{Targets: testTargets}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PlanRebalance(tt.req)
+			assert.ErrorIs(t, err, ErrInvalidAllocation)
+		})
+	}
+}
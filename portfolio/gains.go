@@ -0,0 +1,173 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// GainsRequest describes the transactions to account for. Method is the lot relief method
+// for sells that do not select lots and defaults to FIFO. TaxYear limits the realized gains
+// reported to one year; zero reports every year. Splits among Actions adjust lot quantities.
+type GainsRequest struct {
+	Transactions []Transaction     `json:"transactions"`
+	Actions      []CorporateAction `json:"actions,omitempty"`
+	Method       string            `json:"method,omitempty"`
+	TaxYear      int               `json:"taxYear,omitempty"`
+}
+
+// TaxYearSummary totals the realized gains of one tax year
+type TaxYearSummary struct {
+	Year           int     `json:"year"`
+	Proceeds       float64 `json:"proceeds"`
+	CostBasis      float64 `json:"costBasis"`
+	ShortTermGain  float64 `json:"shortTermGain"`
+	LongTermGain   float64 `json:"longTermGain"`
+	TotalGain      float64 `json:"totalGain"`
+	DisallowedLoss float64 `json:"disallowedLoss"`
+}
+
+// GainsReport holds realized gains by lot and by tax year, and the lots still held
+type GainsReport struct {
+	Method   string           `json:"method"`
+	TaxYear  int              `json:"taxYear,omitempty"`
+	Realized []RealizedLot    `json:"realized"`
+	Years    []TaxYearSummary `json:"years"`
+	OpenLots []Lot            `json:"openLots"`
+}
+
+var gainsCSVHeader = []string{
+	"Symbol", "Lot", "Quantity", "Date Acquired", "Date Sold", "Proceeds", "Cost Basis",
+	"Wash Sale Loss Disallowed", "Gain", "Term",
+}
+
+// CalculateRealizedGains replays transactions in date order, relieving tax lots on each sell,
+// classifying gains as short- or long-term and adjusting wash sales. Splits multiply the shares
+// of open lots without changing their cost basis, and open lots reflect every split.
+func CalculateRealizedGains(req GainsRequest) (*GainsReport, error) {
+	method := req.Method
+	if method == "" {
+		method = FIFO
+	}
+	if method != FIFO && method != LIFO && method != HIFO && method != SpecificID {
+		return nil, fmt.Errorf("%w: method must be fifo, lifo, hifo or specific", ErrInvalidPortfolio)
+	}
+	if req.TaxYear < 0 {
+		return nil, fmt.Errorf("%w: tax year cannot be negative", ErrInvalidPortfolio)
+	}
+	actions, err := sortedActions(req.Actions)
+	if err != nil {
+		return nil, err
+	}
+	splits := splitsOnly(actions)
+	transactions, err := sortedTransactions(req.Transactions, splits)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := newLotLedger(transactions, splits, method)
+	if err := ledger.run(); err != nil {
+		return nil, err
+	}
+
+	report := &GainsReport{
+		Method:   method,
+		TaxYear:  req.TaxYear,
+		Realized: []RealizedLot{},
+		Years:    []TaxYearSummary{},
+		OpenLots: []Lot{},
+	}
+	years := make(map[int]*TaxYearSummary)
+	for _, realized := range ledger.realized {
+		year, _ := strconv.Atoi(realized.SoldDate[:4])
+		if req.TaxYear != 0 && year != req.TaxYear {
+			continue
+		}
+
+		summary, ok := years[year]
+		if !ok {
+			summary = &TaxYearSummary{Year: year}
+			years[year] = summary
+		}
+		summary.Proceeds += realized.Proceeds
+		summary.CostBasis += realized.CostBasis
+		summary.DisallowedLoss += realized.DisallowedLoss
+		if realized.Term == LongTerm {
+			summary.LongTermGain += realized.Gain
+		} else {
+			summary.ShortTermGain += realized.Gain
+		}
+
+		realized.Quantity = roundTo(realized.Quantity, 6)
+		realized.Proceeds = roundTo(realized.Proceeds, 2)
+		realized.CostBasis = roundTo(realized.CostBasis, 2)
+		realized.DisallowedLoss = roundTo(realized.DisallowedLoss, 2)
+		realized.Gain = roundTo(realized.Gain, 2)
+		report.Realized = append(report.Realized, realized)
+	}
+
+	for _, summary := range years {
+		summary.TotalGain = roundTo(summary.ShortTermGain+summary.LongTermGain, 2)
+		summary.Proceeds = roundTo(summary.Proceeds, 2)
+		summary.CostBasis = roundTo(summary.CostBasis, 2)
+		summary.ShortTermGain = roundTo(summary.ShortTermGain, 2)
+		summary.LongTermGain = roundTo(summary.LongTermGain, 2)
+		summary.DisallowedLoss = roundTo(summary.DisallowedLoss, 2)
+		report.Years = append(report.Years, *summary)
+	}
+	sort.Slice(report.Years, func(i, j int) bool {
+		return report.Years[i].Year < report.Years[j].Year
+	})
+
+	for _, lot := range ledger.lots {
+		if lot.Quantity > 0 {
+			open := *lot
+			open.Quantity = roundTo(open.Quantity, 6)
+			open.CostBasis = roundTo(open.CostBasis, 2)
+			open.WashSaleAdjustment = roundTo(open.WashSaleAdjustment, 2)
+			report.OpenLots = append(report.OpenLots, open)
+		}
+	}
+	sort.SliceStable(report.OpenLots, func(i, j int) bool {
+		if report.OpenLots[i].Symbol != report.OpenLots[j].Symbol {
+			return report.OpenLots[i].Symbol < report.OpenLots[j].Symbol
+		}
+		return report.OpenLots[i].AcquiredDate < report.OpenLots[j].AcquiredDate
+	})
+	return report, nil
+}
+
+// WriteGainsCSV writes the realized lots of a gains report as CSV
+func WriteGainsCSV(w io.Writer, report *GainsReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(gainsCSVHeader); err != nil {
+		return err
+	}
+
+	for _, realized := range report.Realized {
+		record := []string{
+			realized.Symbol,
+			realized.LotID,
+			strconv.FormatFloat(realized.Quantity, 'f', -1, 64),
+			realized.AcquiredDate,
+			realized.SoldDate,
+			formatAmount(realized.Proceeds),
+			formatAmount(realized.CostBasis),
+			formatAmount(realized.DisallowedLoss),
+			formatAmount(realized.Gain),
+			realized.Term,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
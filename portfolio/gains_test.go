@@ -0,0 +1,155 @@
+package portfolio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testLotTransactions = []Transaction{
+	{Date: "2024-03-01", Symbol: "AAA", Type: Sell, Quantity: 15, Price: 130},
+	{Date: "2023-01-03", Symbol: "aaa", Type: Buy, Quantity: 10, Price: 100},
+	{Date: "2023-06-01", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 150},
+	{Date: "2024-01-10", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 119, Fees: 10},
+}
+
+func TestCalculateRealizedGainsMethods(t *testing.T) {
+	tests := []struct {
+		method    string
+		lots      []LotSelection
+		gains     []float64
+		shortTerm float64
+		longTerm  float64
+	}{
+		{FIFO, nil, []float64{300, -100}, -100, 300},
+		{LIFO, nil, []float64{100, -100}, 0, 0},
+		{HIFO, nil, []float64{-200, 50}, -150, 0},
+		{SpecificID, []LotSelection{{LotID: "AAA-2023-01-03", Quantity: 5}, {LotID: "AAA-2024-01-10", Quantity: 10}},
+			[]float64{150, 100}, 100, 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			transactions := append([]Transaction{}, testLotTransactions...)
+			transactions[0].Lots = tt.lots
+
+			report, err := CalculateRealizedGains(GainsRequest{Transactions: transactions, Method: tt.method})
+
+			assert.NoError(t, err)
+			var gains []float64
+			for _, realized := range report.Realized {
+				gains = append(gains, realized.Gain)
+				assert.False(t, realized.WashSale)
+			}
+			assert.Equal(t, tt.gains, gains)
+			assert.Len(t, report.Years, 1)
+			assert.Equal(t, tt.shortTerm, report.Years[0].ShortTermGain)
+			assert.Equal(t, tt.longTerm, report.Years[0].LongTermGain)
+			assert.Equal(t, 1950.0, report.Years[0].Proceeds)
+
+			held := 0.0
+			for _, lot := range report.OpenLots {
+				held += lot.Quantity
+			}
+			assert.Equal(t, 15.0, held)
+		})
+	}
+}
+
+func TestWashSaleReplacementBoughtAfter(t *testing.T) {
+	report, err := CalculateRealizedGains(GainsRequest{Transactions: []Transaction{
+		{Date: "2024-01-02", Symbol: "XYZ", Type: Buy, Quantity: 10, Price: 100},
+		{Date: "2024-02-01", Symbol: "XYZ", Type: Sell, Quantity: 10, Price: 80},
+		{Date: "2024-02-15", Symbol: "XYZ", Type: Buy, Quantity: 5, Price: 85},
+	}})
+
+	assert.NoError(t, err)
+	realized := report.Realized[0]
+	assert.True(t, realized.WashSale)
+	assert.Equal(t, 100.0, realized.DisallowedLoss)
+	assert.Equal(t, -100.0, realized.Gain)
+	assert.Equal(t, 100.0, report.Years[0].DisallowedLoss)
+
+	assert.Equal(t, []Lot{{
+		ID:                 "XYZ-2024-02-15",
+		Symbol:             "XYZ",
+		AcquiredDate:       "2024-02-15",
+		HoldingDate:        "2024-01-16",
+		Quantity:           5,
+		CostBasis:          525,
+		WashSaleAdjustment: 100,
+		buy:                2,
+	}}, report.OpenLots)
+}
+
+func TestWashSaleReplacementBoughtBefore(t *testing.T) {
+	report, err := CalculateRealizedGains(GainsRequest{Transactions: []Transaction{
+		{Date: "2024-01-02", Symbol: "XYZ", Type: Buy, Quantity: 10, Price: 100},
+		{Date: "2024-01-20", Symbol: "XYZ", Type: Buy, Quantity: 10, Price: 90},
+		{Date: "2024-02-01", Symbol: "XYZ", Type: Sell, Quantity: 4, Price: 80},
+	}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 80.0, report.Realized[0].DisallowedLoss)
+	assert.Equal(t, 0.0, report.Realized[0].Gain)
+
+	assert.Len(t, report.OpenLots, 3)
+	replacement := report.OpenLots[2]
+	assert.Equal(t, "XYZ-2024-01-20-W", replacement.ID)
+	assert.Equal(t, 4.0, replacement.Quantity)
+	assert.Equal(t, 440.0, replacement.CostBasis)
+	assert.Equal(t, "2023-12-21", replacement.HoldingDate)
+	assert.Equal(t, 6.0, report.OpenLots[1].Quantity)
+	assert.Equal(t, 540.0, report.OpenLots[1].CostBasis)
+}
+
+func TestCalculateRealizedGainsTaxYear(t *testing.T) {
+	transactions := append([]Transaction{
+		{Date: "2023-12-01", Symbol: "AAA", Type: Sell, Quantity: 2, Price: 160, Fees: 2},
+	}, testLotTransactions...)
+
+	report, err := CalculateRealizedGains(GainsRequest{Transactions: transactions, TaxYear: 2023})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Realized, 1)
+	assert.Equal(t, 318.0, report.Realized[0].Proceeds)
+	assert.Equal(t, []TaxYearSummary{
+		{Year: 2023, Proceeds: 318, CostBasis: 200, ShortTermGain: 118, TotalGain: 118},
+	}, report.Years)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteGainsCSV(&buf, report))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "Symbol,Lot,Quantity,Date Acquired,Date Sold,Proceeds,Cost Basis,Wash Sale Loss Disallowed,Gain,Term", lines[0])
+	assert.Equal(t, "AAA,AAA-2023-01-03,2,2023-01-03,2023-12-01,318.00,200.00,0.00,118.00,short", lines[1])
+}
+
+func TestCalculateRealizedGainsValidation(t *testing.T) {
+	buy := Transaction{Date: "2024-01-02", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 100}
+	sell := Transaction{Date: "2024-02-01", Symbol: "AAA", Type: Sell, Quantity: 5, Price: 110}
+	withLots := func(t Transaction, lots ...LotSelection) Transaction {
+		t.Lots = lots
+		return t
+	}
+
+	tests := []struct {
+		name string
+		req  GainsRequest
+	}{
+		{"unknown method", GainsRequest{Transactions: []Transaction{buy}, Method: "average"}},
+		{"specific without lots", GainsRequest{Transactions: []Transaction{buy, sell}, Method: SpecificID}},
+		{"unknown lot", GainsRequest{Transactions: []Transaction{buy, withLots(sell, LotSelection{LotID: "AAA-2023-01-01", Quantity: 5})}}},
+		{"lot quantity mismatch", GainsRequest{Transactions: []Transaction{buy, withLots(sell, LotSelection{LotID: "AAA-2024-01-02", Quantity: 4})}}},
+		{"lots on buy", GainsRequest{Transactions: []Transaction{withLots(buy, LotSelection{LotID: "x", Quantity: 1})}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CalculateRealizedGains(tt.req)
+			assert.ErrorIs(t, err, ErrInvalidPortfolio)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testIncomeTransactions = []Transaction{
+	{Date: "2023-01-10", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 100},
+	{Date: "2023-10-01", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 60},
+	{Date: "2024-01-05", Symbol: "AAA", Type: Sell, Quantity: 10, Price: 65},
+}
+
+var testActions = []CorporateAction{
+	{Symbol: "aaa", Date: "2023-03-15", Type: Dividend, Amount: 0.5},
+	{Symbol: "AAA", Date: "2023-06-01", Type: Split, Ratio: 2},
+	{Symbol: "AAA", Date: "2023-09-15", Type: Dividend, Amount: 0.3},
+	{Symbol: "AAA", Date: "2023-12-15", Type: Dividend, Amount: 0.3},
+	{Symbol: "AAA", Date: "2024-03-15", Type: Dividend, Amount: 0.35},
+	{Symbol: "BBB", Date: "2024-03-15", Type: Dividend, Amount: 1},
+}
+
+func TestCalculateIncome(t *testing.T) {
+	report, err := CalculateIncome(IncomeRequest{Transactions: testIncomeTransactions, Actions: testActions})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-15", report.AsOf)
+	assert.Len(t, report.Holdings, 1)
+	holding := report.Holdings[0]
+	assert.Equal(t, 20.0, holding.Shares)
+	assert.Equal(t, 1066.67, holding.CostBasis)
+	assert.Equal(t, 27.0, holding.TotalIncome)
+	assert.Equal(t, 0.95, holding.TrailingDividend)
+	assert.Equal(t, 19.0, holding.ProjectedAnnualIncome)
+	assert.Equal(t, 0.017813, holding.YieldOnCost)
+	assert.Equal(t, []DividendPayment{
+		{Date: "2023-03-15", Shares: 10, PerShare: 0.5, Amount: 5},
+		{Date: "2023-09-15", Shares: 20, PerShare: 0.3, Amount: 6},
+		{Date: "2023-12-15", Shares: 30, PerShare: 0.3, Amount: 9},
+		{Date: "2024-03-15", Shares: 20, PerShare: 0.35, Amount: 7},
+	}, holding.Payments)
+	assert.Equal(t, []YearIncome{{Year: 2023, Amount: 20}, {Year: 2024, Amount: 7}}, report.ByYear)
+	assert.Equal(t, 27.0, report.TotalIncome)
+}
+
+func TestCalculateIncomeAsOf(t *testing.T) {
+	report, err := CalculateIncome(IncomeRequest{Transactions: testIncomeTransactions, Actions: testActions, AsOf: "2023-12-31"})
+
+	assert.NoError(t, err)
+	holding := report.Holdings[0]
+	assert.Equal(t, 30.0, holding.Shares)
+	assert.Equal(t, 20.0, holding.TotalIncome)
+	// The pre-split dividend is restated per post-split share
+	assert.Equal(t, 0.85, holding.TrailingDividend)
+	assert.Equal(t, 25.5, report.ProjectedAnnualIncome)
+	assert.Equal(t, 0.015938, report.YieldOnCost)
+}
+
+func TestCalculateIncomeValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  IncomeRequest
+	}{
+		{"no transactions", IncomeRequest{Actions: testActions}},
+		{"invalid split", IncomeRequest{Transactions: testIncomeTransactions, Actions: []CorporateAction{
+			{Symbol: "AAA", Date: "2023-06-01", Type: Split, Ratio: 1},
+		}}},
+		{"invalid dividend", IncomeRequest{Transactions: testIncomeTransactions, Actions: []CorporateAction{
+			{Symbol: "AAA", Date: "2023-06-01", Type: Dividend},
+		}}},
+		{"unknown type", IncomeRequest{Transactions: testIncomeTransactions, Actions: []CorporateAction{
+			{Symbol: "AAA", Date: "2023-06-01", Type: "spinoff", Ratio: 1},
+		}}},
+		{"invalid as-of date", IncomeRequest{Transactions: testIncomeTransactions, AsOf: "03/15/2024"}},
+		{"oversold without split", IncomeRequest{Transactions: append([]Transaction{}, testIncomeTransactions[0],
+			Transaction{Date: "2023-07-01", Symbol: "AAA", Type: Sell, Quantity: 15, Price: 55})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CalculateIncome(tt.req)
+			assert.ErrorIs(t, err, ErrInvalidPortfolio)
+		})
+	}
+}
+
+func TestSplitsAdjustLotsAndPerformance(t *testing.T) {
+	split := []CorporateAction{{Symbol: "AAA", Date: "2024-01-04", Type: Split, Ratio: 2}}
+
+	gains, err := CalculateRealizedGains(GainsRequest{
+		Transactions: []Transaction{
+			{Date: "2024-01-02", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 100},
+			{Date: "2024-01-05", Symbol: "AAA", Type: Sell, Quantity: 15, Price: 60},
+		},
+		Actions: split,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 750.0, gains.Realized[0].CostBasis)
+	assert.Equal(t, 150.0, gains.Realized[0].Gain)
+	assert.Equal(t, 5.0, gains.OpenLots[0].Quantity)
+	assert.Equal(t, 250.0, gains.OpenLots[0].CostBasis)
+
+	perf, err := CalculatePerformance(PerformanceRequest{
+		Transactions: []Transaction{{Date: "2024-01-02", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 100}},
+		Actions:      split,
+	}, map[string][]PricePoint{"AAA": {
+		{Date: "2024-01-02", Close: 100},
+		{Date: "2024-01-03", Close: 110},
+		{Date: "2024-01-04", Close: 56},
+		{Date: "2024-01-05", Close: 55},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, 1120.0, perf.Series[2].Value)
+	assert.Equal(t, 1100.0, perf.EndValue)
+	assert.Equal(t, 0.1, perf.TimeWeightedReturn)
+}
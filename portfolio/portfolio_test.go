@@ -0,0 +1,149 @@
+package portfolio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPrices = map[string][]PricePoint{
+	"aaa": {
+		{Date: "2024-01-05", Close: 108.9},
+		{Date: "2024-01-02", Close: 100},
+		{Date: "2024-01-03", Close: 110},
+		{Date: "2024-01-04", Close: 99},
+	},
+	"SPY": {
+		{Date: "2024-01-02", Close: 400},
+		{Date: "2024-01-05", Close: 420},
+	},
+}
+
+func TestCalculatePerformance(t *testing.T) {
+	req := PerformanceRequest{
+		Transactions: []Transaction{
+			{Date: "2024-01-04", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 99},
+			{Date: "2024-01-02", Symbol: "aaa", Type: Buy, Quantity: 10, Price: 100},
+		},
+		Benchmark: "spy",
+	}
+
+	perf, err := CalculatePerformance(req, testPrices)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-02", perf.StartDate)
+	assert.Equal(t, "2024-01-05", perf.EndDate)
+	assert.Equal(t, 1000.0, perf.StartValue)
+	assert.Equal(t, 2178.0, perf.EndValue)
+	assert.Equal(t, 990.0, perf.NetContributions)
+	assert.Equal(t, 188.0, perf.Gain)
+	assert.Equal(t, 0.089, perf.TimeWeightedReturn)
+	// Periods shorter than a year are not annualized
+	assert.Equal(t, perf.TimeWeightedReturn, perf.AnnualizedReturn)
+
+	assert.Len(t, perf.Series, 4)
+	assert.Equal(t, 0.1, perf.Series[1].CumulativeReturn)
+	assert.Equal(t, 990.0, perf.Series[2].NetFlow)
+	assert.Equal(t, -0.01, perf.Series[2].CumulativeReturn)
+	assert.Equal(t, 0.0, perf.Series[2].BenchmarkReturn)
+
+	assert.Equal(t, "SPY", perf.Benchmark.Symbol)
+	assert.Equal(t, 0.05, perf.Benchmark.Return)
+	assert.Equal(t, 0.039, perf.Benchmark.ExcessReturn)
+}
+
+func TestCalculatePerformanceDateRange(t *testing.T) {
+	req := PerformanceRequest{
+		Transactions: []Transaction{
+			{Date: "2024-01-02", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 100},
+			{Date: "2024-01-04", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 99},
+		},
+		StartDate: "2024-01-04",
+		EndDate:   "2024-01-05",
+	}
+
+	perf, err := CalculatePerformance(req, testPrices)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1980.0, perf.StartValue)
+	assert.Equal(t, 0.0, perf.NetContributions)
+	assert.Equal(t, 0.1, perf.TimeWeightedReturn)
+	assert.Nil(t, perf.Benchmark)
+}
+
+func TestCalculatePerformanceLiquidation(t *testing.T) {
+	req := PerformanceRequest{
+		Transactions: []Transaction{
+			{Date: "2024-01-02", Symbol: "AAA", Type: Buy, Quantity: 10, Price: 100},
+			{Date: "2024-01-03", Symbol: "AAA", Type: Sell, Quantity: 10, Price: 110},
+		},
+	}
+
+	perf, err := CalculatePerformance(req, testPrices)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, perf.EndValue)
+	assert.Equal(t, -1100.0, perf.NetContributions)
+	assert.Equal(t, 0.1, perf.TimeWeightedReturn)
+}
+
+func TestAnnualizedReturn(t *testing.T) {
+	req := PerformanceRequest{
+		Transactions: []Transaction{{Date: "2022-01-03", Symbol: "BBB", Type: Buy, Quantity: 1, Price: 100}},
+	}
+	prices := map[string][]PricePoint{
+		"BBB": {{Date: "2022-01-03", Close: 100}, {Date: "2024-01-03", Close: 121}},
+	}
+
+	perf, err := CalculatePerformance(req, prices)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.21, perf.TimeWeightedReturn)
+	assert.Equal(t, 0.1, perf.AnnualizedReturn)
+	assert.InDelta(t, 0.1, perf.MoneyWeightedReturn, 1e-6)
+}
+
+func TestCalculatePerformanceValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PerformanceRequest
+	}{
+		{"no transactions", PerformanceRequest{}},
+		{"invalid type", PerformanceRequest{Transactions: []Transaction{
+			{Date: "2024-01-02", Symbol: "AAA", Type: "short", Quantity: 1, Price: 100},
+		}}},
+		{"oversold", PerformanceRequest{Transactions: []Transaction{
+			{Date: "2024-01-02", Symbol: "AAA", Type: Buy, Quantity: 1, Price: 100},
+			{Date: "2024-01-03", Symbol: "AAA", Type: Sell, Quantity: 2, Price: 110},
+		}}},
+		{"inverted range", PerformanceRequest{
+			Transactions: []Transaction{{Date: "2024-01-02", Symbol: "AAA", Type: Buy, Quantity: 1, Price: 100}},
+			StartDate:    "2024-02-01",
+			EndDate:      "2024-01-01",
+		}},
+		{"missing benchmark", PerformanceRequest{
+			Transactions: []Transaction{{Date: "2024-01-02", Symbol: "AAA", Type: Buy, Quantity: 1, Price: 100}},
+			Benchmark:    "QQQ",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CalculatePerformance(tt.req, testPrices)
+			assert.ErrorIs(t, err, ErrInvalidPortfolio)
+		})
+	}
+}
+
+func TestXIRR(t *testing.T) {
+	rate, err := XIRR([]CashFlow{
+		{Date: "2023-01-01", Amount: -10000},
+		{Date: "2023-07-01", Amount: 2000},
+		{Date: "2024-01-01", Amount: 9000},
+	})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.110889, rate, 1e-6)
+
+	_, err = XIRR([]CashFlow{{Date: "2023-01-01", Amount: 100}})
+	assert.ErrorIs(t, err, ErrXIRRNotFound)
+}
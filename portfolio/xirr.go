@@ -0,0 +1,57 @@
+package portfolio
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"financehub/calculators"
+)
+
+// ErrXIRRNotFound is returned when dated cash flows have no internal rate of return
+var ErrXIRRNotFound = errors.New("XIRR not found")
+
+// CashFlow is an amount paid (negative) or received (positive) by the investor on a date
+type CashFlow struct {
+	Date   string  `json:"date"`
+	Amount float64 `json:"amount"`
+}
+
+// XNPV returns the net present value of dated cash flows at an annual rate,
+// discounting to the first cash flow's date on a 365-day year
+func XNPV(rate float64, cashFlows []CashFlow) float64 {
+	if len(cashFlows) == 0 {
+		return 0
+	}
+	start, _ := time.Parse(DateLayout, cashFlows[0].Date)
+
+	var npv float64
+	for _, cashFlow := range cashFlows {
+		date, _ := time.Parse(DateLayout, cashFlow.Date)
+		years := date.Sub(start).Hours() / 24 / 365
+		npv += cashFlow.Amount / math.Pow(1+rate, years)
+	}
+	return npv
+}
+
+// XIRR returns the annual internal rate of return of dated cash flows.
+// Cash flows must contain at least one negative and one positive amount.
+func XIRR(cashFlows []CashFlow) (float64, error) {
+	var hasNegative, hasPositive bool
+	for _, cashFlow := range cashFlows {
+		if _, err := time.Parse(DateLayout, cashFlow.Date); err != nil {
+			return 0, ErrXIRRNotFound
+		}
+		hasNegative = hasNegative || cashFlow.Amount < 0
+		hasPositive = hasPositive || cashFlow.Amount > 0
+	}
+	if !hasNegative || !hasPositive {
+		return 0, ErrXIRRNotFound
+	}
+
+	rate, ok := calculators.SolveRate(func(rate float64) float64 { return XNPV(rate, cashFlows) })
+	if !ok {
+		return 0, ErrXIRRNotFound
+	}
+	return rate, nil
+}
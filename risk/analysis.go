@@ -0,0 +1,300 @@
+package risk
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"financehub/portfolio"
+)
+
+// ErrInvalidRequest is returned when holdings or risk parameters are missing or out of range
+var ErrInvalidRequest = errors.New("invalid risk request")
+
+// Price sources
+const (
+	SourceStock  = "stock"
+	SourceCrypto = "crypto"
+)
+
+const (
+	defaultConfidence     = 0.95
+	defaultLookbackDays   = 252
+	maxLookbackDays       = 2520
+	defaultPeriodsPerYear = 252
+)
+
+// Holding is a position in a portfolio. Weights are taken from Quantity at the latest price when any
+// quantity is given, otherwise from Weight; with neither, holdings are equally weighted.
+type Holding struct {
+	Symbol   string  `json:"symbol"`
+	Source   string  `json:"source,omitempty"`
+	Weight   float64 `json:"weight,omitempty"`
+	Quantity float64 `json:"quantity,omitempty"`
+}
+
+// Request describes the holdings to analyze. Symbols are stock tickers or, for the crypto source,
+// CoinGecko coin IDs. Confidence defaults to 0.95, Days to 252 observations and PeriodsPerYear to 252.
+type Request struct {
+	Holdings        []Holding `json:"holdings"`
+	Benchmark       string    `json:"benchmark,omitempty"`
+	BenchmarkSource string    `json:"benchmarkSource,omitempty"`
+	RiskFreeRate    float64   `json:"riskFreeRate"`
+	Confidence      float64   `json:"confidence,omitempty"`
+	Days            int       `json:"days,omitempty"`
+	PeriodsPerYear  float64   `json:"periodsPerYear,omitempty"`
+}
+
+// Metrics are the risk measures of a single holding or of the weighted portfolio.
+// Beta is zero when no benchmark is requested.
+type Metrics struct {
+	Symbol           string  `json:"symbol"`
+	Weight           float64 `json:"weight"`
+	AnnualizedReturn float64 `json:"annualizedReturn"`
+	Volatility       float64 `json:"volatility"`
+	Beta             float64 `json:"beta"`
+	SharpeRatio      float64 `json:"sharpeRatio"`
+	SortinoRatio     float64 `json:"sortinoRatio"`
+	MaxDrawdown      float64 `json:"maxDrawdown"`
+	HistoricalVaR    float64 `json:"historicalVaR"`
+	ParametricVaR    float64 `json:"parametricVaR"`
+	ConditionalVaR   float64 `json:"conditionalVaR"`
+}
+
+// CorrelationMatrix holds pairwise return correlations in the order of Symbols
+type CorrelationMatrix struct {
+	Symbols []string    `json:"symbols"`
+	Values  [][]float64 `json:"values"`
+}
+
+// Report is the risk analysis of each holding and of the portfolio over the aligned observation window
+type Report struct {
+	StartDate    string            `json:"startDate"`
+	EndDate      string            `json:"endDate"`
+	Observations int               `json:"observations"`
+	Confidence   float64           `json:"confidence"`
+	Benchmark    string            `json:"benchmark,omitempty"`
+	Holdings     []Metrics         `json:"holdings"`
+	Portfolio    Metrics           `json:"portfolio"`
+	Correlation  CorrelationMatrix `json:"correlation"`
+}
+
+// Normalize validates the request and fills in defaults
+func (r *Request) Normalize() error {
+	if len(r.Holdings) == 0 {
+		return fmt.Errorf("%w: at least one holding is required", ErrInvalidRequest)
+	}
+	seen := make(map[string]bool)
+	for i := range r.Holdings {
+		h := &r.Holdings[i]
+		h.Symbol = strings.TrimSpace(h.Symbol)
+		if h.Source == "" {
+			h.Source = SourceStock
+		}
+		switch {
+		case h.Symbol == "":
+			return fmt.Errorf("%w: holding symbol is required", ErrInvalidRequest)
+		case h.Source != SourceStock && h.Source != SourceCrypto:
+			return fmt.Errorf("%w: source must be stock or crypto", ErrInvalidRequest)
+		case h.Weight < 0 || h.Quantity < 0:
+			return fmt.Errorf("%w: weights and quantities cannot be negative", ErrInvalidRequest)
+		case seen[h.Symbol]:
+			return fmt.Errorf("%w: duplicate holding %s", ErrInvalidRequest, h.Symbol)
+		}
+		seen[h.Symbol] = true
+	}
+
+	r.Benchmark = strings.TrimSpace(r.Benchmark)
+	if r.BenchmarkSource == "" {
+		r.BenchmarkSource = SourceStock
+	}
+	if r.BenchmarkSource != SourceStock && r.BenchmarkSource != SourceCrypto {
+		return fmt.Errorf("%w: benchmark source must be stock or crypto", ErrInvalidRequest)
+	}
+	if r.Confidence == 0 {
+		r.Confidence = defaultConfidence
+	}
+	if r.Confidence <= 0.5 || r.Confidence >= 1 {
+		return fmt.Errorf("%w: confidence must be between 0.5 and 1", ErrInvalidRequest)
+	}
+	if r.Days == 0 {
+		r.Days = defaultLookbackDays
+	}
+	if r.Days < 2 || r.Days > maxLookbackDays {
+		return fmt.Errorf("%w: days must be between 2 and %d", ErrInvalidRequest, maxLookbackDays)
+	}
+	if r.PeriodsPerYear == 0 {
+		r.PeriodsPerYear = defaultPeriodsPerYear
+	}
+	if r.PeriodsPerYear < 1 {
+		return fmt.Errorf("%w: periods per year must be positive", ErrInvalidRequest)
+	}
+	return nil
+}
+
+// Analyze computes risk metrics from daily closes keyed by holding symbol (and benchmark).
+// Series are aligned on the dates common to all of them and trimmed to the last Days observations.
+// The portfolio is modelled as rebalanced to its weights every period.
+func Analyze(req Request, prices map[string][]portfolio.PricePoint) (*Report, error) {
+	req.Holdings = append([]Holding{}, req.Holdings...)
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, len(req.Holdings))
+	for i, h := range req.Holdings {
+		symbols[i] = h.Symbol
+	}
+	aligned := symbols
+	if req.Benchmark != "" {
+		aligned = append(append([]string{}, symbols...), req.Benchmark)
+	}
+
+	dates, closes, err := alignSeries(aligned, prices, req.Days+1)
+	if err != nil {
+		return nil, err
+	}
+
+	returns := make(map[string][]float64, len(aligned))
+	for _, symbol := range aligned {
+		returns[symbol] = Returns(closes[symbol])
+	}
+	weights := holdingWeights(req.Holdings, closes)
+
+	portfolioReturns := make([]float64, len(dates)-1)
+	for i, symbol := range symbols {
+		for t, r := range returns[symbol] {
+			portfolioReturns[t] += weights[i] * r
+		}
+	}
+	portfolioValues := make([]float64, len(dates))
+	portfolioValues[0] = 1
+	for t, r := range portfolioReturns {
+		portfolioValues[t+1] = portfolioValues[t] * (1 + r)
+	}
+
+	benchmarkReturns := returns[req.Benchmark]
+	report := &Report{
+		StartDate:    dates[0],
+		EndDate:      dates[len(dates)-1],
+		Observations: len(dates) - 1,
+		Confidence:   req.Confidence,
+		Benchmark:    req.Benchmark,
+		Holdings:     make([]Metrics, len(symbols)),
+		Correlation: CorrelationMatrix{
+			Symbols: symbols,
+			Values:  make([][]float64, len(symbols)),
+		},
+	}
+	for i, symbol := range symbols {
+		report.Holdings[i] = measure(symbol, weights[i], closes[symbol], returns[symbol], benchmarkReturns, req)
+		report.Correlation.Values[i] = make([]float64, len(symbols))
+		for j, other := range symbols {
+			report.Correlation.Values[i][j] = roundTo(Correlation(returns[symbol], returns[other]), 4)
+		}
+	}
+	report.Portfolio = measure("portfolio", 1, portfolioValues, portfolioReturns, benchmarkReturns, req)
+	return report, nil
+}
+
+// measure computes the metrics for one value series and its returns
+func measure(symbol string, weight float64, values, returns, benchmark []float64, req Request) Metrics {
+	drawdown, _, _ := MaxDrawdown(values)
+	growth := 0.0
+	if values[0] > 0 {
+		growth = math.Pow(values[len(values)-1]/values[0], req.PeriodsPerYear/float64(len(returns))) - 1
+	}
+	metrics := Metrics{
+		Symbol:           symbol,
+		Weight:           roundTo(weight, 4),
+		AnnualizedReturn: roundTo(growth, 6),
+		Volatility:       roundTo(Volatility(returns, req.PeriodsPerYear), 6),
+		SharpeRatio:      roundTo(SharpeRatio(returns, req.RiskFreeRate, req.PeriodsPerYear), 4),
+		SortinoRatio:     roundTo(SortinoRatio(returns, req.RiskFreeRate, req.PeriodsPerYear), 4),
+		MaxDrawdown:      roundTo(drawdown, 6),
+		HistoricalVaR:    roundTo(HistoricalVaR(returns, req.Confidence), 6),
+		ParametricVaR:    roundTo(ParametricVaR(returns, req.Confidence), 6),
+		ConditionalVaR:   roundTo(ConditionalVaR(returns, req.Confidence), 6),
+	}
+	if benchmark != nil {
+		metrics.Beta = roundTo(Beta(returns, benchmark), 4)
+	}
+	return metrics
+}
+
+// alignSeries returns the last limit dates shared by every symbol's series and the closes on those dates
+func alignSeries(symbols []string, prices map[string][]portfolio.PricePoint, limit int) ([]string, map[string][]float64, error) {
+	counts := make(map[string]int)
+	byDate := make(map[string]map[string]float64, len(symbols))
+	for _, symbol := range symbols {
+		points, ok := prices[symbol]
+		if !ok || len(points) == 0 {
+			return nil, nil, fmt.Errorf("%w: no prices for %s", ErrInvalidRequest, symbol)
+		}
+		byDate[symbol] = make(map[string]float64, len(points))
+		for _, point := range points {
+			if _, dup := byDate[symbol][point.Date]; !dup {
+				counts[point.Date]++
+			}
+			byDate[symbol][point.Date] = point.Close
+		}
+	}
+
+	var dates []string
+	for date, count := range counts {
+		if count == len(symbols) {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+	if len(dates) > limit {
+		dates = dates[len(dates)-limit:]
+	}
+	if len(dates) < 3 {
+		return nil, nil, fmt.Errorf("%w: not enough overlapping price history", ErrInvalidRequest)
+	}
+
+	closes := make(map[string][]float64, len(symbols))
+	for _, symbol := range symbols {
+		closes[symbol] = make([]float64, len(dates))
+		for i, date := range dates {
+			closes[symbol][i] = byDate[symbol][date]
+		}
+	}
+	return dates, closes, nil
+}
+
+// holdingWeights returns portfolio weights that sum to one, from market values at the latest close
+// when quantities are given, otherwise from the requested weights, falling back to equal weights
+func holdingWeights(holdings []Holding, closes map[string][]float64) []float64 {
+	weights := make([]float64, len(holdings))
+	total := 0.0
+	for i, h := range holdings {
+		if h.Quantity > 0 {
+			series := closes[h.Symbol]
+			weights[i] = h.Quantity * series[len(series)-1]
+		}
+		total += weights[i]
+	}
+	if total == 0 {
+		for i, h := range holdings {
+			weights[i] = h.Weight
+			total += h.Weight
+		}
+	}
+	for i := range weights {
+		if total == 0 {
+			weights[i] = 1 / float64(len(holdings))
+		} else {
+			weights[i] /= total
+		}
+	}
+	return weights
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,185 @@
+// Package risk measures the risk of individual holdings and portfolios from daily price series:
+// volatility, beta, Sharpe and Sortino ratios, maximum drawdown, Value at Risk, CVaR and correlations.
+//
+// Returns and rates are expressed as decimals (0.05 = 5%). Value at Risk and CVaR are one-period
+// losses reported as positive fractions of value.
+package risk
+
+import (
+	"math"
+	"sort"
+)
+
+// Returns converts a price series, oldest first, to simple periodic returns
+func Returns(prices []float64) []float64 {
+	if len(prices) < 2 {
+		return []float64{}
+	}
+	returns := make([]float64, 0, len(prices)-1)
+	for i := 1; i < len(prices); i++ {
+		if prices[i-1] == 0 {
+			returns = append(returns, 0)
+			continue
+		}
+		returns = append(returns, prices[i]/prices[i-1]-1)
+	}
+	return returns
+}
+
+// Mean returns the arithmetic mean of values
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// StdDev returns the sample standard deviation of values
+func StdDev(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := Mean(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+// Covariance returns the sample covariance of two equal-length series
+func Covariance(a, b []float64) float64 {
+	n := min(len(a), len(b))
+	if n < 2 {
+		return 0
+	}
+	meanA, meanB := Mean(a[:n]), Mean(b[:n])
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += (a[i] - meanA) * (b[i] - meanB)
+	}
+	return sum / float64(n-1)
+}
+
+// Correlation returns the Pearson correlation of two equal-length series
+func Correlation(a, b []float64) float64 {
+	denominator := StdDev(a) * StdDev(b)
+	if denominator == 0 {
+		return 0
+	}
+	return Covariance(a, b) / denominator
+}
+
+// Volatility returns the annualized standard deviation of periodic returns
+func Volatility(returns []float64, periodsPerYear float64) float64 {
+	return StdDev(returns) * math.Sqrt(periodsPerYear)
+}
+
+// Beta returns the sensitivity of asset returns to benchmark returns
+func Beta(asset, benchmark []float64) float64 {
+	variance := Covariance(benchmark, benchmark)
+	if variance == 0 {
+		return 0
+	}
+	return Covariance(asset, benchmark) / variance
+}
+
+// SharpeRatio returns the annualized excess return per unit of volatility
+func SharpeRatio(returns []float64, riskFreeRate, periodsPerYear float64) float64 {
+	deviation := StdDev(returns)
+	if deviation == 0 {
+		return 0
+	}
+	excess := Mean(returns) - riskFreeRate/periodsPerYear
+	return excess / deviation * math.Sqrt(periodsPerYear)
+}
+
+// SortinoRatio returns the annualized excess return per unit of downside deviation,
+// measuring only returns below the periodic risk-free rate
+func SortinoRatio(returns []float64, riskFreeRate, periodsPerYear float64) float64 {
+	if len(returns) == 0 {
+		return 0
+	}
+	target := riskFreeRate / periodsPerYear
+	sum := 0.0
+	for _, r := range returns {
+		if r < target {
+			sum += (r - target) * (r - target)
+		}
+	}
+	downside := math.Sqrt(sum / float64(len(returns)))
+	if downside == 0 {
+		return 0
+	}
+	return (Mean(returns) - target) / downside * math.Sqrt(periodsPerYear)
+}
+
+// MaxDrawdown returns the largest peak-to-trough decline of a value series as a positive fraction,
+// along with the indexes of the peak and the trough
+func MaxDrawdown(values []float64) (drawdown float64, peak, trough int) {
+	high := 0
+	for i, v := range values {
+		if v > values[high] {
+			high = i
+		}
+		if values[high] <= 0 {
+			continue
+		}
+		if dd := 1 - v/values[high]; dd > drawdown {
+			drawdown, peak, trough = dd, high, i
+		}
+	}
+	return drawdown, peak, trough
+}
+
+// HistoricalVaR returns the loss not exceeded with the given confidence, from the empirical return distribution
+func HistoricalVaR(returns []float64, confidence float64) float64 {
+	if len(returns) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, returns...)
+	sort.Float64s(sorted)
+	return math.Max(-percentile(sorted, 1-confidence), 0)
+}
+
+// ParametricVaR returns the loss not exceeded with the given confidence, assuming normally distributed returns
+func ParametricVaR(returns []float64, confidence float64) float64 {
+	if len(returns) < 2 {
+		return 0
+	}
+	z := math.Sqrt2 * math.Erfinv(2*(1-confidence)-1)
+	return math.Max(-(Mean(returns) + z*StdDev(returns)), 0)
+}
+
+// ConditionalVaR returns the expected loss on returns at or beyond the historical VaR (expected shortfall)
+func ConditionalVaR(returns []float64, confidence float64) float64 {
+	if len(returns) == 0 {
+		return 0
+	}
+	threshold := -HistoricalVaR(returns, confidence)
+	var tail []float64
+	for _, r := range returns {
+		if r <= threshold {
+			tail = append(tail, r)
+		}
+	}
+	if len(tail) == 0 {
+		return 0
+	}
+	return math.Max(-Mean(tail), 0)
+}
+
+// percentile returns the linearly interpolated p-th quantile (0 to 1) of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 1 {
+		return sorted[0]
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
@@ -0,0 +1,126 @@
+package risk
+
+import (
+	"math"
+	"testing"
+
+	"financehub/portfolio"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// evenReturns are -5% to +5% in one-point steps
+var evenReturns = []float64{-0.05, -0.04, -0.03, -0.02, -0.01, 0, 0.01, 0.02, 0.03, 0.04, 0.05}
+
+func TestReturnsAndDrawdown(t *testing.T) {
+	returns := Returns([]float64{100, 110, 99})
+	assert.InDeltaSlice(t, []float64{0.1, -0.1}, returns, 1e-12)
+
+	drawdown, peak, trough := MaxDrawdown([]float64{100, 120, 90, 130, 104})
+	assert.InDelta(t, 0.25, drawdown, 1e-12)
+	assert.Equal(t, 1, peak)
+	assert.Equal(t, 2, trough)
+}
+
+func TestValueAtRisk(t *testing.T) {
+	assert.InDelta(t, 0.04, HistoricalVaR(evenReturns, 0.9), 1e-12)
+	assert.InDelta(t, 0.045, ConditionalVaR(evenReturns, 0.9), 1e-12)
+	assert.InDelta(t, 0.054554, ParametricVaR(evenReturns, 0.95), 1e-6)
+	assert.Equal(t, 0.0, HistoricalVaR([]float64{0.01, 0.02}, 0.95))
+}
+
+func TestRatios(t *testing.T) {
+	benchmark := []float64{0.01, -0.02, 0.015, 0.005, -0.01}
+	asset := make([]float64, len(benchmark))
+	for i, r := range benchmark {
+		asset[i] = 2 * r
+	}
+
+	assert.InDelta(t, 2.0, Beta(asset, benchmark), 1e-12)
+	assert.InDelta(t, 1.0, Correlation(asset, benchmark), 1e-12)
+	assert.InDelta(t, StdDev(benchmark)*math.Sqrt(252), Volatility(benchmark, 252), 1e-12)
+	assert.InDelta(t, Mean(benchmark)/StdDev(benchmark)*math.Sqrt(252), SharpeRatio(benchmark, 0, 252), 1e-12)
+
+	// Downside deviation only counts the two losing periods
+	downside := math.Sqrt((0.02*0.02 + 0.01*0.01) / 5)
+	assert.InDelta(t, Mean(benchmark)/downside*math.Sqrt(252), SortinoRatio(benchmark, 0, 252), 1e-12)
+	assert.Equal(t, 0.0, SortinoRatio([]float64{0.01, 0.02}, 0, 252))
+}
+
+func TestAnalyze(t *testing.T) {
+	prices := map[string][]portfolio.PricePoint{
+		"AAA": {
+			{Date: "2024-01-02", Close: 100}, {Date: "2024-01-03", Close: 110},
+			{Date: "2024-01-04", Close: 99}, {Date: "2024-01-05", Close: 108.9},
+		},
+		// A crypto series also trades on the weekend, which is dropped when aligning
+		"bitcoin": {
+			{Date: "2024-01-02", Close: 40000}, {Date: "2024-01-03", Close: 38000},
+			{Date: "2024-01-04", Close: 41800}, {Date: "2024-01-05", Close: 39710},
+			{Date: "2024-01-06", Close: 40000},
+		},
+		"SPY": {
+			{Date: "2024-01-02", Close: 400}, {Date: "2024-01-03", Close: 404},
+			{Date: "2024-01-04", Close: 399.96}, {Date: "2024-01-05", Close: 403.9596},
+		},
+	}
+	req := Request{
+		Holdings: []Holding{
+			{Symbol: "AAA", Weight: 3},
+			{Symbol: "bitcoin", Source: SourceCrypto, Weight: 3},
+		},
+		Benchmark: "SPY",
+	}
+
+	report, err := Analyze(req, prices)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-02", report.StartDate)
+	assert.Equal(t, "2024-01-05", report.EndDate)
+	assert.Equal(t, 3, report.Observations)
+	assert.Equal(t, 0.95, report.Confidence)
+	assert.Equal(t, "", req.Holdings[0].Source)
+
+	aaa := report.Holdings[0]
+	assert.Equal(t, 0.5, aaa.Weight)
+	assert.Equal(t, 10.0, aaa.Beta)
+	assert.Equal(t, 0.1, aaa.MaxDrawdown)
+	assert.Equal(t, -1.0, report.Correlation.Values[0][1])
+	assert.Equal(t, 1.0, report.Correlation.Values[1][1])
+
+	// AAA moves +10%/-10%/+10% and bitcoin -5%/+10%/-5%, so the portfolio gains 2.5%, 0%, 2.5%
+	assert.Equal(t, 0.0, report.Portfolio.MaxDrawdown)
+	assert.Equal(t, 0.0, report.Portfolio.HistoricalVaR)
+	assert.Equal(t, 1.25, report.Portfolio.Beta)
+
+	// Quantities take precedence over weights, valued at the latest close
+	req.Holdings[0].Quantity = 10
+	req.Holdings[1].Quantity = 1089.0 / 39710
+	report, err = Analyze(req, prices)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, report.Holdings[1].Weight)
+}
+
+func TestAnalyzeValidation(t *testing.T) {
+	prices := map[string][]portfolio.PricePoint{
+		"AAA": {{Date: "2024-01-02", Close: 100}, {Date: "2024-01-03", Close: 110}, {Date: "2024-01-04", Close: 99}},
+	}
+	tests := []struct {
+		name string
+		req  Request
+	}{
+		{"no holdings", Request{}},
+		{"bad source", Request{Holdings: []Holding{{Symbol: "AAA", Source: "bond"}}}},
+		{"duplicate", Request{Holdings: []Holding{{Symbol: "AAA"}, {Symbol: "AAA"}}}},
+		{"bad confidence", Request{Holdings: []Holding{{Symbol: "AAA"}}, Confidence: 1.5}},
+		{"missing prices", Request{Holdings: []Holding{{Symbol: "AAA"}, {Symbol: "BBB"}}}},
+		{"short history", Request{Holdings: []Holding{{Symbol: "AAA"}}, Days: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Analyze(tt.req, prices)
+			assert.ErrorIs(t, err, ErrInvalidRequest)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext(t *testing.T) {
+	// Monday 2024-01-01 10:07:30
+	start := time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 1, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)},
+		{"5 * * * *", time.Date(2024, 1, 1, 11, 5, 0, 0, time.UTC)},
+		{"0,30 9-16 * * 1-5", time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)},
+		{"30 22 * * 1-5", time.Date(2024, 1, 1, 22, 30, 0, 0, time.UTC)},
+		{"0 3 * * 6,7", time.Date(2024, 1, 6, 3, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"10-20/5 8 * * *", time.Date(2024, 1, 2, 8, 10, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", time.Date(2024, 1, 1, 10, 9, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.spec)
+		assert.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.want, s.Next(start), tt.spec)
+		assert.Equal(t, tt.spec, s.String())
+	}
+}
+
+func TestNextDays(t *testing.T) {
+	// Restricting both day fields matches either, so the 15th or any Friday
+	s, err := Parse("0 12 15 * 5")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC), s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC), s.Next(time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC)))
+
+	// Dates that never occur have no next time
+	s, err = Parse("0 0 30 2 *")
+	assert.NoError(t, err)
+	assert.True(t, s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+
+	// Schedules are matched in the location of the time given
+	newYork, err := time.LoadLocation("America/New_York")
+	if err == nil {
+		s, _ = Parse("30 9 * * *")
+		next := s.Next(time.Date(2024, 3, 9, 12, 0, 0, 0, newYork))
+		assert.Equal(t, time.Date(2024, 3, 10, 9, 30, 0, 0, newYork), next)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	specs := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every soon",
+		"@every -1m",
+		"@fortnightly",
+	}
+	for _, spec := range specs {
+		_, err := Parse(spec)
+		assert.ErrorIs(t, err, ErrInvalidSchedule, spec)
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startServer serves handler on a free port until the returned context cancel is called
+func startServer(t *testing.T, handler http.Handler, timeout time.Duration) (string, context.CancelFunc, chan error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- ServeListener(ctx, &http.Server{Handler: handler}, ln, timeout)
+	}()
+	return "http://" + ln.Addr().String(), cancel, done
+}
+
+func TestServeListenerDrainsRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	url, cancel, done := startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		io.WriteString(w, "finished")
+	}), time.Second)
+
+	response := make(chan string, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			response <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		response <- string(body)
+	}()
+
+	<-started
+	cancel()
+	// New connections are refused while the request in flight is drained
+	assert.Eventually(t, func() bool {
+		_, err := net.Dial("tcp", url[len("http://"):])
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	assert.Equal(t, "finished", <-response)
+	assert.NoError(t, <-done)
+}
+
+func TestServeListenerDeadline(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	url, cancel, done := startServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+		close(cancelled)
+	}), 50*time.Millisecond)
+
+	go http.Get(url)
+	<-started
+	cancel()
+
+	assert.ErrorContains(t, <-done, "failed to drain requests")
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("request context was not cancelled at the deadline")
+	}
+}
+
+func TestShutdownTimeoutFromEnv(t *testing.T) {
+	t.Setenv("SHUTDOWN_TIMEOUT", "")
+	timeout, err := ShutdownTimeoutFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultShutdownTimeout, timeout)
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "30s")
+	timeout, err = ShutdownTimeoutFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, timeout)
+
+	t.Setenv("SHUTDOWN_TIMEOUT", "soon")
+	timeout, err = ShutdownTimeoutFromEnv()
+	assert.Error(t, err)
+	assert.Equal(t, DefaultShutdownTimeout, timeout)
+}
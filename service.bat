@@ -8,7 +8,7 @@ echo ======================================
 echo.
 
 if "%1"=="" (
-    echo Usage: service.bat [install^|uninstall^|start^|stop^|restart]
+    echo Usage: service.bat [install^|uninstall^|start^|stop^|restart^|status^|logs^|config] [options]
     echo.
     echo Commands:
     echo   install   - Install FinanceHub as a Windows Service
@@ -16,6 +16,14 @@ if "%1"=="" (
     echo   start     - Start the FinanceHub service
     echo   stop      - Stop the FinanceHub service
     echo   restart   - Restart the FinanceHub service
+    echo   status    - Show whether the FinanceHub service is running
+    echo   logs      - Show the service log
+    echo   config    - Show the service configuration
+    echo.
+    echo Options:
+    echo   -config PATH  - Settings file read when the service runs ^(install^)
+    echo   -port N       - Also serve the REST API on port N ^(install^)
+    echo   -tail N       - Number of log lines to show ^(logs^)
     echo.
     pause
     exit /b 1
@@ -43,7 +51,7 @@ if not exist "%SERVICE_EXE%" (
 
 if "%1"=="install" (
     echo Installing FinanceHub as Windows Service...
-    "%SERVICE_EXE%" install
+    "%SERVICE_EXE%" service install %2 %3 %4 %5
     if %errorlevel% equ 0 (
         echo.
         echo ✓ Service installed successfully!
@@ -56,7 +64,7 @@ if "%1"=="install" (
 
 if "%1"=="uninstall" (
     echo Uninstalling FinanceHub Windows Service...
-    "%SERVICE_EXE%" uninstall
+    "%SERVICE_EXE%" service uninstall
     if %errorlevel% equ 0 (
         echo.
         echo ✓ Service uninstalled successfully!
@@ -68,7 +76,7 @@ if "%1"=="uninstall" (
 
 if "%1"=="start" (
     echo Starting FinanceHub service...
-    "%SERVICE_EXE%" start
+    "%SERVICE_EXE%" service start
     if %errorlevel% equ 0 (
         echo.
         echo ✓ Service started successfully!
@@ -80,7 +88,7 @@ if "%1"=="start" (
 
 if "%1"=="stop" (
     echo Stopping FinanceHub service...
-    "%SERVICE_EXE%" stop
+    "%SERVICE_EXE%" service stop
     if %errorlevel% equ 0 (
         echo.
         echo ✓ Service stopped successfully!
@@ -92,7 +100,7 @@ if "%1"=="stop" (
 
 if "%1"=="restart" (
     echo Restarting FinanceHub service...
-    "%SERVICE_EXE%" restart
+    "%SERVICE_EXE%" service restart
     if %errorlevel% equ 0 (
         echo.
         echo ✓ Service restarted successfully!
@@ -102,5 +110,17 @@ if "%1"=="restart" (
     )
 )
 
+if "%1"=="status" (
+    "%SERVICE_EXE%" service status
+)
+
+if "%1"=="logs" (
+    "%SERVICE_EXE%" service logs %2 %3
+)
+
+if "%1"=="config" (
+    "%SERVICE_EXE%" service config %2
+)
+
 echo.
 pause
@@ -0,0 +1,404 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"financehub/handlers"
+	"financehub/logging"
+	"financehub/server"
+	"financehub/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kardianos/service"
+)
+
+// serviceOptions select how the service is installed and run
+type serviceOptions struct {
+	// User installs a service for the current user instead of a system-wide one (systemd and launchd)
+	User bool
+	// Config is a file of KEY=VALUE settings loaded into the environment when the service runs
+	Config string
+	// Port serves the REST API from the service when it is not zero
+	Port int
+}
+
+// Program structures.
+type program struct {
+	logger service.Logger
+	logDir string
+	log    *slog.Logger
+	jobs   *services.JobScheduler
+	market *services.MarketOverviewService
+	server *http.Server
+	// listener is bound by Start, so that a port in use fails the start
+	listener net.Listener
+	// exit ends the process when the API server fails, so that the service manager sees the
+	// service fail rather than keep running without its API; os.Exit when nil
+	exit func(code int)
+
+	// shutdownTimeout is how long Stop waits for requests in flight and running jobs
+	shutdownTimeout time.Duration
+	cancel          context.CancelFunc
+	done            chan struct{}
+}
+
+// Start initializes and starts the FinanceHub service
+func (p *program) Start(s service.Service) error {
+	if p.server != nil {
+		ln, err := net.Listen("tcp", p.server.Addr)
+		if err != nil {
+			return fmt.Errorf("failed to start API server: %w", err)
+		}
+		p.listener = ln
+	}
+
+	// Start should not block. Do the actual work async.
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.run(ctx)
+	return nil
+}
+
+// run executes the main service logic until the context is cancelled
+func (p *program) run(ctx context.Context) {
+	defer close(p.done)
+	p.logger.Info("FinanceHub service is running")
+
+	// Log service start event
+	p.logEvent("Service started successfully")
+
+	// Synchronize quotes, price history and exchange rates in the background
+	p.jobs.Start(ctx)
+	for _, job := range p.jobs.List() {
+		p.logger.Infof("Scheduled job %s (%s), next run %s", job.Name, job.Schedule, job.NextRun)
+	}
+
+	if p.server != nil {
+		if p.market != nil {
+			p.market.Start(ctx, 15*time.Minute)
+		}
+		p.logger.Infof("Finance Hub API server starting on %s", p.listener.Addr())
+		// Serve returns once the requests in flight are drained after a stop
+		if err := server.ServeListener(ctx, p.server, p.listener, p.shutdownTimeout); err != nil {
+			p.logger.Errorf("API server failed: %v", err)
+			p.log.Error("Service error", "error", err)
+			if ctx.Err() == nil {
+				// The server failed on its own, so the service stops instead of running headless
+				p.stopBackground()
+				p.log.Error("Service stopped after its API server failed")
+				p.exitProcess(exitFailure)
+				return
+			}
+		}
+	}
+	<-ctx.Done()
+	p.stopBackground()
+}
+
+// stopBackground stops refreshing the market overview and scheduling jobs, waiting for jobs in
+// progress
+func (p *program) stopBackground() {
+	if p.market != nil {
+		p.market.Stop()
+	}
+	p.jobs.Stop()
+}
+
+func (p *program) exitProcess(code int) {
+	if p.exit != nil {
+		p.exit(code)
+		return
+	}
+	os.Exit(code)
+}
+
+// Stop terminates the service gracefully: the API stops accepting connections and the requests
+// in flight and running jobs are given the shutdown timeout to finish
+func (p *program) Stop(s service.Service) error {
+	p.logger.Info("FinanceHub service is stopping")
+	p.cancel()
+
+	// Service managers expect Stop to return, so it does not wait past the deadline
+	select {
+	case <-p.done:
+		p.logEvent("Service stopped gracefully")
+	case <-time.After(p.shutdownTimeout + 5*time.Second):
+		p.log.Warn("Service stopped before its background work finished", "timeout", p.shutdownTimeout.String())
+	}
+	return nil
+}
+
+// setup prepares the background jobs and, when a port is given, the REST API server. The API's
+// handlers run the jobs so that /api/jobs reports them.
+func (p *program) setup(port int) {
+	timeout, err := server.ShutdownTimeoutFromEnv()
+	if err != nil {
+		p.log.Warn("Using the default shutdown timeout", "error", err)
+	}
+	p.shutdownTimeout = timeout
+
+	if port == 0 {
+		p.jobs = newJobScheduler()
+		return
+	}
+
+	h := handlers.NewHandler()
+	router := gin.New()
+	router.Use(gin.Recovery(), logging.Middleware(p.log))
+	h.RegisterRoutes(router)
+
+	p.jobs = h.Jobs
+	p.market = h.Market
+	p.server = &http.Server{
+		Addr:              ":" + strconv.Itoa(port),
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// newJobScheduler schedules the data synchronization jobs, keeping their run history in the
+// user's config directory. The watchlist and currency pairs are read from SYNC_WATCHLIST and
+// SYNC_CURRENCIES.
+func newJobScheduler() *services.JobScheduler {
+	path := ""
+	if configDir, err := os.UserConfigDir(); err == nil {
+		path = filepath.Join(configDir, "FinanceHub", "jobs.json")
+	}
+
+	jobs, err := services.NewJobScheduler(path)
+	if err != nil {
+		slog.Warn("Job data unavailable, run history will not be saved", "error", err)
+		jobs, _ = services.NewJobScheduler("")
+	}
+
+	dataSync := services.NewSyncService(services.NewAlphaVantageService(), newTimeSeriesStore(), newPaperTradingService())
+	dataSync.Watchlist = services.ParseList(os.Getenv("SYNC_WATCHLIST"))
+	if currencies := os.Getenv("SYNC_CURRENCIES"); currencies != "" {
+		dataSync.Currencies = services.ParseList(currencies)
+	}
+	if err := dataSync.Register(jobs); err != nil {
+		slog.Warn("Background jobs unavailable", "error", err)
+	}
+	return jobs
+}
+
+// logEvent writes service lifecycle events to the service log
+func (p *program) logEvent(message string) {
+	if p.log != nil {
+		p.log.Info(message)
+		return
+	}
+	logServiceEvent(p.logDir, message)
+}
+
+// serviceLogFile is the name of the service log
+const serviceLogFile = "financehub_service.log"
+
+// newServiceLogger opens the service log in logDir, rotated and formatted as configured by the
+// LOG_* settings. LOG_FILE does not apply, the service always logs to its log directory.
+func newServiceLogger(logDir string) (*slog.Logger, io.Closer, error) {
+	cfg, err := logging.ConfigFromEnv()
+	cfg.File = filepath.Join(logDir, serviceLogFile)
+	logger, closer, openErr := logging.New(cfg)
+	if openErr != nil {
+		return nil, nil, openErr
+	}
+	if err != nil {
+		logger.Warn("Using default logging settings", "error", err)
+	}
+	return logger, closer, nil
+}
+
+// logServiceEvent appends a service lifecycle event to the service log in logDir
+func logServiceEvent(logDir, message string) {
+	logger, closer, err := newServiceLogger(logDir)
+	if err != nil {
+		return
+	}
+	defer closer.Close()
+	logger.Info(message)
+}
+
+// serviceName returns the name the platform's service manager knows the service by
+func serviceName(goos string) string {
+	switch goos {
+	case "windows":
+		return "FinanceHubService"
+	case "darwin":
+		return "com.financehub.service"
+	default:
+		return "financehub"
+	}
+}
+
+// serviceLogDir returns where the service writes its logs: next to the executable on Windows,
+// ~/Library/Logs or /Library/Logs on macOS, and the XDG state directory or /var/log elsewhere
+func serviceLogDir(goos string, user bool) string {
+	home, _ := os.UserHomeDir()
+	switch {
+	case goos == "windows":
+		if exePath, err := os.Executable(); err == nil {
+			return filepath.Join(filepath.Dir(exePath), "logs")
+		}
+		return "logs"
+	case goos == "darwin" && user:
+		return filepath.Join(home, "Library", "Logs", "FinanceHub")
+	case goos == "darwin":
+		return "/Library/Logs/FinanceHub"
+	case user:
+		stateDir := os.Getenv("XDG_STATE_HOME")
+		if stateDir == "" {
+			stateDir = filepath.Join(home, ".local", "state")
+		}
+		return filepath.Join(stateDir, "financehub", "logs")
+	default:
+		return "/var/log/financehub"
+	}
+}
+
+// serviceDataDir returns the working directory of the service, where the API keeps its data
+// files: the user's config directory for user services, and a system data directory otherwise
+func serviceDataDir(goos string, user bool) string {
+	if user {
+		if configDir, err := os.UserConfigDir(); err == nil {
+			return filepath.Join(configDir, "FinanceHub")
+		}
+	}
+	switch goos {
+	case "windows":
+		programData := os.Getenv("ProgramData")
+		if programData == "" {
+			programData = `C:\ProgramData`
+		}
+		return filepath.Join(programData, "FinanceHub")
+	case "darwin":
+		return "/Library/Application Support/FinanceHub"
+	default:
+		return "/var/lib/financehub"
+	}
+}
+
+// defaultConfigFile returns the settings file read by the service when none is given: in the
+// user's config directory for user services, and in the system configuration directory otherwise
+func defaultConfigFile(goos string, user bool) string {
+	if goos != "windows" && goos != "darwin" && !user {
+		return "/etc/financehub/service.env"
+	}
+	return filepath.Join(serviceDataDir(goos, user), "service.env")
+}
+
+// newServiceConfig describes the service to the platform's service manager. The service is
+// started with "service run" and the options it was installed with.
+func newServiceConfig(opts serviceOptions, goos string) *service.Config {
+	args := []string{"service", "run"}
+	if opts.User {
+		args = append(args, "-user")
+	}
+	if opts.Config != "" {
+		args = append(args, "-config", opts.Config)
+	}
+	if opts.Port != 0 {
+		args = append(args, "-port", strconv.Itoa(opts.Port))
+	}
+
+	config := &service.Config{
+		Name:        serviceName(goos),
+		DisplayName: "FinanceHub Service",
+		Description: "FinanceHub - Finance Learning & Insights Desktop Application Service. Provides background data synchronization and system integration.",
+		Arguments:   args,
+	}
+
+	switch goos {
+	case "windows":
+		config.Option = service.KeyValue{
+			"StartType": "automatic",
+		}
+	case "darwin":
+		config.Option = service.KeyValue{
+			"UserService":  opts.User,
+			"RunAtLoad":    true,
+			"KeepAlive":    true,
+			"LogOutput":    true,
+			"LogDirectory": serviceLogDir(goos, opts.User),
+		}
+	default:
+		config.Option = service.KeyValue{
+			"UserService":  opts.User,
+			"Restart":      "on-failure",
+			"LogOutput":    true,
+			"LogDirectory": serviceLogDir(goos, opts.User),
+		}
+		config.Dependencies = []string{"After=network-online.target", "Wants=network-online.target"}
+	}
+	return config
+}
+
+// setting is a KEY=VALUE line of a settings file
+type setting struct {
+	Key   string
+	Value string
+}
+
+// readConfigFile reads the KEY=VALUE lines of a settings file. Blank lines, comments and an
+// "export " prefix are allowed, and values may be quoted.
+func readConfigFile(path string) ([]setting, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var settings []setting
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid config file %s line %d: expected KEY=VALUE", path, line)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		settings = append(settings, setting{Key: key, Value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return settings, nil
+}
+
+// loadConfigFile sets the settings of a config file that are not already set in the
+// environment. A missing file is ignored.
+func loadConfigFile(path string) error {
+	settings, err := readConfigFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, s := range settings {
+		if _, set := os.LookupEnv(s.Key); !set {
+			os.Setenv(s.Key, s.Value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+#!/bin/sh
+# FinanceHub Service Management Script for Linux (systemd) and macOS (launchd)
+# This script manages the FinanceHub background service
+
+set -e
+
+echo "======================================"
+echo "FinanceHub - Service Manager"
+echo "======================================"
+echo
+
+usage() {
+    echo "Usage: ./service.sh [install|uninstall|start|stop|restart|status|logs|config] [--user] [options]"
+    echo
+    echo "Commands:"
+    echo "  install   - Install FinanceHub as a background service"
+    echo "  uninstall - Remove the FinanceHub service"
+    echo "  start     - Start the FinanceHub service"
+    echo "  stop      - Stop the FinanceHub service"
+    echo "  restart   - Restart the FinanceHub service"
+    echo "  status    - Show whether the FinanceHub service is running"
+    echo "  logs      - Show the service log (-tail N, -follow)"
+    echo "  config    - Show the service configuration (config show|path)"
+    echo
+    echo "Options:"
+    echo "  --user          - Use a service for the current user (systemd --user or a LaunchAgent)"
+    echo "                    instead of a system-wide service, which needs root"
+    echo "  -config PATH    - Settings file read when the service runs"
+    echo "  -port N         - Also serve the REST API on port N"
+    echo
+    echo "Run \"$0 help\" for all options and exit codes."
+    exit 2
+}
+
+if [ $# -eq 0 ]; then
+    usage
+fi
+
+case "$1" in
+    install|uninstall|start|stop|restart|status|logs|config|help) ;;
+    *) usage ;;
+esac
+
+if [ "$(uname)" = "Darwin" ]; then
+    SERVICE_EXE="build/bin/FinanceHub.app/Contents/MacOS/FinanceHub"
+else
+    SERVICE_EXE="build/bin/FinanceHub"
+fi
+
+if [ ! -x "$SERVICE_EXE" ]; then
+    echo "ERROR: FinanceHub not found at $SERVICE_EXE"
+    echo "Please build the application first using wails build"
+    exit 1
+fi
+
+# System-wide services are managed by root
+USER_SERVICE=false
+for arg in "$@"; do
+    case "$arg" in
+        -user|--user) USER_SERVICE=true ;;
+    esac
+done
+if [ "$USER_SERVICE" = false ] && [ "$1" != "status" ] && [ "$1" != "logs" ] && [ "$1" != "config" ] && [ "$1" != "help" ] && [ "$(id -u)" -ne 0 ]; then
+    echo "ERROR: Managing the system service requires root privileges!"
+    echo "Run with sudo, or pass --user for a service of the current user."
+    exit 1
+fi
+
+"$SERVICE_EXE" service "$@"
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/kardianos/service"
+)
+
+// Exit codes of the service commands. status exits with exitNotRunning or exitNotInstalled so
+// that scripts can check the service without parsing its output.
+const (
+	exitOK           = 0
+	exitFailure      = 1
+	exitUsage        = 2
+	exitNotRunning   = 3
+	exitNotInstalled = 4
+)
+
+// serviceUsage is the help text of the service commands
+const serviceUsage = `Usage: financehub service <command> [options]
+
+Commands:
+  install      Install FinanceHub as a background service
+  uninstall    Remove the FinanceHub service
+  start        Start the installed service
+  stop         Stop the running service
+  restart      Restart the service
+  status       Show whether the service is installed and running
+  logs         Show the service log
+  config show  Show the service configuration and settings
+  config path  Print the path of the service settings file
+  run          Run the service in the foreground, as the service manager does
+  help         Show this help
+
+Options:
+  -user         Use a service for the current user (systemd --user or a LaunchAgent)
+                instead of a system-wide one
+  -config PATH  Settings file loaded when the service runs (install, run, config)
+  -port N       Also serve the REST API on port N (install, run)
+  -tail N       Number of log lines to show (logs, default 50)
+  -follow       Keep showing new log lines until interrupted (logs)
+
+Exit codes:
+  0  success
+  1  the command failed
+  2  invalid command or options
+  3  the service is not running (status)
+  4  the service is not installed (status)
+`
+
+// serviceCommands are the commands of the service CLI
+var serviceCommands = map[string]bool{
+	"install":   true,
+	"uninstall": true,
+	"start":     true,
+	"stop":      true,
+	"restart":   true,
+	"status":    true,
+	"logs":      true,
+	"config":    true,
+	"run":       true,
+}
+
+// isServiceCommand reports whether a command-line argument is a service command. The commands
+// are also accepted without the "service" prefix, as earlier versions installed them.
+func isServiceCommand(arg string) bool {
+	return serviceCommands[arg]
+}
+
+// serviceCLIOptions are the options of a service command
+type serviceCLIOptions struct {
+	serviceOptions
+	Tail   int
+	Follow bool
+}
+
+// runServiceCLI runs a service command and returns the process exit code
+func runServiceCLI(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, serviceUsage)
+		return exitUsage
+	}
+	command := args[0]
+	switch command {
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, serviceUsage)
+		return exitOK
+	}
+	if !isServiceCommand(command) {
+		fmt.Fprintf(stderr, "unknown service command %q\n\n%s", command, serviceUsage)
+		return exitUsage
+	}
+
+	args = args[1:]
+	subcommand := ""
+	if command == "config" {
+		if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+			subcommand = "show"
+		} else {
+			subcommand, args = args[0], args[1:]
+		}
+		if subcommand != "show" && subcommand != "path" {
+			fmt.Fprintf(stderr, "unknown config command %q, expected show or path\n", subcommand)
+			return exitUsage
+		}
+	}
+
+	opts, err := parseServiceFlags(command, args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprint(stdout, serviceUsage)
+		return exitOK
+	}
+	if err != nil {
+		return exitUsage
+	}
+	if opts.Config == "" {
+		opts.Config = defaultConfigFile(runtime.GOOS, opts.User)
+	}
+
+	switch command {
+	case "logs":
+		return runServiceLogs(opts, stdout, stderr)
+	case "config":
+		return runServiceConfig(subcommand, opts, stdout, stderr)
+	}
+
+	prg := &program{logDir: serviceLogDir(runtime.GOOS, opts.User)}
+	s, err := service.New(prg, newServiceConfig(opts.serviceOptions, runtime.GOOS))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	logger, err := s.Logger(nil)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+	prg.logger = logger
+
+	name := serviceName(runtime.GOOS)
+	switch command {
+	case "install":
+		if err := os.MkdirAll(prg.logDir, 0755); err != nil {
+			fmt.Fprintf(stderr, "failed to create log directory: %v\n", err)
+			return exitFailure
+		}
+		if err := s.Install(); err != nil {
+			fmt.Fprintf(stderr, "failed to install service: %v\n", err)
+			return exitFailure
+		}
+		prg.logEvent("Service installed")
+		fmt.Fprintf(stdout, "Service %s installed\n", name)
+		fmt.Fprintf(stdout, "Config file: %s\n", opts.Config)
+		if opts.Port != 0 {
+			fmt.Fprintf(stdout, "API port: %d\n", opts.Port)
+		}
+		fmt.Fprintf(stdout, "Logs: %s\n", prg.logDir)
+		return exitOK
+	case "uninstall":
+		if err := s.Uninstall(); err != nil {
+			fmt.Fprintf(stderr, "failed to uninstall service: %v\n", err)
+			return exitFailure
+		}
+		prg.logEvent("Service uninstalled")
+		fmt.Fprintf(stdout, "Service %s uninstalled\n", name)
+		return exitOK
+	case "status":
+		status, err := s.Status()
+		switch {
+		case errors.Is(err, service.ErrNotInstalled):
+			fmt.Fprintf(stdout, "Service %s is not installed\n", name)
+			return exitNotInstalled
+		case err != nil:
+			fmt.Fprintf(stderr, "failed to get service status: %v\n", err)
+			return exitFailure
+		case status == service.StatusRunning:
+			fmt.Fprintf(stdout, "Service %s is running\n", name)
+			return exitOK
+		case status == service.StatusStopped:
+			fmt.Fprintf(stdout, "Service %s is stopped\n", name)
+			return exitNotRunning
+		default:
+			fmt.Fprintf(stdout, "Service %s is in an unknown state\n", name)
+			return exitFailure
+		}
+	case "run":
+		if err := loadConfigFile(opts.Config); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+		if opts.Port != 0 {
+			// The API keeps its data files relative to the working directory
+			dataDir := serviceDataDir(runtime.GOOS, opts.User)
+			if err := os.MkdirAll(dataDir, 0755); err != nil {
+				fmt.Fprintf(stderr, "failed to create data directory: %v\n", err)
+				return exitFailure
+			}
+			if err := os.Chdir(dataDir); err != nil {
+				fmt.Fprintf(stderr, "failed to use data directory: %v\n", err)
+				return exitFailure
+			}
+		}
+		// Everything the service and its API log goes to the service log
+		logger, closer, err := newServiceLogger(prg.logDir)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+		defer closer.Close()
+		prg.log = logger
+		slog.SetDefault(logger)
+
+		prg.setup(opts.Port)
+		if err := s.Run(); err != nil {
+			logger.Error("Service error", "error", err)
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+		return exitOK
+	default:
+		if err := service.Control(s, command); err != nil {
+			fmt.Fprintln(stderr, err)
+			return exitFailure
+		}
+		fmt.Fprintf(stdout, "Service %s %s\n", name, pastTense(command))
+		return exitOK
+	}
+}
+
+// parseServiceFlags parses the options of a service command. Each command accepts only the
+// options that apply to it.
+func parseServiceFlags(command string, args []string, stderr io.Writer) (serviceCLIOptions, error) {
+	var opts serviceCLIOptions
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {}
+	flags.BoolVar(&opts.User, "user", false, "use a service for the current user instead of a system-wide service")
+	switch command {
+	case "install", "run", "config":
+		flags.StringVar(&opts.Config, "config", "", "settings file loaded when the service runs")
+		flags.StringVar(&opts.Config, "env-file", "", "alias of -config")
+	}
+	switch command {
+	case "install", "run":
+		flags.IntVar(&opts.Port, "port", 0, "also serve the REST API on this port")
+	case "logs":
+		flags.IntVar(&opts.Tail, "tail", 50, "number of log lines to show")
+		flags.BoolVar(&opts.Follow, "follow", false, "keep showing new log lines")
+		flags.BoolVar(&opts.Follow, "f", false, "alias of -follow")
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return opts, err
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected argument %q\n", flags.Arg(0))
+		return opts, fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	if opts.Port < 0 || opts.Port > 65535 {
+		fmt.Fprintf(stderr, "invalid port %d\n", opts.Port)
+		return opts, fmt.Errorf("invalid port %d", opts.Port)
+	}
+	if opts.Tail < 0 {
+		fmt.Fprintf(stderr, "invalid -tail %d\n", opts.Tail)
+		return opts, fmt.Errorf("invalid -tail %d", opts.Tail)
+	}
+	return opts, nil
+}
+
+// runServiceConfig prints where the service keeps its settings, logs and data, and the settings
+// of its config file with secrets masked
+func runServiceConfig(subcommand string, opts serviceCLIOptions, stdout, stderr io.Writer) int {
+	if subcommand == "path" {
+		fmt.Fprintln(stdout, opts.Config)
+		return exitOK
+	}
+
+	settings, err := readConfigFile(opts.Config)
+	configState := opts.Config
+	if errors.Is(err, os.ErrNotExist) {
+		configState += " (missing)"
+	} else if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitFailure
+	}
+
+	fmt.Fprintf(stdout, "Service:     %s\n", serviceName(runtime.GOOS))
+	fmt.Fprintf(stdout, "Config file: %s\n", configState)
+	fmt.Fprintf(stdout, "Log dir:     %s\n", serviceLogDir(runtime.GOOS, opts.User))
+	fmt.Fprintf(stdout, "Data dir:    %s\n", serviceDataDir(runtime.GOOS, opts.User))
+	if len(settings) > 0 {
+		fmt.Fprintln(stdout, "\nSettings:")
+		for _, s := range settings {
+			fmt.Fprintf(stdout, "  %s=%s\n", s.Key, maskSetting(s))
+		}
+	}
+	return exitOK
+}
+
+// maskSetting hides the value of settings holding API keys, tokens and passwords
+func maskSetting(s setting) string {
+	key := strings.ToUpper(s.Key)
+	for _, secret := range []string{"KEY", "SECRET", "TOKEN", "PASSWORD"} {
+		if strings.Contains(key, secret) && s.Value != "" {
+			return "********"
+		}
+	}
+	return s.Value
+}
+
+// runServiceLogs prints the last lines of the service log and, with -follow, the lines written
+// after them until interrupted
+func runServiceLogs(opts serviceCLIOptions, stdout, stderr io.Writer) int {
+	logFile := filepath.Join(serviceLogDir(runtime.GOOS, opts.User), serviceLogFile)
+	content, err := os.ReadFile(logFile)
+	if errors.Is(err, os.ErrNotExist) && !opts.Follow {
+		fmt.Fprintf(stderr, "no service log at %s\n", logFile)
+		return exitFailure
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(stderr, "failed to read service log: %v\n", err)
+		return exitFailure
+	}
+	stdout.Write(lastLines(content, opts.Tail))
+	if !opts.Follow {
+		return exitOK
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := followFile(ctx, logFile, int64(len(content)), stdout); err != nil {
+		fmt.Fprintf(stderr, "failed to read service log: %v\n", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// lastLines returns the last n lines of content
+func lastLines(content []byte, n int) []byte {
+	if n == 0 {
+		return nil
+	}
+	end := len(content)
+	if end > 0 && content[end-1] == '\n' {
+		end--
+	}
+	start := end
+	for lines := 0; start > 0; start-- {
+		if content[start-1] == '\n' {
+			lines++
+			if lines == n {
+				break
+			}
+		}
+	}
+	return content[start:]
+}
+
+// followFile copies what is appended to a file from offset until the context is done, starting
+// over when the file is truncated or replaced by a shorter one
+func followFile(ctx context.Context, path string, offset int64, out io.Writer) error {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		content, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if int64(len(content)) < offset {
+			offset = 0
+		}
+		// Only whole lines are copied, the rest is copied once it is complete
+		if last := bytes.LastIndexByte(content[offset:], '\n'); last >= 0 {
+			out.Write(content[offset : offset+int64(last)+1])
+			offset += int64(last) + 1
+		}
+	}
+}
+
+// pastTense returns the outcome of a start, stop or restart command
+func pastTense(command string) string {
+	if command == "stop" {
+		return "stopped"
+	}
+	return command + "ed"
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"financehub/services"
+
+	"github.com/kardianos/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_STATE_HOME", "")
+
+	linux := newServiceConfig(serviceOptions{User: true, Config: "/tmp/financehub.env", Port: 8080}, "linux")
+	assert.Equal(t, "financehub", linux.Name)
+	assert.Equal(t, []string{"service", "run", "-user", "-config", "/tmp/financehub.env", "-port", "8080"}, linux.Arguments)
+	assert.Equal(t, true, linux.Option["UserService"])
+	assert.Equal(t, filepath.Join(home, ".local", "state", "financehub", "logs"), linux.Option["LogDirectory"])
+
+	system := newServiceConfig(serviceOptions{}, "linux")
+	assert.Equal(t, []string{"service", "run"}, system.Arguments)
+	assert.Equal(t, "/var/log/financehub", system.Option["LogDirectory"])
+	assert.Equal(t, "/etc/financehub/service.env", defaultConfigFile("linux", false))
+
+	darwin := newServiceConfig(serviceOptions{User: true}, "darwin")
+	assert.Equal(t, "com.financehub.service", darwin.Name)
+	assert.Equal(t, filepath.Join(home, "Library", "Logs", "FinanceHub"), darwin.Option["LogDirectory"])
+	assert.Equal(t, true, darwin.Option["RunAtLoad"])
+	assert.Equal(t, "/Library/Logs/FinanceHub", serviceLogDir("darwin", false))
+
+	windows := newServiceConfig(serviceOptions{}, "windows")
+	assert.Equal(t, "FinanceHubService", windows.Name)
+	assert.Equal(t, "automatic", windows.Option["StartType"])
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.env")
+	content := "# FinanceHub\n\nSYNC_WATCHLIST=SPY,QQQ\nexport SYNC_CURRENCIES=\"EUR/USD\"\nPORT=9090\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	t.Setenv("SYNC_WATCHLIST", "")
+	t.Setenv("SYNC_CURRENCIES", "")
+	os.Unsetenv("SYNC_WATCHLIST")
+	os.Unsetenv("SYNC_CURRENCIES")
+	// Variables already set take precedence over the file
+	t.Setenv("PORT", "8080")
+
+	assert.NoError(t, loadConfigFile(path))
+	assert.Equal(t, "SPY,QQQ", os.Getenv("SYNC_WATCHLIST"))
+	assert.Equal(t, "EUR/USD", os.Getenv("SYNC_CURRENCIES"))
+	assert.Equal(t, "8080", os.Getenv("PORT"))
+
+	assert.NoError(t, loadConfigFile(filepath.Join(t.TempDir(), "missing.env")))
+	assert.NoError(t, os.WriteFile(path, []byte("not a variable\n"), 0644))
+	assert.ErrorContains(t, loadConfigFile(path), "line 1")
+}
+
+func TestServiceCLIUsage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitOK, runServiceCLI([]string{"help"}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "Usage: financehub service")
+	stdout.Reset()
+	assert.Equal(t, exitOK, runServiceCLI([]string{"install", "-h"}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "Exit codes:")
+
+	assert.Equal(t, exitUsage, runServiceCLI(nil, &stdout, &stderr))
+	assert.Equal(t, exitUsage, runServiceCLI([]string{"reload"}, &stdout, &stderr))
+	assert.Equal(t, exitUsage, runServiceCLI([]string{"status", "-verbose"}, &stdout, &stderr))
+	assert.Equal(t, exitUsage, runServiceCLI([]string{"status", "-port", "8080"}, &stdout, &stderr))
+	assert.Equal(t, exitUsage, runServiceCLI([]string{"install", "-port", "70000"}, &stdout, &stderr))
+	assert.Equal(t, exitUsage, runServiceCLI([]string{"config", "edit"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "unknown service command \"reload\"")
+
+	assert.True(t, isServiceCommand("status"))
+	assert.False(t, isServiceCommand("-assetdir"))
+}
+
+func TestServiceCLIConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.env")
+	content := "SYNC_WATCHLIST=SPY,QQQ\nALPHA_VANTAGE_API_KEY=secret123\nEMPTY_TOKEN=\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitOK, runServiceCLI([]string{"config", "show", "-config", path}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "Config file: "+path+"\n")
+	assert.Contains(t, stdout.String(), "SYNC_WATCHLIST=SPY,QQQ")
+	assert.Contains(t, stdout.String(), "ALPHA_VANTAGE_API_KEY=********")
+	assert.Contains(t, stdout.String(), "EMPTY_TOKEN=\n")
+	assert.NotContains(t, stdout.String(), "secret123")
+
+	stdout.Reset()
+	assert.Equal(t, exitOK, runServiceCLI([]string{"config", "path", "-env-file", path}, &stdout, &stderr))
+	assert.Equal(t, path+"\n", stdout.String())
+
+	stdout.Reset()
+	missing := filepath.Join(t.TempDir(), "missing.env")
+	assert.Equal(t, exitOK, runServiceCLI([]string{"config", "-config", missing}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), missing+" (missing)")
+}
+
+func TestServiceCLILogs(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_STATE_HOME", filepath.Join(dir, "state"))
+	if runtime.GOOS == "windows" {
+		t.Skip("the Windows service log is kept next to the executable")
+	}
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, exitFailure, runServiceCLI([]string{"logs", "-user"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "no service log")
+
+	logDir := serviceLogDir(runtime.GOOS, true)
+	for _, message := range []string{"one", "two", "three"} {
+		logServiceEvent(logDir, message)
+	}
+	assert.Equal(t, exitOK, runServiceCLI([]string{"logs", "-user", "-tail", "2"}, &stdout, &stderr))
+	assert.NotContains(t, stdout.String(), "one")
+	assert.Contains(t, stdout.String(), `"msg":"two"`)
+	assert.Contains(t, stdout.String(), `"msg":"three"`)
+	assert.Equal(t, 2, strings.Count(stdout.String(), "\n"))
+
+	assert.Equal(t, "a\nb\n", string(lastLines([]byte("a\nb\n"), 5)))
+	assert.Equal(t, "b", string(lastLines([]byte("a\nb"), 1)))
+	assert.Empty(t, lastLines([]byte("a\n"), 0))
+}
+
+// TestServiceCLIRun runs the service in the foreground, as it runs from a terminal,
+// and stops it with an interrupt
+func TestServiceCLIRun(t *testing.T) {
+	if runtime.GOOS == "windows" || !service.Interactive() {
+		t.Skip("interactive mode needs a terminal session on a POSIX system")
+	}
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(dir, "state"))
+	envFile := filepath.Join(dir, "service.env")
+	assert.NoError(t, os.WriteFile(envFile, []byte("FINANCEHUB_TEST_SERVICE=running\n"), 0644))
+	defer os.Unsetenv("FINANCEHUB_TEST_SERVICE")
+
+	done := make(chan int, 1)
+	go func() {
+		done <- runServiceCLI([]string{"run", "-user", "-config", envFile}, &bytes.Buffer{}, &bytes.Buffer{})
+	}()
+
+	logFile := filepath.Join(serviceLogDir(runtime.GOOS, true), "financehub_service.log")
+	assert.Eventually(t, func() bool {
+		content, _ := os.ReadFile(logFile)
+		return bytes.Contains(content, []byte("Service started successfully"))
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "running", os.Getenv("FINANCEHUB_TEST_SERVICE"))
+
+	process, _ := os.FindProcess(os.Getpid())
+	assert.NoError(t, process.Signal(os.Interrupt))
+	select {
+	case code := <-done:
+		assert.Equal(t, exitOK, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("service did not stop after an interrupt")
+	}
+
+	content, _ := os.ReadFile(logFile)
+	assert.Contains(t, string(content), "Service stopped gracefully")
+}
+
+func TestServiceAPIServerFailure(t *testing.T) {
+	newProgram := func(addr string) *program {
+		jobs, _ := services.NewJobScheduler("")
+		return &program{
+			logger:          service.ConsoleLogger,
+			log:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+			jobs:            jobs,
+			server:          &http.Server{Addr: addr, Handler: http.NotFoundHandler()},
+			shutdownTimeout: time.Second,
+		}
+	}
+
+	// A port in use fails the start
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+	assert.ErrorContains(t, newProgram(ln.Addr().String()).Start(nil), "failed to start API server")
+
+	// A server failing once started exits with a failure instead of running without the API
+	prg := newProgram("127.0.0.1:0")
+	exited := make(chan int, 1)
+	prg.exit = func(code int) { exited <- code }
+	assert.NoError(t, prg.Start(nil))
+	prg.listener.Close()
+	select {
+	case code := <-exited:
+		assert.Equal(t, exitFailure, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("service kept running after its API server failed")
+	}
+	<-prg.done
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"financehub/portfolio"
+)
+
+// ErrTargetsNotFound is returned when a portfolio has no saved allocation targets
+var ErrTargetsNotFound = errors.New("allocation targets not found")
+
+// allocationData is the persisted allocation targets, keyed by portfolio name
+type allocationData struct {
+	Portfolios map[string]portfolio.AllocationTargets `json:"portfolios"`
+}
+
+// AllocationService stores allocation targets per portfolio and plans rebalancing trades.
+// Targets are persisted as JSON to Path after every change; an empty Path keeps them in memory only.
+type AllocationService struct {
+	Path         string
+	AlphaVantage *AlphaVantageService
+
+	mu   sync.RWMutex
+	data allocationData
+}
+
+// NewAllocationService creates an allocation service, loading saved targets from path if present
+func NewAllocationService(path string, alphaVantage *AlphaVantageService) (*AllocationService, error) {
+	s := &AllocationService{
+		Path:         path,
+		AlphaVantage: alphaVantage,
+		data:         allocationData{Portfolios: make(map[string]portfolio.AllocationTargets)},
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	if _, err := readJSON(path, &s.data, "allocation data"); err != nil {
+		return nil, err
+	}
+	if s.data.Portfolios == nil {
+		s.data.Portfolios = make(map[string]portfolio.AllocationTargets)
+	}
+	return s, nil
+}
+
+// ListTargets returns the allocation targets of every portfolio, keyed by portfolio name
+func (s *AllocationService) ListTargets() map[string]portfolio.AllocationTargets {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	targets := make(map[string]portfolio.AllocationTargets, len(s.data.Portfolios))
+	for name, t := range s.data.Portfolios {
+		targets[name] = t
+	}
+	return targets
+}
+
+// GetTargets returns a portfolio's allocation targets
+func (s *AllocationService) GetTargets(name string) (*portfolio.AllocationTargets, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	targets, ok := s.data.Portfolios[strings.TrimSpace(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTargetsNotFound, name)
+	}
+	return &targets, nil
+}
+
+// SetTargets validates and saves a portfolio's allocation targets, replacing any existing ones
+func (s *AllocationService) SetTargets(name string, targets portfolio.AllocationTargets) (*portfolio.AllocationTargets, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: portfolio name is required", portfolio.ErrInvalidAllocation)
+	}
+	if err := targets.Normalize(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.data.Portfolios[name]
+	s.data.Portfolios[name] = targets
+	if err := s.save(); err != nil {
+		if existed {
+			s.data.Portfolios[name] = previous
+		} else {
+			delete(s.data.Portfolios, name)
+		}
+		return nil, err
+	}
+	return &targets, nil
+}
+
+// DeleteTargets removes a portfolio's allocation targets
+func (s *AllocationService) DeleteTargets(name string) error {
+	name = strings.TrimSpace(name)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.data.Portfolios[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTargetsNotFound, name)
+	}
+	delete(s.data.Portfolios, name)
+	if err := s.save(); err != nil {
+		s.data.Portfolios[name] = previous
+		return err
+	}
+	return nil
+}
+
+// PlanRebalance proposes trades that bring a portfolio back within its drift bands.
+// The request's targets default to the portfolio's saved targets, and positions without
+// a price are valued at their latest quote.
+func (s *AllocationService) PlanRebalance(ctx context.Context, name string, req portfolio.RebalanceRequest) (*portfolio.RebalancePlan, error) {
+	if len(req.Targets.Targets) == 0 {
+		targets, err := s.GetTargets(name)
+		if err != nil {
+			return nil, err
+		}
+		req.Targets = *targets
+	}
+
+	positions := append([]portfolio.Position{}, req.Positions...)
+	for i, position := range positions {
+		if position.Price != 0 || strings.TrimSpace(position.Symbol) == "" {
+			continue
+		}
+		quote, err := s.AlphaVantage.GetStockQuote(ctx, strings.ToUpper(strings.TrimSpace(position.Symbol)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to price %s: %w", position.Symbol, err)
+		}
+		positions[i].Price = quote.Price
+	}
+	req.Positions = positions
+
+	return portfolio.PlanRebalance(req)
+}
+
+// save writes the current targets to Path atomically. Callers must hold the write lock.
+func (s *AllocationService) save() error {
+	if s.Path == "" {
+		return nil
+	}
+
+	return writeJSONAtomic(s.Path, s.data, "allocation data")
+}
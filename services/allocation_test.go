@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"financehub/portfolio"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllocationTargetsPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "allocation.json")
+	service, err := NewAllocationService(path, nil)
+	assert.NoError(t, err)
+
+	saved, err := service.SetTargets(" retirement ", portfolio.AllocationTargets{
+		Dimension: portfolio.BySymbol,
+		Targets:   []portfolio.Target{{Key: "vti", Weight: 0.7}, {Key: "bnd", Weight: 0.3, Band: 0.1}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "VTI", saved.Targets[0].Key)
+	assert.Equal(t, portfolio.DefaultDriftBand, saved.Targets[0].Band)
+
+	_, err = service.SetTargets("broken", portfolio.AllocationTargets{Targets: []portfolio.Target{{Key: "Stocks", Weight: 0.5}}})
+	assert.ErrorIs(t, err, portfolio.ErrInvalidAllocation)
+
+	reloaded, err := NewAllocationService(path, nil)
+	assert.NoError(t, err)
+	targets, err := reloaded.GetTargets("retirement")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, targets.Targets[1].Band)
+	assert.Len(t, reloaded.ListTargets(), 1)
+
+	assert.NoError(t, reloaded.DeleteTargets("retirement"))
+	assert.ErrorIs(t, reloaded.DeleteTargets("retirement"), ErrTargetsNotFound)
+	_, err = reloaded.GetTargets("retirement")
+	assert.ErrorIs(t, err, ErrTargetsNotFound)
+}
+
+func TestAllocationPlanRebalance(t *testing.T) {
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "BND", r.URL.Query().Get("symbol"))
+		w.Write([]byte(`{"Global Quote":{"01. symbol":"BND","05. price":"20.00","10. change percent":"0.5%"}}`))
+	})
+	defer server.Close()
+	service, _ := NewAllocationService("", alphaVantage)
+	_, err := service.SetTargets("main", portfolio.AllocationTargets{
+		Dimension: portfolio.BySymbol,
+		Targets:   []portfolio.Target{{Key: "VTI", Weight: 0.5}, {Key: "BND", Weight: 0.5}},
+	})
+	assert.NoError(t, err)
+
+	plan, err := service.PlanRebalance(context.Background(), "main", portfolio.RebalanceRequest{
+		Positions: []portfolio.Position{
+			{Symbol: "VTI", Quantity: 30, Price: 10},
+			{Symbol: "bnd", Quantity: 5},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 400.0, plan.TotalValue)
+	assert.Equal(t, []portfolio.Order{
+		{Symbol: "VTI", Group: "VTI", Side: portfolio.Sell, Quantity: 10, Price: 10, Amount: 100},
+		{Symbol: "BND", Group: "BND", Side: portfolio.Buy, Quantity: 5, Price: 20, Amount: 100},
+	}, plan.Orders)
+	assert.True(t, plan.Balanced)
+
+	_, err = service.PlanRebalance(context.Background(), "unknown", portfolio.RebalanceRequest{})
+	assert.ErrorIs(t, err, ErrTargetsNotFound)
+}
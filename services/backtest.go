@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+
+	"financehub/backtest"
+)
+
+// BacktestService runs strategy backtests over daily stock and crypto price history
+type BacktestService struct {
+	Prices *TimeSeriesStore
+}
+
+// NewBacktestService creates a new backtest service
+func NewBacktestService(prices *TimeSeriesStore) *BacktestService {
+	return &BacktestService{Prices: prices}
+}
+
+// Run fetches the symbol's daily bars and replays them through the requested strategy
+func (s *BacktestService) Run(ctx context.Context, req backtest.Request) (*backtest.Result, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	strategy, err := backtest.NewStrategy(req.Strategy, req.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	bars, err := s.Prices.Daily(ctx, req.Symbol, req.Source, req.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := backtest.Run(strategy, bars, req.Settings)
+	if err != nil {
+		return nil, err
+	}
+	result.Symbol = req.Symbol
+	return result, nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"financehub/backtest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBacktestRun(t *testing.T) {
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testDailySeries[r.URL.Query().Get("symbol")]))
+	})
+	defer server.Close()
+	service := NewBacktestService(NewTimeSeriesStore("", alphaVantage, NewCoinGeckoService()))
+
+	result, err := service.Run(context.Background(), backtest.Request{Symbol: "AAA", Strategy: backtest.BuyAndHold})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AAA", result.Symbol)
+	assert.Equal(t, "2024-01-02", result.StartDate)
+	assert.Equal(t, 4, result.Bars)
+	assert.Equal(t, 10890.0, result.EndingEquity)
+	assert.Len(t, result.EquityCurve, 4)
+
+	_, err = service.Run(context.Background(), backtest.Request{Symbol: "AAA", Strategy: "momentum"})
+	assert.ErrorIs(t, err, backtest.ErrInvalidBacktest)
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+
+	"financehub/importer"
+	"financehub/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBudgetService(t *testing.T, rollover string) *BudgetService {
+	service, err := NewBudgetService("")
+	assert.NoError(t, err)
+
+	_, err = service.AddCategory(models.Category{Name: "Salary", Kind: models.CategoryIncome})
+	assert.NoError(t, err)
+	_, err = service.AddCategory(models.Category{Name: "Dining Out", Rollover: rollover})
+	assert.NoError(t, err)
+	_, err = service.SetBudget(models.Budget{CategoryID: "dining-out", Amount: 300, EffectiveFrom: "2024-01"})
+	assert.NoError(t, err)
+
+	for _, expense := range []models.Expense{
+		{CategoryID: "salary", Amount: 5000, Date: "2024-01-01"},
+		{CategoryID: "salary", Amount: 5000, Date: "2024-02-01"},
+		{CategoryID: "salary", Amount: 5000, Date: "2024-03-01"},
+		{CategoryID: "dining-out", Amount: 200, Date: "2024-01-15"},
+		{CategoryID: "dining-out", Amount: 450, Date: "2024-02-10"},
+		{CategoryID: "dining-out", Amount: 250, Date: "2024-03-05"},
+	} {
+		_, err := service.RecordExpense(expense)
+		assert.NoError(t, err)
+	}
+	return service
+}
+
+func TestBudgetCategoryValidation(t *testing.T) {
+	service, _ := NewBudgetService("")
+
+	category, err := service.AddCategory(models.Category{Name: "Rent & Utilities"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rent-utilities", category.ID)
+	assert.Equal(t, models.CategoryExpense, category.Kind)
+	assert.Equal(t, models.RolloverNone, category.Rollover)
+
+	tests := []struct {
+		name     string
+		category models.Category
+		err      error
+	}{
+		{"missing name", models.Category{}, ErrInvalidBudget},
+		{"invalid kind", models.Category{Name: "Gifts", Kind: "transfer"}, ErrInvalidBudget},
+		{"invalid rollover", models.Category{Name: "Gifts", Rollover: "always"}, ErrInvalidBudget},
+		{"duplicate", models.Category{Name: "Rent Utilities"}, ErrCategoryExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AddCategory(tt.category)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestRecordExpenseValidation(t *testing.T) {
+	service := newTestBudgetService(t, models.RolloverNone)
+
+	_, err := service.RecordExpense(models.Expense{CategoryID: "salary", Kind: models.CategoryExpense, Amount: 10, Date: "2024-01-01"})
+	assert.ErrorIs(t, err, ErrInvalidBudget)
+
+	_, err = service.RecordExpense(models.Expense{CategoryID: "travel", Amount: 10, Date: "2024-01-01"})
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+
+	_, err = service.RecordExpense(models.Expense{CategoryID: "dining-out", Amount: -5, Date: "2024-01-01"})
+	assert.ErrorIs(t, err, ErrInvalidBudget)
+
+	assert.ErrorIs(t, service.DeleteCategory("dining-out"), ErrCategoryInUse)
+	assert.ErrorIs(t, service.DeleteExpense(999), ErrExpenseNotFound)
+
+	expenses, err := service.ListExpenses("2024-02")
+	assert.NoError(t, err)
+	assert.Len(t, expenses, 2)
+	assert.Equal(t, "dining-out", expenses[0].CategoryID)
+}
+
+func TestMonthlyRollupRollover(t *testing.T) {
+	tests := []struct {
+		rollover    string
+		carriedOver float64
+		remaining   float64
+		overBudget  bool
+	}{
+		// Jan leaves 100 unspent, Feb overspends available budget
+		{models.RolloverNone, 0, 50, false},
+		{models.RolloverSurplus, 0, 50, false},
+		{models.RolloverFull, -50, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rollover, func(t *testing.T) {
+			service := newTestBudgetService(t, tt.rollover)
+
+			rollup, err := service.GetMonthlyRollup("2024-03")
+			assert.NoError(t, err)
+			assert.Equal(t, 5000.0, rollup.TotalIncome)
+			assert.Equal(t, 250.0, rollup.TotalExpenses)
+			assert.Equal(t, 4750.0, rollup.Net)
+			assert.Equal(t, 95.0, rollup.SavingsRate)
+			assert.Equal(t, 300.0, rollup.TotalBudgeted)
+
+			dining := rollup.Categories[1]
+			assert.Equal(t, "dining-out", dining.CategoryID)
+			assert.Equal(t, tt.carriedOver, dining.CarriedOver)
+			assert.Equal(t, tt.remaining, dining.Remaining)
+			assert.Equal(t, tt.overBudget, dining.OverBudget)
+		})
+	}
+
+	service := newTestBudgetService(t, models.RolloverSurplus)
+	rollup, err := service.GetMonthlyRollup("2024-02")
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, rollup.Categories[1].CarriedOver)
+	assert.Equal(t, 400.0, rollup.Categories[1].Available)
+	assert.True(t, rollup.Categories[1].OverBudget)
+}
+
+func TestBudgetTrends(t *testing.T) {
+	service := newTestBudgetService(t, models.RolloverNone)
+
+	trends, err := service.GetTrends("2024-03", 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, trends.Months, 3)
+	assert.Equal(t, "2024-01", trends.Months[0].Month)
+	assert.Equal(t, 4550.0, trends.Months[1].Net)
+	assert.Equal(t, 5000.0, trends.AverageIncome)
+	assert.Equal(t, 300.0, trends.AverageExpenses)
+	assert.Equal(t, []float64{200, 450, 250}, trends.Categories[1].Monthly)
+	assert.Equal(t, -23.08, trends.Categories[1].ChangePercent)
+
+	_, err = service.GetTrends("2024-03", 100)
+	assert.ErrorIs(t, err, ErrInvalidBudget)
+}
+
+func TestBudgetPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "budget.json")
+	service, err := NewBudgetService(path)
+	assert.NoError(t, err)
+
+	_, err = service.AddCategory(models.Category{Name: "Groceries", Rollover: models.RolloverSurplus})
+	assert.NoError(t, err)
+	_, err = service.SetBudget(models.Budget{CategoryID: "groceries", Amount: 600, EffectiveFrom: "2024-01"})
+	assert.NoError(t, err)
+	expense, err := service.RecordExpense(models.Expense{CategoryID: "groceries", Amount: 82.5, Date: "2024-01-06"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), expense.ID)
+
+	reloaded, err := NewBudgetService(path)
+	assert.NoError(t, err)
+	assert.Equal(t, service.ListCategories(), reloaded.ListCategories())
+	assert.Equal(t, service.ListBudgets(), reloaded.ListBudgets())
+
+	next, err := reloaded.RecordExpense(models.Expense{CategoryID: "groceries", Amount: 40, Date: "2024-01-13"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), next.ID)
+}
+
+func TestImportTransactions(t *testing.T) {
+	service := newTestBudgetService(t, models.RolloverNone)
+	_, err := service.SetRules([]importer.Rule{
+		{Contains: "bistro", CategoryID: "dining-out"},
+		{Pattern: "payroll", CategoryID: "salary"},
+	})
+	assert.NoError(t, err)
+
+	_, err = service.SetRules([]importer.Rule{{Contains: "x", CategoryID: "travel"}})
+	assert.ErrorIs(t, err, ErrCategoryNotFound)
+	_, err = service.SetRules([]importer.Rule{{CategoryID: "salary"}})
+	assert.ErrorIs(t, err, ErrInvalidBudget)
+
+	statement := []importer.Transaction{
+		{Date: "2024-04-01", Amount: 5000, Description: "ACME PAYROLL"},
+		{Date: "2024-04-03", Amount: -64.2, Description: "Corner Bistro"},
+		{Date: "2024-04-05", Amount: 12.5, Description: "Corner Bistro refund"},
+		{Date: "2024-04-07", Amount: -30, Description: "Hardware store"},
+	}
+	importer.AssignIDs(statement)
+
+	result, err := service.ImportTransactions(statement)
+	assert.NoError(t, err)
+	assert.Len(t, result.Imported, 2)
+	assert.Equal(t, 64.2, result.Imported[1].Amount)
+	assert.Equal(t, models.CategoryExpense, result.Imported[1].Kind)
+	assert.Len(t, result.Uncategorized, 2)
+	assert.Empty(t, result.Duplicates)
+
+	// Re-importing reports duplicates; uncategorized lines can then be recorded once by hand
+	again, err := service.ImportTransactions(statement)
+	assert.NoError(t, err)
+	assert.Len(t, again.Duplicates, 2)
+	assert.Len(t, again.Uncategorized, 2)
+
+	manual := result.Uncategorized[1]
+	manual.CategoryID = "dining-out"
+	_, err = service.RecordExpense(manual)
+	assert.NoError(t, err)
+	_, err = service.RecordExpense(manual)
+	assert.ErrorIs(t, err, ErrDuplicateTransaction)
+
+	rollup, err := service.GetMonthlyRollup("2024-04")
+	assert.NoError(t, err)
+	assert.Equal(t, 5000.0, rollup.TotalIncome)
+	assert.Equal(t, 94.2, rollup.TotalExpenses)
+}
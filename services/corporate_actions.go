@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"financehub/portfolio"
+)
+
+// ErrCorporateActionNotFound is returned when a recorded corporate action does not exist
+var ErrCorporateActionNotFound = errors.New("corporate action not found")
+
+// ErrCorporateActionExists is returned when recording a dividend or split that is already recorded
+var ErrCorporateActionExists = errors.New("corporate action already recorded")
+
+// Corporate action sources
+const (
+	ActionSourceManual       = "manual"
+	ActionSourceAlphaVantage = "alphavantage"
+)
+
+// corporateActionData is the persisted state of recorded dividends and splits
+type corporateActionData struct {
+	Actions []portfolio.CorporateAction `json:"actions"`
+}
+
+// CorporateActionService records dividends and splits entered manually or ingested from
+// Alpha Vantage daily-adjusted data. Each symbol has at most one action of a type per date.
+// State is persisted as JSON to Path after every change; an empty Path keeps it in memory only.
+type CorporateActionService struct {
+	Path         string
+	AlphaVantage *AlphaVantageService
+
+	mu   sync.RWMutex
+	data corporateActionData
+}
+
+// NewCorporateActionService creates a corporate action service, loading recorded actions from path if present
+func NewCorporateActionService(path string, alphaVantage *AlphaVantageService) (*CorporateActionService, error) {
+	s := &CorporateActionService{
+		Path:         path,
+		AlphaVantage: alphaVantage,
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	if _, err := readJSON(path, &s.data, "corporate action data"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ListActions returns the recorded actions for the given symbols, or for every symbol when none
+// are given, sorted by symbol and date
+func (s *CorporateActionService) ListActions(symbols ...string) []portfolio.CorporateAction {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(symbols))
+	for _, symbol := range symbols {
+		wanted[strings.ToUpper(strings.TrimSpace(symbol))] = true
+	}
+
+	actions := []portfolio.CorporateAction{}
+	for _, action := range s.data.Actions {
+		if len(wanted) == 0 || wanted[action.Symbol] {
+			actions = append(actions, action)
+		}
+	}
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i].Symbol != actions[j].Symbol {
+			return actions[i].Symbol < actions[j].Symbol
+		}
+		return actions[i].Date < actions[j].Date
+	})
+	return actions
+}
+
+// AddAction records a manually entered dividend or split
+func (s *CorporateActionService) AddAction(action portfolio.CorporateAction) (*portfolio.CorporateAction, error) {
+	if err := action.Validate(); err != nil {
+		return nil, err
+	}
+	action = normalizeAction(action, ActionSourceManual)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findAction(action.ID) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrCorporateActionExists, action.ID)
+	}
+	s.data.Actions = append(s.data.Actions, action)
+	if err := s.save(); err != nil {
+		s.data.Actions = s.data.Actions[:len(s.data.Actions)-1]
+		return nil, err
+	}
+	return &action, nil
+}
+
+// DeleteAction removes a recorded corporate action
+func (s *CorporateActionService) DeleteAction(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findAction(id) == nil {
+		return fmt.Errorf("%w: %s", ErrCorporateActionNotFound, id)
+	}
+
+	previous := s.data.Actions
+	s.data.Actions = nil
+	for _, action := range previous {
+		if action.ID != id {
+			s.data.Actions = append(s.data.Actions, action)
+		}
+	}
+	if err := s.save(); err != nil {
+		s.data.Actions = previous
+		return err
+	}
+	return nil
+}
+
+// SyncActions ingests a symbol's dividends and splits from Alpha Vantage daily-adjusted data
+// and returns the actions that were not already recorded. Recorded actions, including manual
+// entries, are kept as they are.
+func (s *CorporateActionService) SyncActions(ctx context.Context, symbol string) ([]portfolio.CorporateAction, error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if symbol == "" {
+		return nil, fmt.Errorf("%w: symbol is required", portfolio.ErrInvalidPortfolio)
+	}
+	history, err := s.AlphaVantage.GetCorporateActions(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s corporate actions: %w", symbol, err)
+	}
+
+	var fetched []portfolio.CorporateAction
+	for _, day := range history {
+		if day.DividendAmount > 0 {
+			fetched = append(fetched, portfolio.CorporateAction{
+				Symbol: symbol,
+				Date:   day.Date,
+				Type:   portfolio.Dividend,
+				Amount: day.DividendAmount,
+			})
+		}
+		if day.SplitCoefficient > 0 && day.SplitCoefficient != 1 {
+			fetched = append(fetched, portfolio.CorporateAction{
+				Symbol: symbol,
+				Date:   day.Date,
+				Type:   portfolio.Split,
+				Ratio:  day.SplitCoefficient,
+			})
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := []portfolio.CorporateAction{}
+	previous := s.data.Actions
+	for _, action := range fetched {
+		action = normalizeAction(action, ActionSourceAlphaVantage)
+		if s.findAction(action.ID) == nil {
+			s.data.Actions = append(s.data.Actions, action)
+			added = append(added, action)
+		}
+	}
+	if len(added) == 0 {
+		return added, nil
+	}
+	if err := s.save(); err != nil {
+		s.data.Actions = previous
+		return nil, err
+	}
+	return added, nil
+}
+
+// normalizeAction upper-cases the symbol and derives the ID from the symbol, date and type
+func normalizeAction(action portfolio.CorporateAction, source string) portfolio.CorporateAction {
+	action.Symbol = strings.ToUpper(strings.TrimSpace(action.Symbol))
+	action.ID = strings.Join([]string{action.Symbol, action.Date, action.Type}, "-")
+	action.Source = source
+	if action.Type == portfolio.Dividend {
+		action.Ratio = 0
+	} else {
+		action.Amount = 0
+	}
+	return action
+}
+
+func (s *CorporateActionService) findAction(id string) *portfolio.CorporateAction {
+	for i := range s.data.Actions {
+		if s.data.Actions[i].ID == id {
+			return &s.data.Actions[i]
+		}
+	}
+	return nil
+}
+
+// save writes the current state to Path atomically. Callers must hold the write lock.
+func (s *CorporateActionService) save() error {
+	if s.Path == "" {
+		return nil
+	}
+
+	return writeJSONAtomic(s.Path, s.data, "corporate action data")
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"financehub/portfolio"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDailyAdjustedResponse = `{"Time Series (Daily)":{
+	"2024-03-15":{"4. close":"65","7. dividend amount":"0.3500","8. split coefficient":"1.0"},
+	"2024-03-14":{"4. close":"64","7. dividend amount":"0.0000","8. split coefficient":"1.0"},
+	"2023-06-01":{"4. close":"56","7. dividend amount":"0.0000","8. split coefficient":"2.0"},
+	"2023-03-15":{"4. close":"100","7. dividend amount":"0.5000","8. split coefficient":"1.0"}}}`
+
+func TestGetCorporateActions(t *testing.T) {
+	service, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "TIME_SERIES_DAILY_ADJUSTED", r.URL.Query().Get("function"))
+		assert.Equal(t, "full", r.URL.Query().Get("outputsize"))
+		w.Write([]byte(testDailyAdjustedResponse))
+	})
+	defer server.Close()
+
+	actions, err := service.GetCorporateActions(context.Background(), "AAA")
+
+	assert.NoError(t, err)
+	assert.Len(t, actions, 3)
+	assert.Equal(t, "2023-03-15", actions[0].Date)
+	assert.Equal(t, 0.5, actions[0].DividendAmount)
+	assert.Equal(t, 2.0, actions[1].SplitCoefficient)
+}
+
+func TestCorporateActionSync(t *testing.T) {
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testDailyAdjustedResponse))
+	})
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "data", "corporate_actions.json")
+	service, err := NewCorporateActionService(path, alphaVantage)
+	assert.NoError(t, err)
+
+	manual, err := service.AddAction(portfolio.CorporateAction{Symbol: "aaa", Date: "2024-03-15", Type: portfolio.Dividend, Amount: 0.36})
+	assert.NoError(t, err)
+	assert.Equal(t, "AAA-2024-03-15-dividend", manual.ID)
+	assert.Equal(t, ActionSourceManual, manual.Source)
+
+	_, err = service.AddAction(*manual)
+	assert.ErrorIs(t, err, ErrCorporateActionExists)
+	_, err = service.AddAction(portfolio.CorporateAction{Symbol: "AAA", Date: "2024-03-15", Type: portfolio.Split})
+	assert.ErrorIs(t, err, portfolio.ErrInvalidPortfolio)
+
+	added, err := service.SyncActions(context.Background(), "aaa")
+	assert.NoError(t, err)
+	assert.Len(t, added, 2)
+	assert.Equal(t, ActionSourceAlphaVantage, added[0].Source)
+
+	reloaded, err := NewCorporateActionService(path, alphaVantage)
+	assert.NoError(t, err)
+	actions := reloaded.ListActions("AAA")
+	assert.Len(t, actions, 3)
+	assert.Equal(t, 0.36, actions[2].Amount)
+	assert.Empty(t, reloaded.ListActions("BBB"))
+
+	assert.NoError(t, reloaded.DeleteAction("AAA-2023-06-01-split"))
+	assert.ErrorIs(t, reloaded.DeleteAction("AAA-2023-06-01-split"), ErrCorporateActionNotFound)
+	assert.Len(t, reloaded.ListActions(), 2)
+}
+
+func TestPortfolioIncomeUsesRecordedActions(t *testing.T) {
+	actions, _ := NewCorporateActionService("", nil)
+	actions.AddAction(portfolio.CorporateAction{Symbol: "AAA", Date: "2023-06-01", Type: portfolio.Split, Ratio: 2})
+	actions.AddAction(portfolio.CorporateAction{Symbol: "AAA", Date: "2023-09-15", Type: portfolio.Dividend, Amount: 0.25})
+	service := NewPortfolioService(nil, actions)
+	transactions := []portfolio.Transaction{{Date: "2023-01-10", Symbol: "AAA", Type: portfolio.Buy, Quantity: 10, Price: 100}}
+
+	income, err := service.GetIncome(portfolio.IncomeRequest{Transactions: transactions})
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, income.Holdings[0].Shares)
+	assert.Equal(t, 5.0, income.TotalIncome)
+
+	income, err = service.GetIncome(portfolio.IncomeRequest{Transactions: transactions, Actions: []portfolio.CorporateAction{}})
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, income.Holdings[0].Shares)
+	assert.Equal(t, 0.0, income.TotalIncome)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIndicator(t *testing.T) {
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "REAL_GDP", r.URL.Query().Get("function"))
+		assert.Equal(t, "quarterly", r.URL.Query().Get("interval"))
+		w.Write([]byte(`{"name":"Real Gross Domestic Product","interval":"quarterly","unit":"billions of dollars",
+			"data":[{"date":"2024-01-01","value":"5681.4"},{"date":"2023-10-01","value":"."},{"date":"2023-07-01","value":"5620.2"},{"date":"2023-04-01","value":"5550.7"}]}`))
+	})
+	defer server.Close()
+	service := NewEconomicsService(alphaVantage)
+
+	series, err := service.GetIndicator(context.Background(), "real-gdp", "quarterly", "", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "real-gdp", series.Indicator)
+	assert.Equal(t, "billions of dollars", series.Unit)
+	assert.Empty(t, series.Maturity)
+	assert.Len(t, series.Data, 2, "Missing observations should be skipped and limit applied")
+	assert.Equal(t, "2024-01-01", series.Data[0].Date)
+	assert.Equal(t, 5620.2, series.Data[1].Value)
+}
+
+func TestGetIndicatorDefaults(t *testing.T) {
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "TREASURY_YIELD", r.URL.Query().Get("function"))
+		assert.Equal(t, "monthly", r.URL.Query().Get("interval"))
+		assert.Equal(t, "10year", r.URL.Query().Get("maturity"))
+		w.Write([]byte(`{"name":"10-Year Treasury Constant Maturity Rate","unit":"percent","data":[{"date":"2024-04-01","value":"4.54"}]}`))
+	})
+	defer server.Close()
+	service := NewEconomicsService(alphaVantage)
+
+	series, err := service.GetIndicator(context.Background(), "treasury-yield", "", "", 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "10year", series.Maturity)
+	assert.Equal(t, 4.54, series.Data[0].Value)
+}
+
+func TestGetIndicatorValidation(t *testing.T) {
+	service := NewEconomicsService(NewAlphaVantageService())
+
+	tests := []struct {
+		name      string
+		indicator string
+		interval  string
+		maturity  string
+		expected  error
+	}{
+		{name: "Unknown indicator", indicator: "gdp-per-capita", expected: ErrUnknownIndicator},
+		{name: "Unsupported interval", indicator: "inflation", interval: "monthly", expected: ErrInvalidIndicatorOption},
+		{name: "Unsupported maturity", indicator: "treasury-yield", maturity: "1year", expected: ErrInvalidIndicatorOption},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetIndicator(context.Background(), tt.indicator, tt.interval, tt.maturity, 0)
+			assert.True(t, errors.Is(err, tt.expected), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestGlobalEconomyTopicIndicators(t *testing.T) {
+	topic := NewTopicsService().GetTopicByID("global-economy")
+	service := NewEconomicsService(NewAlphaVantageService())
+
+	supported := map[string]bool{}
+	for _, indicator := range service.ListIndicators() {
+		supported[indicator.ID] = true
+	}
+
+	assert.NotEmpty(t, topic.Indicators)
+	for _, id := range topic.Indicators {
+		assert.True(t, supported[id], "Topic indicator %s should be supported", id)
+	}
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"financehub/models"
+)
+
+// GetCompanyOverview retrieves company profile and key statistics
+func (s *AlphaVantageService) GetCompanyOverview(ctx context.Context, symbol string) (*models.CompanyOverview, error) {
+	result, err := s.query(ctx, "OVERVIEW", map[string]string{"symbol": symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 || result["Symbol"] == nil {
+		return nil, fmt.Errorf("invalid response or symbol not found")
+	}
+
+	return &models.CompanyOverview{
+		Symbol:               getString(result["Symbol"]),
+		Name:                 getString(result["Name"]),
+		Description:          getString(result["Description"]),
+		Exchange:             getString(result["Exchange"]),
+		Currency:             getString(result["Currency"]),
+		Country:              getString(result["Country"]),
+		Sector:               getString(result["Sector"]),
+		Industry:             getString(result["Industry"]),
+		FiscalYearEnd:        getString(result["FiscalYearEnd"]),
+		LatestQuarter:        getString(result["LatestQuarter"]),
+		MarketCapitalization: parseNumber(result["MarketCapitalization"]),
+		EBITDA:               parseNumber(result["EBITDA"]),
+		PERatio:              parseNumber(result["PERatio"]),
+		PEGRatio:             parseNumber(result["PEGRatio"]),
+		BookValue:            parseNumber(result["BookValue"]),
+		DividendPerShare:     parseNumber(result["DividendPerShare"]),
+		DividendYield:        parseNumber(result["DividendYield"]),
+		EPS:                  parseNumber(result["EPS"]),
+		RevenueTTM:           parseNumber(result["RevenueTTM"]),
+		GrossProfitTTM:       parseNumber(result["GrossProfitTTM"]),
+		ProfitMargin:         parseNumber(result["ProfitMargin"]),
+		OperatingMarginTTM:   parseNumber(result["OperatingMarginTTM"]),
+		ReturnOnAssetsTTM:    parseNumber(result["ReturnOnAssetsTTM"]),
+		ReturnOnEquityTTM:    parseNumber(result["ReturnOnEquityTTM"]),
+		AnalystTargetPrice:   parseNumber(result["AnalystTargetPrice"]),
+		PriceToBookRatio:     parseNumber(result["PriceToBookRatio"]),
+		Beta:                 parseNumber(result["Beta"]),
+		Week52High:           parseNumber(result["52WeekHigh"]),
+		Week52Low:            parseNumber(result["52WeekLow"]),
+		SharesOutstanding:    parseNumber(result["SharesOutstanding"]),
+	}, nil
+}
+
+// GetEarnings retrieves annual and quarterly earnings history
+func (s *AlphaVantageService) GetEarnings(ctx context.Context, symbol string) (*models.Earnings, error) {
+	result, err := s.query(ctx, "EARNINGS", map[string]string{"symbol": symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	annual, ok := result["annualEarnings"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid earnings data")
+	}
+	quarterly, _ := result["quarterlyEarnings"].([]interface{})
+
+	earnings := &models.Earnings{Symbol: symbol}
+	for _, item := range annual {
+		report, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		earnings.Annual = append(earnings.Annual, models.EarningsReport{
+			FiscalDateEnding: getString(report["fiscalDateEnding"]),
+			ReportedEPS:      parseNumber(report["reportedEPS"]),
+		})
+	}
+	for _, item := range quarterly {
+		report, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		earnings.Quarterly = append(earnings.Quarterly, models.EarningsReport{
+			FiscalDateEnding:   getString(report["fiscalDateEnding"]),
+			ReportedDate:       getString(report["reportedDate"]),
+			ReportedEPS:        parseNumber(report["reportedEPS"]),
+			EstimatedEPS:       parseNumber(report["estimatedEPS"]),
+			Surprise:           parseNumber(report["surprise"]),
+			SurprisePercentage: parseNumber(report["surprisePercentage"]),
+		})
+	}
+
+	return earnings, nil
+}
+
+// GetIncomeStatement retrieves annual and quarterly income statements
+func (s *AlphaVantageService) GetIncomeStatement(ctx context.Context, symbol string) (*models.IncomeStatements, error) {
+	annual, quarterly, err := s.getReports(ctx, "INCOME_STATEMENT", symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := &models.IncomeStatements{Symbol: symbol}
+	for _, report := range annual {
+		statements.Annual = append(statements.Annual, parseIncomeStatement(report))
+	}
+	for _, report := range quarterly {
+		statements.Quarterly = append(statements.Quarterly, parseIncomeStatement(report))
+	}
+
+	return statements, nil
+}
+
+// GetBalanceSheet retrieves annual and quarterly balance sheets
+func (s *AlphaVantageService) GetBalanceSheet(ctx context.Context, symbol string) (*models.BalanceSheets, error) {
+	annual, quarterly, err := s.getReports(ctx, "BALANCE_SHEET", symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets := &models.BalanceSheets{Symbol: symbol}
+	for _, report := range annual {
+		sheets.Annual = append(sheets.Annual, parseBalanceSheet(report))
+	}
+	for _, report := range quarterly {
+		sheets.Quarterly = append(sheets.Quarterly, parseBalanceSheet(report))
+	}
+
+	return sheets, nil
+}
+
+// GetCashFlow retrieves annual and quarterly cash flow statements
+func (s *AlphaVantageService) GetCashFlow(ctx context.Context, symbol string) (*models.CashFlowStatements, error) {
+	annual, quarterly, err := s.getReports(ctx, "CASH_FLOW", symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := &models.CashFlowStatements{Symbol: symbol}
+	for _, report := range annual {
+		statements.Annual = append(statements.Annual, parseCashFlowStatement(report))
+	}
+	for _, report := range quarterly {
+		statements.Quarterly = append(statements.Quarterly, parseCashFlowStatement(report))
+	}
+
+	return statements, nil
+}
+
+// GetFinancialRatios computes valuation and profitability ratios from the latest annual reports
+func (s *AlphaVantageService) GetFinancialRatios(ctx context.Context, symbol string) (*models.FinancialRatios, error) {
+	quote, err := s.GetStockQuote(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	overview, err := s.GetCompanyOverview(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	income, err := s.GetIncomeStatement(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := s.GetBalanceSheet(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	cashFlow, err := s.GetCashFlow(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var latestIncome *models.IncomeStatement
+	if len(income.Annual) > 0 {
+		latestIncome = &income.Annual[0]
+	}
+	var latestBalance *models.BalanceSheet
+	if len(balance.Annual) > 0 {
+		latestBalance = &balance.Annual[0]
+	}
+	var latestCashFlow *models.CashFlowStatement
+	if len(cashFlow.Annual) > 0 {
+		latestCashFlow = &cashFlow.Annual[0]
+	}
+
+	ratios := ComputeFinancialRatios(symbol, quote.Price, overview, latestIncome, latestBalance, latestCashFlow)
+	return &ratios, nil
+}
+
+// ComputeFinancialRatios derives ratios from a share price and the given reports.
+// Any report may be nil; ratios that depend on missing data are left at zero.
+func ComputeFinancialRatios(symbol string, price float64, overview *models.CompanyOverview,
+	income *models.IncomeStatement, balance *models.BalanceSheet, cashFlow *models.CashFlowStatement) models.FinancialRatios {
+	ratios := models.FinancialRatios{
+		Symbol: symbol,
+		Price:  price,
+	}
+
+	var shares float64
+	if balance != nil {
+		shares = balance.CommonStockSharesOutstanding
+		ratios.FiscalDateEnding = balance.FiscalDateEnding
+	}
+	if shares == 0 && overview != nil {
+		shares = overview.SharesOutstanding
+	}
+
+	ratios.MarketCap = price * shares
+	if ratios.MarketCap == 0 && overview != nil {
+		ratios.MarketCap = overview.MarketCapitalization
+	}
+
+	var eps float64
+	if overview != nil {
+		eps = overview.EPS
+	}
+	if eps == 0 && income != nil {
+		eps = safeDivide(income.NetIncome, shares)
+	}
+	ratios.PriceToEarnings = safeDivide(price, eps)
+
+	if income != nil {
+		ratios.GrossMargin = safeDivide(income.GrossProfit, income.TotalRevenue)
+		ratios.OperatingMargin = safeDivide(income.OperatingIncome, income.TotalRevenue)
+		ratios.NetMargin = safeDivide(income.NetIncome, income.TotalRevenue)
+	}
+
+	if balance != nil {
+		equity := balance.TotalShareholderEquity
+		ratios.PriceToBook = safeDivide(price, safeDivide(equity, shares))
+		ratios.DebtToEquity = safeDivide(balance.ShortTermDebt+balance.LongTermDebt, equity)
+		if income != nil {
+			ratios.ReturnOnEquity = safeDivide(income.NetIncome, equity)
+		}
+	}
+
+	if cashFlow != nil {
+		// Alpha Vantage reports capital expenditures as a positive outflow
+		ratios.FreeCashFlow = cashFlow.OperatingCashflow - cashFlow.CapitalExpenditures
+		ratios.FCFYield = safeDivide(ratios.FreeCashFlow, ratios.MarketCap)
+	}
+
+	return ratios
+}
+
+// getReports fetches a financial statement function and returns its annual and quarterly report maps
+func (s *AlphaVantageService) getReports(ctx context.Context, function, symbol string) ([]map[string]interface{}, []map[string]interface{}, error) {
+	result, err := s.query(ctx, function, map[string]string{"symbol": symbol})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annual, ok := result["annualReports"].([]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid %s data", function)
+	}
+	quarterly, _ := result["quarterlyReports"].([]interface{})
+
+	return toReportMaps(annual), toReportMaps(quarterly), nil
+}
+
+func toReportMaps(items []interface{}) []map[string]interface{} {
+	var reports []map[string]interface{}
+	for _, item := range items {
+		if report, ok := item.(map[string]interface{}); ok {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
+
+func parseIncomeStatement(report map[string]interface{}) models.IncomeStatement {
+	return models.IncomeStatement{
+		FiscalDateEnding:       getString(report["fiscalDateEnding"]),
+		ReportedCurrency:       getString(report["reportedCurrency"]),
+		TotalRevenue:           parseNumber(report["totalRevenue"]),
+		CostOfRevenue:          parseNumber(report["costOfRevenue"]),
+		GrossProfit:            parseNumber(report["grossProfit"]),
+		OperatingExpenses:      parseNumber(report["operatingExpenses"]),
+		ResearchAndDevelopment: parseNumber(report["researchAndDevelopment"]),
+		OperatingIncome:        parseNumber(report["operatingIncome"]),
+		InterestExpense:        parseNumber(report["interestExpense"]),
+		IncomeTaxExpense:       parseNumber(report["incomeTaxExpense"]),
+		EBIT:                   parseNumber(report["ebit"]),
+		EBITDA:                 parseNumber(report["ebitda"]),
+		NetIncome:              parseNumber(report["netIncome"]),
+	}
+}
+
+func parseBalanceSheet(report map[string]interface{}) models.BalanceSheet {
+	return models.BalanceSheet{
+		FiscalDateEnding:             getString(report["fiscalDateEnding"]),
+		ReportedCurrency:             getString(report["reportedCurrency"]),
+		TotalAssets:                  parseNumber(report["totalAssets"]),
+		TotalCurrentAssets:           parseNumber(report["totalCurrentAssets"]),
+		CashAndEquivalents:           parseNumber(report["cashAndCashEquivalentsAtCarryingValue"]),
+		Inventory:                    parseNumber(report["inventory"]),
+		TotalLiabilities:             parseNumber(report["totalLiabilities"]),
+		TotalCurrentLiabilities:      parseNumber(report["totalCurrentLiabilities"]),
+		ShortTermDebt:                parseNumber(report["shortTermDebt"]),
+		LongTermDebt:                 parseNumber(report["longTermDebt"]),
+		TotalShareholderEquity:       parseNumber(report["totalShareholderEquity"]),
+		RetainedEarnings:             parseNumber(report["retainedEarnings"]),
+		CommonStockSharesOutstanding: parseNumber(report["commonStockSharesOutstanding"]),
+	}
+}
+
+func parseCashFlowStatement(report map[string]interface{}) models.CashFlowStatement {
+	return models.CashFlowStatement{
+		FiscalDateEnding:      getString(report["fiscalDateEnding"]),
+		ReportedCurrency:      getString(report["reportedCurrency"]),
+		OperatingCashflow:     parseNumber(report["operatingCashflow"]),
+		CapitalExpenditures:   parseNumber(report["capitalExpenditures"]),
+		CashflowFromInvesting: parseNumber(report["cashflowFromInvestment"]),
+		CashflowFromFinancing: parseNumber(report["cashflowFromFinancing"]),
+		DividendPayout:        parseNumber(report["dividendPayout"]),
+		NetIncome:             parseNumber(report["netIncome"]),
+		ChangeInCash:          parseNumber(report["changeInCashAndCashEquivalents"]),
+	}
+}
+
+// safeDivide returns a/b, or zero when b is zero
+func safeDivide(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financehub/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAlphaVantageService(handler http.HandlerFunc) (*AlphaVantageService, *httptest.Server) {
+	server := httptest.NewServer(handler)
+	service := NewAlphaVantageService()
+	service.APIKey = "test"
+	service.BaseURL = server.URL
+	return service, server
+}
+
+func TestAlphaVantageContextCancelled(t *testing.T) {
+	service, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err := service.GetStockQuote(ctx, "IBM")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+}
+
+func TestGetCompanyOverview(t *testing.T) {
+	service, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "OVERVIEW", r.URL.Query().Get("function"))
+		assert.Equal(t, "IBM", r.URL.Query().Get("symbol"))
+		w.Write([]byte(`{"Symbol":"IBM","Name":"International Business Machines","Sector":"TECHNOLOGY",
+			"MarketCapitalization":"150000000000","EPS":"8.14","PERatio":"None","52WeekHigh":"199.18"}`))
+	})
+	defer server.Close()
+
+	overview, err := service.GetCompanyOverview(context.Background(), "IBM")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "IBM", overview.Symbol)
+	assert.Equal(t, "TECHNOLOGY", overview.Sector)
+	assert.Equal(t, 150000000000.0, overview.MarketCapitalization)
+	assert.Equal(t, 8.14, overview.EPS)
+	assert.Equal(t, 0.0, overview.PERatio)
+	assert.Equal(t, 199.18, overview.Week52High)
+}
+
+func TestGetCompanyOverviewRateLimited(t *testing.T) {
+	service, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Note":"Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`))
+	})
+	defer server.Close()
+
+	overview, err := service.GetCompanyOverview(context.Background(), "IBM")
+
+	assert.Error(t, err)
+	assert.Nil(t, overview)
+	assert.Contains(t, err.Error(), "call frequency")
+}
+
+func TestGetIncomeStatement(t *testing.T) {
+	service, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "INCOME_STATEMENT", r.URL.Query().Get("function"))
+		w.Write([]byte(`{"symbol":"IBM",
+			"annualReports":[{"fiscalDateEnding":"2023-12-31","reportedCurrency":"USD","totalRevenue":"61860000000","grossProfit":"34300000000","netIncome":"7502000000"}],
+			"quarterlyReports":[{"fiscalDateEnding":"2024-03-31","totalRevenue":"14462000000","netIncome":"None"}]}`))
+	})
+	defer server.Close()
+
+	statements, err := service.GetIncomeStatement(context.Background(), "IBM")
+
+	assert.NoError(t, err)
+	assert.Len(t, statements.Annual, 1)
+	assert.Len(t, statements.Quarterly, 1)
+	assert.Equal(t, "2023-12-31", statements.Annual[0].FiscalDateEnding)
+	assert.Equal(t, 61860000000.0, statements.Annual[0].TotalRevenue)
+	assert.Equal(t, 0.0, statements.Quarterly[0].NetIncome)
+}
+
+func TestComputeFinancialRatios(t *testing.T) {
+	overview := &models.CompanyOverview{EPS: 5}
+	income := &models.IncomeStatement{
+		TotalRevenue:    1000,
+		GrossProfit:     400,
+		OperatingIncome: 200,
+		NetIncome:       100,
+	}
+	balance := &models.BalanceSheet{
+		FiscalDateEnding:             "2023-12-31",
+		ShortTermDebt:                50,
+		LongTermDebt:                 150,
+		TotalShareholderEquity:       400,
+		CommonStockSharesOutstanding: 20,
+	}
+	cashFlow := &models.CashFlowStatement{
+		OperatingCashflow:   180,
+		CapitalExpenditures: 60,
+	}
+
+	ratios := ComputeFinancialRatios("TEST", 50, overview, income, balance, cashFlow)
+
+	assert.Equal(t, "2023-12-31", ratios.FiscalDateEnding)
+	assert.Equal(t, 1000.0, ratios.MarketCap)
+	assert.Equal(t, 10.0, ratios.PriceToEarnings)
+	assert.Equal(t, 2.5, ratios.PriceToBook)
+	assert.Equal(t, 0.5, ratios.DebtToEquity)
+	assert.Equal(t, 0.4, ratios.GrossMargin)
+	assert.Equal(t, 0.2, ratios.OperatingMargin)
+	assert.Equal(t, 0.1, ratios.NetMargin)
+	assert.Equal(t, 0.25, ratios.ReturnOnEquity)
+	assert.Equal(t, 120.0, ratios.FreeCashFlow)
+	assert.Equal(t, 0.12, ratios.FCFYield)
+}
+
+func TestComputeFinancialRatiosMissingReports(t *testing.T) {
+	ratios := ComputeFinancialRatios("TEST", 50, nil, nil, nil, nil)
+
+	assert.Equal(t, 50.0, ratios.Price)
+	assert.Equal(t, 0.0, ratios.PriceToEarnings)
+	assert.Equal(t, 0.0, ratios.DebtToEquity)
+	assert.Equal(t, 0.0, ratios.FCFYield)
+}
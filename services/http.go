@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+	"net/http"
+)
+
+// httpGet sends a GET request that is cancelled with the context
+func httpGet(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
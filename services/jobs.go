@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"financehub/schedule"
+)
+
+// ErrJobNotFound is returned when a background job has not been registered
+var ErrJobNotFound = errors.New("job not found")
+
+// ErrJobRunning is returned when a job is started while its previous run is still going
+var ErrJobRunning = errors.New("job is already running")
+
+// Job run statuses
+const (
+	JobIdle      = "idle"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// JobRun records the outcome of a job's runs
+type JobRun struct {
+	Status       string  `json:"status"`
+	LastRun      string  `json:"lastRun,omitempty"`
+	LastSuccess  string  `json:"lastSuccess,omitempty"`
+	LastDuration float64 `json:"lastDurationSeconds"`
+	LastError    string  `json:"lastError,omitempty"`
+	Runs         int     `json:"runs"`
+	Failures     int     `json:"failures"`
+}
+
+// JobState describes a registered job with its schedule and last run
+type JobState struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Schedule    string `json:"schedule"`
+	NextRun     string `json:"nextRun,omitempty"`
+	JobRun
+}
+
+// job is a registered job
+type job struct {
+	name        string
+	description string
+	schedule    *schedule.Schedule
+	run         func(context.Context) error
+	next        time.Time
+	running     bool
+}
+
+// JobScheduler runs registered jobs on cron-like schedules in the background. The outcome of
+// every run is persisted as JSON to Path; an empty Path keeps it in memory only.
+type JobScheduler struct {
+	Path string
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	runs   map[string]*JobRun
+	cancel context.CancelFunc
+	wake   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewJobScheduler creates a job scheduler, loading previous run outcomes from path if present
+func NewJobScheduler(path string) (*JobScheduler, error) {
+	s := &JobScheduler{
+		Path: path,
+		jobs: make(map[string]*job),
+		runs: make(map[string]*JobRun),
+		wake: make(chan struct{}, 1),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	if _, err := readJSON(path, &s.runs, "job data"); err != nil {
+		return nil, err
+	}
+	// Runs interrupted by a shutdown did not finish
+	for _, run := range s.runs {
+		if run.Status == JobRunning {
+			run.Status = JobFailed
+			run.LastError = "interrupted"
+		}
+	}
+	return s, nil
+}
+
+// Register adds a job that runs on a cron expression, shorthand such as @hourly, or @every interval.
+// Scheduled runs are passed the scheduler's context, which is cancelled when it stops.
+func (s *JobScheduler) Register(name, description, spec string, run func(context.Context) error) error {
+	parsed, err := schedule.Parse(spec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[name]; ok {
+		return fmt.Errorf("job %s is already registered", name)
+	}
+	s.jobs[name] = &job{
+		name:        name,
+		description: description,
+		schedule:    parsed,
+		run:         run,
+		next:        parsed.Next(time.Now()),
+	}
+	if _, ok := s.runs[name]; !ok {
+		s.runs[name] = &JobRun{Status: JobIdle}
+	}
+	s.notify()
+	return nil
+}
+
+// List returns the registered jobs sorted by name
+func (s *JobScheduler) List() []JobState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]JobState, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		states = append(states, s.state(j))
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states
+}
+
+// Get returns a registered job
+func (s *JobScheduler) Get(name string) (*JobState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j, ok := s.jobs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	state := s.state(j)
+	return &state, nil
+}
+
+// Run runs a job now with the context and waits for it to finish, returning its outcome. The
+// job's error is recorded in the outcome rather than returned.
+func (s *JobScheduler) Run(ctx context.Context, name string) (*JobState, error) {
+	s.mu.Lock()
+	j, ok := s.jobs[name]
+	if !ok {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if j.running {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrJobRunning, name)
+	}
+	s.begin(j)
+	s.mu.Unlock()
+
+	s.execute(ctx, j)
+	return s.Get(name)
+}
+
+// Start runs jobs as they fall due until the context is cancelled or Stop is called
+func (s *JobScheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			timer := time.NewTimer(s.runDue(ctx, time.Now()))
+			select {
+			case <-timer.C:
+			case <-s.wake:
+				timer.Stop()
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends scheduling and waits for running jobs to finish
+func (s *JobScheduler) Stop() {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+// runDue starts the jobs that are due with the context and returns how long to wait for the next one
+func (s *JobScheduler) runDue(ctx context.Context, now time.Time) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wait := time.Hour
+	for _, j := range s.jobs {
+		if !j.next.IsZero() && !j.next.After(now) {
+			j.next = j.schedule.Next(now)
+			// A run that outlasts its interval skips the runs it overlaps
+			if !j.running {
+				s.begin(j)
+				s.wg.Add(1)
+				go func(j *job) {
+					defer s.wg.Done()
+					s.execute(ctx, j)
+				}(j)
+			}
+		}
+		if !j.next.IsZero() {
+			wait = min(wait, j.next.Sub(now))
+		}
+	}
+	return max(wait, 0)
+}
+
+// begin marks a job as running. Callers must hold the lock.
+func (s *JobScheduler) begin(j *job) {
+	j.running = true
+	run := s.runs[j.name]
+	run.Status = JobRunning
+	run.LastRun = time.Now().Format(time.RFC3339)
+	s.persist()
+}
+
+// execute runs a job that has begun and records its outcome
+func (s *JobScheduler) execute(ctx context.Context, j *job) {
+	started := time.Now()
+	err := j.run(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j.running = false
+	run := s.runs[j.name]
+	run.Runs++
+	run.LastDuration = time.Since(started).Seconds()
+	if err != nil {
+		run.Status = JobFailed
+		run.LastError = err.Error()
+		run.Failures++
+		slog.WarnContext(ctx, "Job failed", "job", j.name, "duration_s", run.LastDuration, "error", err)
+	} else {
+		run.Status = JobSucceeded
+		run.LastError = ""
+		run.LastSuccess = time.Now().Format(time.RFC3339)
+		slog.InfoContext(ctx, "Job succeeded", "job", j.name, "duration_s", run.LastDuration)
+	}
+	s.persist()
+}
+
+// state describes a job. Callers must hold the lock.
+func (s *JobScheduler) state(j *job) JobState {
+	state := JobState{
+		Name:        j.name,
+		Description: j.description,
+		Schedule:    j.schedule.String(),
+		JobRun:      *s.runs[j.name],
+	}
+	if !j.next.IsZero() {
+		state.NextRun = j.next.Format(time.RFC3339)
+	}
+	return state
+}
+
+// notify wakes the scheduler to pick up a changed job
+func (s *JobScheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// persist saves run outcomes, logging failures since they do not affect the jobs themselves.
+// Callers must hold the lock.
+func (s *JobScheduler) persist() {
+	if err := s.save(); err != nil {
+		slog.Warn("Job data unavailable, run history will not be saved", "error", err)
+	}
+}
+
+// save writes the current state to Path atomically. Callers must hold the lock.
+func (s *JobScheduler) save() error {
+	if s.Path == "" {
+		return nil
+	}
+
+	return writeJSONAtomic(s.Path, s.runs, "job data")
+}
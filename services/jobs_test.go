@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"financehub/schedule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobSchedulerRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	scheduler, err := NewJobScheduler(path)
+	assert.NoError(t, err)
+
+	fail := true
+	assert.NoError(t, scheduler.Register("sync", "Sync prices", "@hourly", func(context.Context) error {
+		if fail {
+			return errors.New("rate limited")
+		}
+		return nil
+	}))
+	assert.Error(t, scheduler.Register("sync", "Again", "@daily", func(context.Context) error { return nil }))
+	assert.ErrorIs(t, scheduler.Register("bad", "Bad", "every day", func(context.Context) error { return nil }), schedule.ErrInvalidSchedule)
+
+	state, err := scheduler.Run(context.Background(), "sync")
+	assert.NoError(t, err)
+	assert.Equal(t, JobFailed, state.Status)
+	assert.Equal(t, "rate limited", state.LastError)
+	assert.Equal(t, "@hourly", state.Schedule)
+	assert.NotEmpty(t, state.NextRun)
+
+	fail = false
+	state, err = scheduler.Run(context.Background(), "sync")
+	assert.NoError(t, err)
+	assert.Equal(t, JobSucceeded, state.Status)
+	assert.Empty(t, state.LastError)
+	assert.Equal(t, 2, state.Runs)
+	assert.Equal(t, 1, state.Failures)
+	assert.NotEmpty(t, state.LastSuccess)
+
+	_, err = scheduler.Run(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+
+	// Run history is kept across restarts
+	reloaded, err := NewJobScheduler(path)
+	assert.NoError(t, err)
+	assert.NoError(t, reloaded.Register("sync", "Sync prices", "@hourly", func(context.Context) error { return nil }))
+	jobs := reloaded.List()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, 2, jobs[0].Runs)
+	assert.Equal(t, JobSucceeded, jobs[0].Status)
+}
+
+func TestJobSchedulerStart(t *testing.T) {
+	scheduler, _ := NewJobScheduler("")
+	var runs atomic.Int32
+	assert.NoError(t, scheduler.Register("tick", "Tick", "@every 10ms", func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler.Start(ctx)
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	// Cancelling the context stops scheduling, Stop waits for the loop to end
+	cancel()
+	scheduler.Stop()
+
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+	state, _ := scheduler.Get("tick")
+	assert.Equal(t, int(stopped), state.Runs)
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "items.json")
+	var items map[string]int
+
+	found, err := readJSON(path, &items, "item data")
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	assert.NoError(t, writeJSONAtomic(path, map[string]int{"a": 1}, "item data"))
+	found, err = readJSON(path, &items, "item data")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, map[string]int{"a": 1}, items)
+	_, err = os.Stat(path + ".tmp")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+	_, err = readJSON(path, &items, "item data")
+	assert.ErrorContains(t, err, "failed to parse item data")
+}
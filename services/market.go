@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"financehub/models"
+)
+
+// MarketOverviewService builds and caches the market overview from equity and crypto sources
+type MarketOverviewService struct {
+	AlphaVantage *AlphaVantageService
+	CoinGecko    *CoinGeckoService
+	TTL          time.Duration
+
+	mu        sync.RWMutex
+	overview  *models.MarketOverview
+	updatedAt time.Time
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// NewMarketOverviewService creates a new market overview service
+func NewMarketOverviewService(alphaVantage *AlphaVantageService, coinGecko *CoinGeckoService) *MarketOverviewService {
+	return &MarketOverviewService{
+		AlphaVantage: alphaVantage,
+		CoinGecko:    coinGecko,
+		TTL:          15 * time.Minute,
+	}
+}
+
+// GetOverview returns the cached overview, refreshing it when it is older than the TTL.
+// A stale overview is returned if the refresh fails.
+func (s *MarketOverviewService) GetOverview(ctx context.Context) (*models.MarketOverview, error) {
+	s.mu.RLock()
+	cached, updatedAt := s.overview, s.updatedAt
+	s.mu.RUnlock()
+
+	if cached != nil && time.Since(updatedAt) < s.TTL {
+		return cached, nil
+	}
+
+	overview, err := s.Refresh(ctx)
+	if err != nil {
+		if cached != nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+	return overview, nil
+}
+
+// Refresh fetches market movers and global crypto data and updates the cache.
+// If only one source fails, the previously cached values for that source are kept.
+func (s *MarketOverviewService) Refresh(ctx context.Context) (*models.MarketOverview, error) {
+	movers, moversErr := s.AlphaVantage.GetTopGainersLosers(ctx)
+	global, globalErr := s.CoinGecko.GetGlobalMarket(ctx)
+	if moversErr != nil && globalErr != nil {
+		return nil, fmt.Errorf("failed to refresh market overview: %v; %v", moversErr, globalErr)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	overview := models.MarketOverview{
+		TopGainers: []models.StockQuote{},
+		TopLosers:  []models.StockQuote{},
+		MostActive: []models.StockQuote{},
+	}
+	if s.overview != nil {
+		overview = *s.overview
+	}
+	if moversErr == nil {
+		overview.TopGainers = movers.TopGainers
+		overview.TopLosers = movers.TopLosers
+		overview.MostActive = movers.MostActive
+	}
+	if globalErr == nil {
+		overview.TotalMarketCap = global.TotalMarketCap
+		overview.Volume24h = global.TotalVolume24h
+	}
+	overview.LastUpdated = time.Now().Format(time.RFC3339)
+
+	s.overview = &overview
+	s.updatedAt = time.Now()
+	return s.overview, nil
+}
+
+// Start refreshes the overview immediately and then on every interval until the context is
+// cancelled or Stop is called
+func (s *MarketOverviewService) Start(ctx context.Context, interval time.Duration) {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.mu.Unlock()
+		return
+	}
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		refresh := func() {
+			if _, err := s.Refresh(ctx); err != nil {
+				slog.WarnContext(ctx, "Market overview refresh failed", "error", err)
+			}
+		}
+
+		refresh()
+		for {
+			select {
+			case <-ticker.C:
+				refresh()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends background refreshing and waits for a refresh in progress to finish
+func (s *MarketOverviewService) Stop() {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financehub/transport"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testMoversResponse = `{
+	"metadata": "Top gainers, losers, and most actively traded US tickers",
+	"last_updated": "2024-05-10 16:15:59 US/Eastern",
+	"top_gainers": [{"ticker":"ABCD","price":"3.5","change_amount":"1.5","change_percentage":"75.0%","volume":"1200000"}],
+	"top_losers": [{"ticker":"WXYZ","price":"1.2","change_amount":"-0.8","change_percentage":"-40.0%","volume":"800000"}],
+	"most_actively_traded": [{"ticker":"SPY","price":"520.1","change_amount":"0.9","change_percentage":"0.17%","volume":"55000000"}]
+}`
+
+const testGlobalResponse = `{"data":{"active_cryptocurrencies":10000,
+	"total_market_cap":{"usd":2500000000000},"total_volume":{"usd":90000000000},
+	"market_cap_percentage":{"btc":52.1,"eth":16.4},"market_cap_change_percentage_24h_usd":1.25}}`
+
+// newTestHTTPClient returns a client that retries without delay and has a breaker of its own
+func newTestHTTPClient() *http.Client {
+	t := &transport.Transport{Retry: transport.DefaultRetryPolicy(), Breaker: transport.NewBreaker("test")}
+	t.Retry.BaseDelay = 0
+	return &http.Client{Transport: t}
+}
+
+func newTestMarketOverviewService(moversOK, globalOK bool) (*MarketOverviewService, func()) {
+	avServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !moversOK {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(testMoversResponse))
+	}))
+	cgServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !globalOK {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(testGlobalResponse))
+	}))
+
+	alphaVantage := NewAlphaVantageService()
+	alphaVantage.BaseURL = avServer.URL
+	alphaVantage.HTTPClient = newTestHTTPClient()
+	coinGecko := NewCoinGeckoService()
+	coinGecko.BaseURL = cgServer.URL
+	coinGecko.HTTPClient = newTestHTTPClient()
+
+	return NewMarketOverviewService(alphaVantage, coinGecko), func() {
+		avServer.Close()
+		cgServer.Close()
+	}
+}
+
+func TestGetTopGainersLosers(t *testing.T) {
+	service, cleanup := newTestMarketOverviewService(true, true)
+	defer cleanup()
+
+	movers, err := service.AlphaVantage.GetTopGainersLosers(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, movers.TopGainers, 1)
+	assert.Equal(t, "ABCD", movers.TopGainers[0].Symbol)
+	assert.Equal(t, 75.0, movers.TopGainers[0].ChangePercent)
+	assert.Equal(t, int64(1200000), movers.TopGainers[0].Volume)
+	assert.Equal(t, -0.8, movers.TopLosers[0].Change)
+	assert.Equal(t, "SPY", movers.MostActive[0].Symbol)
+}
+
+func TestGetGlobalMarket(t *testing.T) {
+	service, cleanup := newTestMarketOverviewService(true, true)
+	defer cleanup()
+
+	global, err := service.CoinGecko.GetGlobalMarket(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2500000000000.0, global.TotalMarketCap)
+	assert.Equal(t, 90000000000.0, global.TotalVolume24h)
+	assert.Equal(t, 52.1, global.MarketCapPercentage["btc"])
+	assert.Equal(t, 10000, global.ActiveCryptocurrencies)
+}
+
+func TestMarketOverviewCaching(t *testing.T) {
+	service, cleanup := newTestMarketOverviewService(true, true)
+	defer cleanup()
+
+	overview, err := service.GetOverview(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2500000000000.0, overview.TotalMarketCap)
+	assert.Equal(t, 90000000000.0, overview.Volume24h)
+	assert.Len(t, overview.TopGainers, 1)
+
+	// Upstream failures after a successful refresh fall back to the cached overview
+	failing, cleanupFailing := newTestMarketOverviewService(false, false)
+	defer cleanupFailing()
+	service.AlphaVantage = failing.AlphaVantage
+	service.CoinGecko = failing.CoinGecko
+	service.TTL = 0
+	cached, err := service.GetOverview(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, overview, cached)
+}
+
+func TestMarketOverviewPartialRefresh(t *testing.T) {
+	service, cleanup := newTestMarketOverviewService(false, true)
+	defer cleanup()
+
+	overview, err := service.Refresh(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2500000000000.0, overview.TotalMarketCap)
+	assert.Empty(t, overview.TopGainers)
+}
+
+func TestMarketOverviewUnavailable(t *testing.T) {
+	service, cleanup := newTestMarketOverviewService(false, false)
+	defer cleanup()
+
+	overview, err := service.GetOverview(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, overview)
+}
+
+func TestMarketOverviewBackgroundRefresh(t *testing.T) {
+	service, cleanup := newTestMarketOverviewService(true, true)
+	defer cleanup()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	service.Start(ctx, time.Hour)
+
+	assert.Eventually(t, func() bool {
+		service.mu.RLock()
+		defer service.mu.RUnlock()
+		return service.overview != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// Cancelling the context ends refreshing, so it can be started again after Stop
+	cancel()
+	service.Stop()
+	service.Start(context.Background(), time.Hour)
+	service.Stop()
+}
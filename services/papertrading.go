@@ -0,0 +1,383 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"financehub/trading"
+)
+
+// ErrPaperAccountNotFound is returned when a paper trading account does not exist
+var ErrPaperAccountNotFound = errors.New("paper trading account not found")
+
+// PaperAccountRequest opens a paper trading account
+type PaperAccountRequest struct {
+	Name         string           `json:"name"`
+	StartingCash float64          `json:"startingCash"`
+	Settings     trading.Settings `json:"settings"`
+}
+
+// PaperAccountSummary is an account valued at the latest quotes. Warnings name the holdings
+// that could not be quoted and are valued at cost.
+type PaperAccountSummary struct {
+	*trading.Account
+	Valuation trading.Valuation `json:"valuation"`
+	Warnings  []string          `json:"warnings,omitempty"`
+}
+
+// paperTradingData is the persisted state of the paper trading accounts
+type paperTradingData struct {
+	Accounts      []*trading.Account `json:"accounts"`
+	NextAccountID int64              `json:"nextAccountId"`
+}
+
+// quote is a cached price
+type quote struct {
+	price     float64
+	fetchedAt time.Time
+}
+
+// PaperTradingService runs simulated trading accounts. Orders fill against stock quotes from
+// Alpha Vantage and coin prices from CoinGecko, cached for QuoteTTL, and pending orders are
+// evaluated whenever an account's quotes are refreshed. State is persisted as JSON to Path
+// after every change; an empty Path keeps it in memory only.
+type PaperTradingService struct {
+	Path         string
+	AlphaVantage *AlphaVantageService
+	CoinGecko    *CoinGeckoService
+	QuoteTTL     time.Duration
+
+	mu   sync.RWMutex
+	data paperTradingData
+
+	quoteMu sync.Mutex
+	quotes  map[string]quote
+}
+
+// NewPaperTradingService creates a paper trading service, loading accounts from path if present
+func NewPaperTradingService(path string, alphaVantage *AlphaVantageService, coinGecko *CoinGeckoService) (*PaperTradingService, error) {
+	s := &PaperTradingService{
+		Path:         path,
+		AlphaVantage: alphaVantage,
+		CoinGecko:    coinGecko,
+		QuoteTTL:     time.Minute,
+		data:         paperTradingData{NextAccountID: 1},
+		quotes:       make(map[string]quote),
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	if _, err := readJSON(path, &s.data, "paper trading data"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CreateAccount opens an account funded with starting cash
+func (s *PaperTradingService) CreateAccount(req PaperAccountRequest) (*trading.Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := strconv.FormatInt(s.data.NextAccountID, 10)
+	account, err := trading.NewAccount(id, req.Name, req.StartingCash, req.Settings, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.data.Accounts = append(s.data.Accounts, account)
+	s.data.NextAccountID++
+	if err := s.save(); err != nil {
+		s.data.Accounts = s.data.Accounts[:len(s.data.Accounts)-1]
+		s.data.NextAccountID--
+		return nil, err
+	}
+	return account.Clone(), nil
+}
+
+// ListAccounts returns every account without refreshing quotes
+func (s *PaperTradingService) ListAccounts() []trading.Account {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	accounts := make([]trading.Account, 0, len(s.data.Accounts))
+	for _, account := range s.data.Accounts {
+		accounts = append(accounts, *account.Clone())
+	}
+	return accounts
+}
+
+// GetAccount refreshes an account's quotes, fills any triggered pending orders and returns
+// the account with its valuation
+func (s *PaperTradingService) GetAccount(ctx context.Context, id string) (*PaperAccountSummary, error) {
+	prices, warnings, err := s.refreshQuotes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, err := s.processQuotes(id, prices)
+	if err != nil {
+		return nil, err
+	}
+	return &PaperAccountSummary{
+		Account:   account.Clone(),
+		Valuation: account.Value(prices),
+		Warnings:  warnings,
+	}, nil
+}
+
+// DeleteAccount closes an account
+func (s *PaperTradingService) DeleteAccount(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findAccount(id) == nil {
+		return fmt.Errorf("%w: %s", ErrPaperAccountNotFound, id)
+	}
+
+	previous := s.data.Accounts
+	s.data.Accounts = nil
+	for _, account := range previous {
+		if account.ID != id {
+			s.data.Accounts = append(s.data.Accounts, account)
+		}
+	}
+	if err := s.save(); err != nil {
+		s.data.Accounts = previous
+		return err
+	}
+	return nil
+}
+
+// PlaceOrder places an order at the symbol's latest quote. It fills immediately when it is a
+// market order or already triggered, and otherwise waits in the account's order book.
+func (s *PaperTradingService) PlaceOrder(ctx context.Context, id string, req trading.OrderRequest) (*trading.Order, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	if s.account(id) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPaperAccountNotFound, id)
+	}
+	price, err := s.quote(ctx, req.Symbol, req.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(id, func(account *trading.Account) (*trading.Order, error) {
+		return account.PlaceOrder(req, price, time.Now())
+	})
+}
+
+// CancelOrder cancels a pending order
+func (s *PaperTradingService) CancelOrder(id string, orderID int64) (*trading.Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(id, func(account *trading.Account) (*trading.Order, error) {
+		return account.CancelOrder(orderID, time.Now())
+	})
+}
+
+// Refresh evaluates an account's pending orders at the latest quotes and returns the resulting trades
+func (s *PaperTradingService) Refresh(ctx context.Context, id string) ([]trading.Trade, error) {
+	prices, _, err := s.refreshQuotes(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	before := 0
+	if account := s.findAccount(id); account != nil {
+		before = len(account.Trades)
+	}
+	account, err := s.processQuotes(id, prices)
+	if err != nil {
+		return nil, err
+	}
+	return append([]trading.Trade{}, account.Trades[before:]...), nil
+}
+
+// RefreshAll evaluates the pending orders of every account and returns the number of trades
+func (s *PaperTradingService) RefreshAll(ctx context.Context) (int, error) {
+	var errs []error
+	filled := 0
+	for _, account := range s.ListAccounts() {
+		trades, err := s.Refresh(ctx, account.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		filled += len(trades)
+	}
+	return filled, errors.Join(errs...)
+}
+
+// PruneQuotes drops cached quotes older than QuoteTTL and returns how many were dropped
+func (s *PaperTradingService) PruneQuotes() int {
+	s.quoteMu.Lock()
+	defer s.quoteMu.Unlock()
+
+	pruned := 0
+	for key, cached := range s.quotes {
+		if time.Since(cached.fetchedAt) >= s.QuoteTTL {
+			delete(s.quotes, key)
+			pruned++
+		}
+	}
+	return pruned
+}
+
+// refreshQuotes quotes an account's holdings and the symbols of its pending orders. Symbols
+// that cannot be quoted are reported as warnings and left out of the prices.
+func (s *PaperTradingService) refreshQuotes(ctx context.Context, id string) (map[string]float64, []string, error) {
+	account := s.account(id)
+	if account == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrPaperAccountNotFound, id)
+	}
+
+	symbols := account.PendingSymbols()
+	for _, position := range account.Positions {
+		symbols[position.Symbol] = position.Source
+	}
+
+	prices := make(map[string]float64, len(symbols))
+	var warnings []string
+	for symbol, source := range symbols {
+		price, err := s.quote(ctx, symbol, source)
+		if err != nil {
+			warnings = append(warnings, err.Error())
+			continue
+		}
+		prices[symbol] = price
+	}
+	return prices, warnings, nil
+}
+
+// processQuotes fills an account's triggered pending orders and saves any trades.
+// Callers must hold the write lock.
+func (s *PaperTradingService) processQuotes(id string, prices map[string]float64) (*trading.Account, error) {
+	account := s.findAccount(id)
+	if account == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPaperAccountNotFound, id)
+	}
+
+	previous := account.Clone()
+	account.ProcessQuotes(prices, time.Now())
+	// Pending orders are only ever closed here, by filling or by rejecting them
+	if pendingOrders(account) == pendingOrders(previous) {
+		return account, nil
+	}
+	if err := s.save(); err != nil {
+		*account = *previous
+		return nil, err
+	}
+	return account, nil
+}
+
+// update applies an order change to an account and saves it, restoring the account if
+// the change fails or cannot be saved. Callers must hold the write lock.
+func (s *PaperTradingService) update(id string, change func(*trading.Account) (*trading.Order, error)) (*trading.Order, error) {
+	account := s.findAccount(id)
+	if account == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPaperAccountNotFound, id)
+	}
+
+	previous := account.Clone()
+	order, err := change(account)
+	if err != nil {
+		*account = *previous
+		return nil, err
+	}
+	if err := s.save(); err != nil {
+		*account = *previous
+		return nil, err
+	}
+	placed := *order
+	return &placed, nil
+}
+
+// quote returns the latest price of a stock or coin, fetching it when the cached price is older than QuoteTTL
+func (s *PaperTradingService) quote(ctx context.Context, symbol, source string) (float64, error) {
+	key := source + ":" + symbol
+	s.quoteMu.Lock()
+	cached, ok := s.quotes[key]
+	s.quoteMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < s.QuoteTTL {
+		return cached.price, nil
+	}
+
+	var price float64
+	if source == trading.SourceCrypto {
+		coin, err := s.CoinGecko.GetCryptoPrice(ctx, symbol)
+		if err != nil {
+			return 0, fmt.Errorf("failed to quote %s: %w", symbol, err)
+		}
+		price = coin.CurrentPrice
+	} else {
+		stock, err := s.AlphaVantage.GetStockQuote(ctx, symbol)
+		if err != nil {
+			return 0, fmt.Errorf("failed to quote %s: %w", symbol, err)
+		}
+		price = stock.Price
+	}
+	if price <= 0 {
+		return 0, fmt.Errorf("failed to quote %s: no price available", symbol)
+	}
+
+	s.quoteMu.Lock()
+	s.quotes[key] = quote{price: price, fetchedAt: time.Now()}
+	s.quoteMu.Unlock()
+	return price, nil
+}
+
+// account returns a copy of an account, or nil
+func (s *PaperTradingService) account(id string) *trading.Account {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if account := s.findAccount(id); account != nil {
+		return account.Clone()
+	}
+	return nil
+}
+
+func (s *PaperTradingService) findAccount(id string) *trading.Account {
+	for _, account := range s.data.Accounts {
+		if account.ID == id {
+			return account
+		}
+	}
+	return nil
+}
+
+// pendingOrders counts an account's pending orders
+func pendingOrders(account *trading.Account) int {
+	pending := 0
+	for _, order := range account.Orders {
+		if order.Status == trading.StatusPending {
+			pending++
+		}
+	}
+	return pending
+}
+
+// save writes the current state to Path atomically. Callers must hold the write lock.
+func (s *PaperTradingService) save() error {
+	if s.Path == "" {
+		return nil
+	}
+
+	return writeJSONAtomic(s.Path, s.data, "paper trading data")
+}
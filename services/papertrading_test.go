@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"financehub/trading"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaperTrading(t *testing.T) {
+	stockPrice, coinPrice := 100.0, 50000.0
+	avServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"Global Quote":{"01. symbol":"%s","05. price":"%g"}}`, r.URL.Query().Get("symbol"), stockPrice)
+	}))
+	defer avServer.Close()
+	cgServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"id":"bitcoin","current_price":%g}]`, coinPrice)
+	}))
+	defer cgServer.Close()
+
+	alphaVantage := NewAlphaVantageService()
+	alphaVantage.BaseURL = avServer.URL
+	coinGecko := NewCoinGeckoService()
+	coinGecko.BaseURL = cgServer.URL
+	path := filepath.Join(t.TempDir(), "data", "paper_trading.json")
+	service, err := NewPaperTradingService(path, alphaVantage, coinGecko)
+	assert.NoError(t, err)
+	service.QuoteTTL = 0
+
+	account, err := service.CreateAccount(PaperAccountRequest{Name: "Practice", StartingCash: 10000})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", account.ID)
+
+	order, err := service.PlaceOrder(context.Background(), account.ID, trading.OrderRequest{Symbol: "aapl", Side: trading.Buy, Quantity: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, trading.StatusFilled, order.Status)
+
+	stop, err := service.PlaceOrder(context.Background(), account.ID, trading.OrderRequest{Symbol: "AAPL", Side: trading.Sell, Type: trading.Stop, Quantity: 10, StopPrice: 90})
+	assert.NoError(t, err)
+	assert.Equal(t, trading.StatusPending, stop.Status)
+	limit, err := service.PlaceOrder(context.Background(), account.ID, trading.OrderRequest{Symbol: "bitcoin", Source: trading.SourceCrypto, Side: trading.Buy, Type: trading.Limit, Quantity: 0.1, LimitPrice: 40000})
+	assert.NoError(t, err)
+	_, err = service.CancelOrder(account.ID, limit.ID)
+	assert.NoError(t, err)
+
+	stockPrice = 88
+	trades, err := service.Refresh(context.Background(), account.ID)
+	assert.NoError(t, err)
+	assert.Len(t, trades, 1)
+	assert.Equal(t, stop.ID, trades[0].OrderID)
+
+	reloaded, err := NewPaperTradingService(path, alphaVantage, coinGecko)
+	assert.NoError(t, err)
+	summary, err := reloaded.GetAccount(context.Background(), account.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 9880.0, summary.Cash)
+	assert.Equal(t, -120.0, summary.Valuation.RealizedGain)
+	assert.Len(t, summary.Orders, 3)
+	assert.Equal(t, trading.StatusCancelled, summary.Orders[2].Status)
+
+	_, err = reloaded.PlaceOrder(context.Background(), "9", trading.OrderRequest{Symbol: "AAPL", Side: trading.Buy, Quantity: 1})
+	assert.ErrorIs(t, err, ErrPaperAccountNotFound)
+	assert.NoError(t, reloaded.DeleteAccount(account.ID))
+	assert.Empty(t, reloaded.ListAccounts())
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"financehub/portfolio"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testDailySeries = map[string]string{
+	"AAA": `{"Time Series (Daily)":{
+		"2024-01-05":{"1. open":"100","2. high":"110","3. low":"99","4. close":"108.9","5. volume":"1000"},
+		"2024-01-02":{"1. open":"100","2. high":"100","3. low":"100","4. close":"100","5. volume":"1000"},
+		"2024-01-04":{"1. open":"100","2. high":"100","3. low":"99","4. close":"99","5. volume":"1000"},
+		"2024-01-03":{"1. open":"100","2. high":"110","3. low":"100","4. close":"110","5. volume":"1000"}}}`,
+	"SPY": `{"Time Series (Daily)":{
+		"2024-01-02":{"4. close":"400"},
+		"2024-01-05":{"4. close":"420"}}}`,
+}
+
+func TestGetTimeSeriesDailyOrder(t *testing.T) {
+	service, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.Query().Get("outputsize"))
+		w.Write([]byte(testDailySeries["AAA"]))
+	})
+	defer server.Close()
+
+	series, err := service.GetTimeSeriesDaily(context.Background(), "AAA", 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, series, 3)
+	assert.Equal(t, "2024-01-05", series[0].Date)
+	assert.Equal(t, "2024-01-03", series[2].Date)
+}
+
+func TestGetPortfolioPerformance(t *testing.T) {
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "full", r.URL.Query().Get("outputsize"))
+		w.Write([]byte(testDailySeries[r.URL.Query().Get("symbol")]))
+	})
+	defer server.Close()
+	service := NewPortfolioService(NewTimeSeriesStore("", alphaVantage, nil), nil)
+
+	perf, err := service.GetPerformance(context.Background(), portfolio.PerformanceRequest{
+		Transactions: []portfolio.Transaction{
+			{Date: "2024-01-02", Symbol: "AAA", Type: portfolio.Buy, Quantity: 10, Price: 100},
+			{Date: "2024-01-04", Symbol: "AAA", Type: portfolio.Buy, Quantity: 10, Price: 99},
+		},
+		Benchmark: "SPY",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.089, perf.TimeWeightedReturn)
+	assert.Equal(t, 0.05, perf.Benchmark.Return)
+	assert.Len(t, perf.Series, 4)
+}
+
+func TestGetPortfolioPerformanceMissingPrices(t *testing.T) {
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"Error Message":"Invalid API call."}`))
+	})
+	defer server.Close()
+	service := NewPortfolioService(NewTimeSeriesStore("", alphaVantage, nil), nil)
+
+	perf, err := service.GetPerformance(context.Background(), portfolio.PerformanceRequest{
+		Transactions: []portfolio.Transaction{{Date: "2024-01-02", Symbol: "ZZZ", Type: portfolio.Buy, Quantity: 1, Price: 1}},
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, perf)
+}
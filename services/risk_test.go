@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"financehub/risk"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Daily bitcoin prices at midnight UTC from 2024-01-02 to 2024-01-06, plus an intraday update on the 6th
+const testMarketChartResponse = `{
+	"prices":[[1704153600000,40000],[1704240000000,38000],[1704326400000,41800],[1704412800000,39710],
+		[1704499200000,39000],[1704531600000,40000]],
+	"total_volumes":[[1704153600000,25000000000]]
+}`
+
+func TestGetDailyPrices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/coins/bitcoin/market_chart", r.URL.Path)
+		assert.Equal(t, "daily", r.URL.Query().Get("interval"))
+		w.Write([]byte(testMarketChartResponse))
+	}))
+	defer server.Close()
+	service := NewCoinGeckoService()
+	service.BaseURL = server.URL
+
+	series, err := service.GetDailyPrices(context.Background(), "bitcoin", 5)
+
+	assert.NoError(t, err)
+	assert.Len(t, series, 5)
+	assert.Equal(t, "2024-01-06", series[0].Date)
+	assert.Equal(t, 40000.0, series[0].Close)
+	assert.Equal(t, int64(25000000000), series[4].Volume)
+}
+
+func TestRiskAnalyze(t *testing.T) {
+	alphaVantage, avServer := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testDailySeries[r.URL.Query().Get("symbol")]))
+	})
+	defer avServer.Close()
+	cgServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testMarketChartResponse))
+	}))
+	defer cgServer.Close()
+	coinGecko := NewCoinGeckoService()
+	coinGecko.BaseURL = cgServer.URL
+	service := NewRiskService(NewTimeSeriesStore("", alphaVantage, coinGecko))
+
+	report, err := service.Analyze(context.Background(), risk.Request{
+		Holdings: []risk.Holding{
+			{Symbol: "AAA"},
+			{Symbol: "bitcoin", Source: risk.SourceCrypto},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Observations)
+	assert.Equal(t, "2024-01-05", report.EndDate)
+	assert.Equal(t, -1.0, report.Correlation.Values[0][1])
+	assert.Equal(t, 0.0, report.Portfolio.MaxDrawdown)
+
+	_, err = service.Analyze(context.Background(), risk.Request{Holdings: []risk.Holding{{Symbol: "AAA"}}, Benchmark: "SPY"})
+	assert.ErrorIs(t, err, risk.ErrInvalidRequest)
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"financehub/models"
+)
+
+// Background synchronization job schedules, in local time
+const (
+	QuotesSchedule      = "*/15 9-16 * * 1-5"
+	PaperOrdersSchedule = "*/15 * * * *"
+	HistorySchedule     = "30 18 * * *"
+	RatesSchedule       = "0 */4 * * *"
+	PruneSchedule       = "0 3 * * *"
+)
+
+// How long synchronized quotes and exchange rates are served before they are fetched again. Each
+// outlasts the interval between its job's runs plus the time a run takes, so that requests are
+// served from the cache until the next run refreshes it.
+const (
+	syncedQuoteTTL = 20 * time.Minute
+	syncedRateTTL  = 4*time.Hour + 15*time.Minute
+)
+
+// watchlistHistoryBars is how much history is backfilled for watchlist symbols that have not been stored
+const watchlistHistoryBars = 504
+
+// syncedQuote is a quote refreshed in the background
+type syncedQuote struct {
+	quote     models.StockQuote
+	fetchedAt time.Time
+}
+
+// syncedRate is an exchange rate refreshed in the background
+type syncedRate struct {
+	rate      models.CurrencyRate
+	fetchedAt time.Time
+}
+
+// SyncService keeps watchlist quotes, stored price history and exchange rates up to date in the
+// background so requests can be served without waiting on the upstream APIs. Watchlist holds stock
+// symbols and Currencies holds FROM/TO pairs.
+type SyncService struct {
+	AlphaVantage *AlphaVantageService
+	Prices       *TimeSeriesStore
+	PaperTrading *PaperTradingService
+	Watchlist    []string
+	Currencies   []string
+	QuoteTTL     time.Duration
+	RateTTL      time.Duration
+
+	mu     sync.RWMutex
+	quotes map[string]syncedQuote
+	rates  map[string]syncedRate
+}
+
+// NewSyncService creates a sync service for the major currency pairs and an empty watchlist
+func NewSyncService(alphaVantage *AlphaVantageService, prices *TimeSeriesStore, paperTrading *PaperTradingService) *SyncService {
+	return &SyncService{
+		AlphaVantage: alphaVantage,
+		Prices:       prices,
+		PaperTrading: paperTrading,
+		Currencies:   []string{"EUR/USD", "GBP/USD", "USD/JPY"},
+		QuoteTTL:     syncedQuoteTTL,
+		RateTTL:      syncedRateTTL,
+		quotes:       make(map[string]syncedQuote),
+		rates:        make(map[string]syncedRate),
+	}
+}
+
+// ParseList splits a comma-separated list of symbols or currency pairs, upper-casing each entry
+func ParseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToUpper(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Register adds the synchronization jobs to a scheduler
+func (s *SyncService) Register(scheduler *JobScheduler) error {
+	jobs := []struct {
+		name, description, schedule string
+		run                         func(context.Context) error
+	}{
+		{"refresh-quotes", "Refresh watchlist quotes", QuotesSchedule, s.RefreshQuotes},
+		{"process-paper-orders", "Fill triggered paper trading orders", PaperOrdersSchedule, s.ProcessPaperOrders},
+		{"backfill-history", "Fetch the latest daily bars of the watchlist and stored series", HistorySchedule, s.BackfillHistory},
+		{"update-fx-rates", "Update currency exchange rates", RatesSchedule, s.UpdateRates},
+		{"prune-caches", "Drop expired quotes and rates and release stored series from memory", PruneSchedule, s.PruneCaches},
+	}
+	for _, job := range jobs {
+		if err := scheduler.Register(job.name, job.description, job.schedule, job.run); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Quote returns a watchlist quote refreshed within QuoteTTL
+func (s *SyncService) Quote(symbol string) (*models.StockQuote, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cached, ok := s.quotes[strings.ToUpper(symbol)]
+	if !ok || time.Since(cached.fetchedAt) >= s.QuoteTTL {
+		return nil, false
+	}
+	quote := cached.quote
+	return &quote, true
+}
+
+// Rate returns an exchange rate refreshed within RateTTL
+func (s *SyncService) Rate(from, to string) (*models.CurrencyRate, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cached, ok := s.rates[strings.ToUpper(from+"/"+to)]
+	if !ok || time.Since(cached.fetchedAt) >= s.RateTTL {
+		return nil, false
+	}
+	rate := cached.rate
+	return &rate, true
+}
+
+// RefreshQuotes fetches a quote for every watchlist symbol
+func (s *SyncService) RefreshQuotes(ctx context.Context) error {
+	var errs []error
+	for _, symbol := range s.Watchlist {
+		quote, err := s.AlphaVantage.GetStockQuote(ctx, symbol)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to quote %s: %w", symbol, err))
+			continue
+		}
+		s.mu.Lock()
+		s.quotes[symbol] = syncedQuote{quote: *quote, fetchedAt: time.Now()}
+		s.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// ProcessPaperOrders fills the paper trading orders triggered at the latest quotes
+func (s *SyncService) ProcessPaperOrders(ctx context.Context) error {
+	if s.PaperTrading == nil {
+		return nil
+	}
+	filled, err := s.PaperTrading.RefreshAll(ctx)
+	if filled > 0 {
+		slog.InfoContext(ctx, "Filled paper trading orders", "orders", filled)
+	}
+	return err
+}
+
+// BackfillHistory fetches the bars missing from every stored series and stores the history of
+// watchlist symbols that have not been stored yet
+func (s *SyncService) BackfillHistory(ctx context.Context) error {
+	series, err := s.Prices.List()
+	if err != nil {
+		return err
+	}
+
+	stored := make(map[string]bool, len(series))
+	var errs []error
+	for _, info := range series {
+		stored[info.Source+"/"+info.Symbol] = true
+		if err := s.Prices.Refresh(ctx, info.Symbol, info.Source, info.Bars); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, symbol := range s.Watchlist {
+		if stored[PriceSourceStock+"/"+symbol] {
+			continue
+		}
+		if err := s.Prices.Refresh(ctx, symbol, PriceSourceStock, watchlistHistoryBars); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UpdateRates fetches the exchange rate of every currency pair
+func (s *SyncService) UpdateRates(ctx context.Context) error {
+	var errs []error
+	for _, pair := range s.Currencies {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok {
+			errs = append(errs, fmt.Errorf("invalid currency pair %q, expected FROM/TO", pair))
+			continue
+		}
+		rate, err := s.AlphaVantage.GetCurrencyExchangeRate(ctx, from, to)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to update %s rate: %w", pair, err))
+			continue
+		}
+		s.mu.Lock()
+		s.rates[pair] = syncedRate{rate: *rate, fetchedAt: time.Now()}
+		s.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// PruneCaches drops expired quotes and rates and releases stored series from memory
+func (s *SyncService) PruneCaches(ctx context.Context) error {
+	s.mu.Lock()
+	pruned := 0
+	for symbol, cached := range s.quotes {
+		if time.Since(cached.fetchedAt) >= s.QuoteTTL {
+			delete(s.quotes, symbol)
+			pruned++
+		}
+	}
+	for pair, cached := range s.rates {
+		if time.Since(cached.fetchedAt) >= s.RateTTL {
+			delete(s.rates, pair)
+			pruned++
+		}
+	}
+	s.mu.Unlock()
+
+	if s.PaperTrading != nil {
+		pruned += s.PaperTrading.PruneQuotes()
+	}
+	pruned += s.Prices.Prune()
+	slog.InfoContext(ctx, "Pruned cached entries", "entries", pruned)
+	return nil
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"financehub/schedule"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncService(t *testing.T) {
+	var functions []string
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		functions = append(functions, query.Get("function"))
+		switch query.Get("function") {
+		case "GLOBAL_QUOTE":
+			w.Write([]byte(`{"Global Quote":{"01. symbol":"AAA","05. price":"108.90"}}`))
+		case "CURRENCY_EXCHANGE_RATE":
+			if query.Get("to_currency") == "XXX" {
+				w.Write([]byte(`{"Error Message":"Invalid API call."}`))
+				return
+			}
+			w.Write([]byte(`{"Realtime Currency Exchange Rate":{"1. From_Currency Code":"EUR",
+				"3. To_Currency Code":"USD","5. Exchange Rate":"1.0850"}}`))
+		default:
+			w.Write([]byte(testDailySeries[query.Get("symbol")]))
+		}
+	})
+	defer server.Close()
+
+	dataSync := NewSyncService(alphaVantage, NewTimeSeriesStore("", alphaVantage, nil), nil)
+	dataSync.Watchlist = ParseList(" aaa, ,spy ")
+	dataSync.Currencies = ParseList("eur/usd,EUR/XXX")
+	assert.Equal(t, []string{"AAA", "SPY"}, dataSync.Watchlist)
+
+	_, ok := dataSync.Quote("AAA")
+	assert.False(t, ok)
+	assert.NoError(t, dataSync.RefreshQuotes(context.Background()))
+	quote, ok := dataSync.Quote("aaa")
+	assert.True(t, ok)
+	assert.Equal(t, 108.9, quote.Price)
+
+	err := dataSync.UpdateRates(context.Background())
+	assert.ErrorContains(t, err, "EUR/XXX")
+	rate, ok := dataSync.Rate("eur", "usd")
+	assert.True(t, ok)
+	assert.Equal(t, 1.085, rate.Rate)
+
+	// Watchlist history is stored for offline use
+	assert.NoError(t, dataSync.BackfillHistory(context.Background()))
+	series, _ := dataSync.Prices.List()
+	assert.Len(t, series, 2)
+	assert.Equal(t, 4, series[0].Bars)
+
+	dataSync.QuoteTTL = 0
+	dataSync.RateTTL = 0
+	_, ok = dataSync.Quote("AAA")
+	assert.False(t, ok)
+	_, ok = dataSync.Rate("EUR", "USD")
+	assert.False(t, ok)
+	assert.NoError(t, dataSync.PruneCaches(context.Background()))
+	assert.Empty(t, dataSync.quotes)
+	assert.Empty(t, dataSync.rates)
+	assert.Equal(t, []string{"GLOBAL_QUOTE", "GLOBAL_QUOTE", "CURRENCY_EXCHANGE_RATE", "CURRENCY_EXCHANGE_RATE",
+		"TIME_SERIES_DAILY", "TIME_SERIES_DAILY"}, functions)
+}
+
+func TestSyncServiceServesBetweenRuns(t *testing.T) {
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("function") == "GLOBAL_QUOTE" {
+			w.Write([]byte(`{"Global Quote":{"01. symbol":"AAA","05. price":"108.90"}}`))
+			return
+		}
+		w.Write([]byte(`{"Realtime Currency Exchange Rate":{"1. From_Currency Code":"EUR",
+			"3. To_Currency Code":"USD","5. Exchange Rate":"1.0850"}}`))
+	})
+	defer server.Close()
+
+	dataSync := NewSyncService(alphaVantage, NewTimeSeriesStore("", alphaVantage, nil), nil)
+	dataSync.Watchlist = []string{"AAA"}
+	dataSync.Currencies = []string{"EUR/USD"}
+	assert.NoError(t, dataSync.RefreshQuotes(context.Background()))
+	assert.NoError(t, dataSync.UpdateRates(context.Background()))
+
+	// interval is the time between two runs of a job during market hours
+	interval := func(spec string) time.Duration {
+		s, err := schedule.Parse(spec)
+		assert.NoError(t, err)
+		run := s.Next(time.Date(2024, 1, 2, 10, 0, 0, 0, time.Local))
+		return s.Next(run).Sub(run)
+	}
+
+	// Entries fetched by one run are still served when the next run starts and while it takes a minute
+	dataSync.mu.Lock()
+	quote := dataSync.quotes["AAA"]
+	quote.fetchedAt = time.Now().Add(-interval(QuotesSchedule) - time.Minute)
+	dataSync.quotes["AAA"] = quote
+	rate := dataSync.rates["EUR/USD"]
+	rate.fetchedAt = time.Now().Add(-interval(RatesSchedule) - time.Minute)
+	dataSync.rates["EUR/USD"] = rate
+	dataSync.mu.Unlock()
+
+	_, ok := dataSync.Quote("AAA")
+	assert.True(t, ok)
+	_, ok = dataSync.Rate("EUR", "USD")
+	assert.True(t, ok)
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRecentSeries builds an Alpha Vantage daily series with closes for the given days before today
+func testRecentSeries(closes map[int]float64) string {
+	body := `{"Time Series (Daily)":{`
+	first := true
+	for daysAgo, close := range closes {
+		if !first {
+			body += ","
+		}
+		first = false
+		date := time.Now().UTC().AddDate(0, 0, -daysAgo).Format("2006-01-02")
+		body += fmt.Sprintf(`"%s":{"1. open":"%g","4. close":"%g"}`, date, close, close)
+	}
+	return body + "}}"
+}
+
+func TestTimeSeriesStoreServesStoredBars(t *testing.T) {
+	calls := 0
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "AAA", r.URL.Query().Get("symbol"))
+		w.Write([]byte(testDailySeries["AAA"]))
+	})
+	defer server.Close()
+	dir := t.TempDir()
+
+	store := NewTimeSeriesStore(dir, alphaVantage, nil)
+	bars, err := store.Daily(context.Background(), "aaa", PriceSourceStock, 3)
+	assert.NoError(t, err)
+	assert.Len(t, bars, 3)
+	assert.Equal(t, "2024-01-05", bars[0].Date)
+	assert.Equal(t, 1, calls)
+
+	// Fresh series are served from memory, and from disk after a restart
+	bars, err = store.Daily(context.Background(), "AAA", PriceSourceStock, 2)
+	assert.NoError(t, err)
+	assert.Len(t, bars, 2)
+	reloaded := NewTimeSeriesStore(dir, alphaVantage, nil)
+	bars, err = reloaded.Daily(context.Background(), "AAA", PriceSourceStock, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-03", bars[2].Date)
+	assert.Equal(t, 1, calls)
+
+	series, err := NewTimeSeriesStore(dir, nil, nil).List()
+	assert.NoError(t, err)
+	assert.Len(t, series, 1)
+	assert.Equal(t, "AAA", series[0].Symbol)
+	assert.Equal(t, PriceSourceStock, series[0].Source)
+	assert.Equal(t, 3, series[0].Bars)
+	assert.Equal(t, "2024-01-03", series[0].FirstDate)
+	assert.Equal(t, "2024-01-05", series[0].LastDate)
+
+	// Longer requests than the stored series fetch again
+	_, err = reloaded.Daily(context.Background(), "AAA", PriceSourceStock, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestTimeSeriesStoreIncrementalBackfill(t *testing.T) {
+	var outputSizes []string
+	responses := []string{
+		testRecentSeries(map[int]float64{3: 100, 2: 101, 1: 102}),
+		testRecentSeries(map[int]float64{1: 103, 0: 104}),
+		`{"Note":"API call frequency exceeded"}`,
+	}
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		outputSizes = append(outputSizes, r.URL.Query().Get("outputsize"))
+		w.Write([]byte(responses[min(len(outputSizes), len(responses))-1]))
+	})
+	defer server.Close()
+
+	store := NewTimeSeriesStore("", alphaVantage, nil)
+	store.MaxAge = 0
+	_, err := store.Daily(context.Background(), "AAA", PriceSourceStock, 500)
+	assert.NoError(t, err)
+
+	// The full history is stored, so a stale series only needs the compact recent bars
+	bars, err := store.Daily(context.Background(), "AAA", PriceSourceStock, 500)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"full", ""}, outputSizes)
+	assert.Len(t, bars, 4)
+	assert.Equal(t, 104.0, bars[0].Close)
+	// The revised close replaces the stored one
+	assert.Equal(t, 103.0, bars[1].Close)
+	assert.Equal(t, 100.0, bars[3].Close)
+
+	// Failed refreshes serve the stored bars
+	bars, err = store.Daily(context.Background(), "AAA", PriceSourceStock, 2)
+	assert.NoError(t, err)
+	assert.Len(t, outputSizes, 3)
+	assert.Empty(t, outputSizes[2])
+	assert.Len(t, bars, 2)
+	assert.Equal(t, 104.0, bars[0].Close)
+
+	_, err = store.Daily(context.Background(), "BBB", PriceSourceStock, 2)
+	assert.Error(t, err)
+}
+
+func TestTimeSeriesStoreStaleBackfill(t *testing.T) {
+	// The stored bars end longer ago than compact requests reach, so the full history is fetched
+	// whether or not the stored series covers the request
+	for _, limit := range []int{3, 30} {
+		t.Run(fmt.Sprintf("limit %d", limit), func(t *testing.T) {
+			var outputSizes []string
+			alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+				outputSizes = append(outputSizes, r.URL.Query().Get("outputsize"))
+				closes := map[int]float64{202: 100, 201: 101, 200: 102}
+				if len(outputSizes) > 1 {
+					// Compact requests only return the newest bars
+					days := compactBars
+					if r.URL.Query().Get("outputsize") == "full" {
+						days = 203
+					}
+					closes = map[int]float64{}
+					for daysAgo := 0; daysAgo < days; daysAgo++ {
+						closes[daysAgo] = float64(200 - daysAgo)
+					}
+				}
+				w.Write([]byte(testRecentSeries(closes)))
+			})
+			defer server.Close()
+
+			store := NewTimeSeriesStore("", alphaVantage, nil)
+			store.MaxAge = 0
+			_, err := store.Daily(context.Background(), "AAA", PriceSourceStock, 3)
+			assert.NoError(t, err)
+
+			bars, err := store.Daily(context.Background(), "AAA", PriceSourceStock, limit)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"", "full"}, outputSizes)
+			assert.Len(t, bars, limit)
+
+			series, err := store.load(PriceSourceStock, "AAA", IntervalDaily)
+			assert.NoError(t, err)
+			assert.Len(t, series.Bars, 203)
+			for i := 1; i < len(series.Bars); i++ {
+				previous, _ := time.Parse("2006-01-02", series.Bars[i-1].Date)
+				date, _ := time.Parse("2006-01-02", series.Bars[i].Date)
+				assert.Equal(t, 24*time.Hour, date.Sub(previous), "gap after %s", series.Bars[i-1].Date)
+			}
+		})
+	}
+}
+
+func TestTimeSeriesStoreCrypto(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	var days []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/coins/bitcoin/market_chart", r.URL.Path)
+		days = append(days, r.URL.Query().Get("days"))
+		fmt.Fprintf(w, `{"prices":[[%d,40000],[%d,41000],[%d,42000]]}`,
+			today.AddDate(0, 0, -2).UnixMilli(), today.AddDate(0, 0, -1).UnixMilli(), today.UnixMilli())
+	}))
+	defer server.Close()
+	coinGecko := NewCoinGeckoService()
+	coinGecko.BaseURL = server.URL
+
+	store := NewTimeSeriesStore("", nil, coinGecko)
+	store.MaxAge = 0
+	_, err := store.Daily(context.Background(), "Bitcoin", PriceSourceCrypto, 3)
+	assert.NoError(t, err)
+
+	// Only the days since the last stored bar are fetched
+	bars, err := store.Daily(context.Background(), "bitcoin", PriceSourceCrypto, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"3", "2"}, days)
+	assert.Len(t, bars, 3)
+	assert.Equal(t, 42000.0, bars[0].Close)
+}
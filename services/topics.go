@@ -154,6 +154,7 @@ func (s *TopicsService) GetAllTopics() []models.FinanceTopic {
 				"https://www.imf.org/",
 				"https://www.worldbank.org/",
 			},
+			Indicators: []string{"real-gdp", "cpi", "inflation", "federal-funds-rate", "treasury-yield", "unemployment", "retail-sales"},
 		},
 	}
 }
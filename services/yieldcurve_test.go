@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"financehub/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testYieldObservations() map[string][]models.EconomicDataPoint {
+	return map[string][]models.EconomicDataPoint{
+		"3M":  {{Date: "2024-01-03", Value: 5.40}, {Date: "2024-01-02", Value: 5.42}},
+		"2Y":  {{Date: "2024-01-03", Value: 4.33}, {Date: "2024-01-02", Value: 4.30}},
+		"5Y":  {{Date: "2024-01-03", Value: 3.94}, {Date: "2024-01-02", Value: 3.93}},
+		"7Y":  {{Date: "2024-01-03", Value: 3.95}, {Date: "2024-01-02", Value: 3.94}},
+		"10Y": {{Date: "2024-01-03", Value: 3.91}, {Date: "2024-01-02", Value: 3.95}},
+		"30Y": {{Date: "2024-01-03", Value: 4.05}, {Date: "2024-01-02", Value: 4.08}},
+	}
+}
+
+func TestBuildYieldCurve(t *testing.T) {
+	curve, err := BuildYieldCurve("", testYieldObservations())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-03", curve.Date)
+	assert.Len(t, curve.Points, 9, "Six observed and three interpolated tenors")
+	assert.Equal(t, "3M", curve.Points[0].Tenor)
+	assert.Equal(t, "30Y", curve.Points[len(curve.Points)-1].Tenor)
+	assert.True(t, curve.Inverted)
+	assert.Equal(t, "inverted", curve.Shape)
+
+	spreads := map[string]models.YieldSpread{}
+	for _, spread := range curve.Spreads {
+		spreads[spread.Name] = spread
+	}
+	assert.Equal(t, -0.42, spreads["10Y-2Y"].Value)
+	assert.Equal(t, -42.0, spreads["10Y-2Y"].BasisPoints)
+	assert.True(t, spreads["10Y-2Y"].Inverted)
+	assert.Equal(t, 0.11, spreads["30Y-5Y"].Value)
+	assert.False(t, spreads["30Y-5Y"].Inverted)
+}
+
+func TestBuildYieldCurveForDate(t *testing.T) {
+	curve, err := BuildYieldCurve("2024-01-02", testYieldObservations())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-02", curve.Date)
+	assert.Equal(t, 5.42, curve.Points[0].Yield)
+	assert.Equal(t, "2024-01-02", curve.Points[0].ObservedOn)
+
+	_, err = BuildYieldCurve("2023-12-01", testYieldObservations())
+	assert.True(t, errors.Is(err, ErrNoYieldCurveData))
+}
+
+func TestBuildYieldCurveCommonDate(t *testing.T) {
+	observations := testYieldObservations()
+	// The 30Y yield for 2024-01-03 is not published yet
+	observations["30Y"] = observations["30Y"][1:]
+
+	curve, err := BuildYieldCurve("", observations)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-02", curve.Date)
+	for _, point := range curve.Points {
+		if !point.Interpolated {
+			assert.Equal(t, "2024-01-02", point.ObservedOn, point.Tenor)
+		}
+	}
+}
+
+func TestInterpolateYield(t *testing.T) {
+	points := []models.YieldCurvePoint{
+		{Tenor: "2Y", Years: 2, Yield: 4.0},
+		{Tenor: "5Y", Years: 5, Yield: 4.6},
+		{Tenor: "10Y", Years: 10, Yield: 5.0},
+	}
+
+	tests := []struct {
+		name     string
+		years    float64
+		expected float64
+		ok       bool
+	}{
+		{name: "Between tenors", years: 3, expected: 4.2, ok: true},
+		{name: "On a tenor", years: 5, expected: 4.6, ok: true},
+		{name: "Upper segment", years: 7.5, expected: 4.8, ok: true},
+		{name: "Below range", years: 1, ok: false},
+		{name: "Above range", years: 30, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yield, ok := InterpolateYield(points, tt.years)
+			assert.Equal(t, tt.ok, ok)
+			assert.InDelta(t, tt.expected, yield, 1e-9)
+		})
+	}
+}
+
+func TestGetYieldCurve(t *testing.T) {
+	var requests atomic.Int32
+	alphaVantage, server := newTestAlphaVantageService(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "daily", r.URL.Query().Get("interval"))
+		yields := map[string]string{
+			"3month": "5.25", "2year": "4.75", "5year": "4.40",
+			"7year": "4.35", "10year": "4.30", "30year": "4.45",
+		}
+		w.Write([]byte(`{"data":[{"date":"2024-05-01","value":"` + yields[r.URL.Query().Get("maturity")] + `"}]}`))
+	})
+	defer server.Close()
+	service := NewYieldCurveService(NewEconomicsService(alphaVantage))
+
+	curve, err := service.GetYieldCurve(context.Background(), "", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-05-01", curve.Date)
+	assert.Equal(t, -0.45, curve.Spreads[0].Value)
+	assert.True(t, curve.Inverted)
+
+	// The curve is served from the cache until it is older than the TTL
+	cached, err := service.GetYieldCurve(context.Background(), "", "daily")
+	assert.NoError(t, err)
+	assert.Same(t, curve, cached)
+	assert.Equal(t, int32(6), requests.Load())
+
+	service.TTL = 0
+	_, err = service.GetYieldCurve(context.Background(), "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(12), requests.Load())
+
+	// Expired curves are evicted when another is cached
+	_, err = service.GetYieldCurve(context.Background(), "2024-05-01", "")
+	assert.NoError(t, err)
+	assert.Len(t, service.curves, 1)
+}
+
+func TestYieldCurveCacheLimit(t *testing.T) {
+	service := NewYieldCurveService(nil)
+	now := time.Now()
+	for i := 0; i < maxCachedYieldCurves; i++ {
+		key := fmt.Sprintf("2024-01-%02d|daily", i)
+		service.curves[key] = cachedYieldCurve{curve: &models.YieldCurve{}, updatedAt: now.Add(time.Duration(i) * time.Second)}
+	}
+
+	service.evictCurves(now.Add(time.Minute))
+
+	assert.Len(t, service.curves, maxCachedYieldCurves-1)
+	assert.NotContains(t, service.curves, "2024-01-00|daily")
+	assert.Contains(t, service.curves, "2024-01-01|daily")
+}
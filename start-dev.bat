@@ -6,7 +6,7 @@ echo.
 
 REM Start Backend in new window
 echo Starting Backend server...
-start "FinanceHub Backend" cmd /k "cd backend && go run main.go"
+start "FinanceHub Backend" cmd /k "cd backend && go run ."
 
 REM Wait a bit for backend to start
 timeout /t 3 /nobreak >nul
@@ -0,0 +1,234 @@
+// Package trading simulates paper-trading accounts: market, limit and stop orders filled
+// against quoted prices with commissions and slippage, a book of pending orders evaluated
+// on each price refresh, and a trade history.
+//
+// Accounts hold cash and long positions only; sells cannot exceed the shares held.
+package trading
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidOrder is returned when an order or account setting is missing or out of range
+var ErrInvalidOrder = errors.New("invalid order")
+
+// ErrInsufficientFunds is returned when a buy costs more than the account's buying power
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrInsufficientShares is returned when a sell exceeds the shares held and not already committed to sells
+var ErrInsufficientShares = errors.New("insufficient shares")
+
+// ErrOrderNotFound is returned when an order does not exist in the account
+var ErrOrderNotFound = errors.New("order not found")
+
+// Order sides
+const (
+	Buy  = "buy"
+	Sell = "sell"
+)
+
+// Order types
+const (
+	Market = "market"
+	Limit  = "limit"
+	Stop   = "stop"
+)
+
+// Order statuses
+const (
+	StatusPending   = "pending"
+	StatusFilled    = "filled"
+	StatusCancelled = "cancelled"
+	StatusRejected  = "rejected"
+)
+
+// Quote sources
+const (
+	SourceStock  = "stock"
+	SourceCrypto = "crypto"
+)
+
+// Settings are an account's trading costs. Commission is a flat fee per fill, CommissionRate
+// a fraction of the traded value and Slippage a fraction of the quoted price paid on buys and
+// given up on sells.
+type Settings struct {
+	Commission     float64 `json:"commission"`
+	CommissionRate float64 `json:"commissionRate"`
+	Slippage       float64 `json:"slippage"`
+}
+
+// Position is a holding with its average cost basis, including commissions
+type Position struct {
+	Symbol    string  `json:"symbol"`
+	Source    string  `json:"source"`
+	Quantity  float64 `json:"quantity"`
+	CostBasis float64 `json:"costBasis"`
+}
+
+// Trade is the execution of an order. Amount is the change in cash, negative for buys.
+type Trade struct {
+	ID           int64   `json:"id"`
+	OrderID      int64   `json:"orderId"`
+	Symbol       string  `json:"symbol"`
+	Source       string  `json:"source"`
+	Side         string  `json:"side"`
+	Quantity     float64 `json:"quantity"`
+	Price        float64 `json:"price"`
+	Commission   float64 `json:"commission"`
+	Amount       float64 `json:"amount"`
+	RealizedGain float64 `json:"realizedGain,omitempty"`
+	ExecutedAt   string  `json:"executedAt"`
+}
+
+// Account is a simulated brokerage account
+type Account struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	StartingCash float64    `json:"startingCash"`
+	Cash         float64    `json:"cash"`
+	Settings     Settings   `json:"settings"`
+	Positions    []Position `json:"positions"`
+	Orders       []Order    `json:"orders"`
+	Trades       []Trade    `json:"trades"`
+	NextOrderID  int64      `json:"nextOrderId"`
+	NextTradeID  int64      `json:"nextTradeId"`
+	CreatedAt    string     `json:"createdAt"`
+}
+
+// HoldingValue is a position valued at its latest price
+type HoldingValue struct {
+	Position
+	Price          float64 `json:"price"`
+	MarketValue    float64 `json:"marketValue"`
+	UnrealizedGain float64 `json:"unrealizedGain"`
+}
+
+// Valuation is an account's value at the latest prices. Positions without a price are valued at cost.
+type Valuation struct {
+	Cash           float64        `json:"cash"`
+	MarketValue    float64        `json:"marketValue"`
+	Equity         float64        `json:"equity"`
+	UnrealizedGain float64        `json:"unrealizedGain"`
+	RealizedGain   float64        `json:"realizedGain"`
+	Commissions    float64        `json:"commissions"`
+	TotalReturn    float64        `json:"totalReturn"`
+	Holdings       []HoldingValue `json:"holdings"`
+}
+
+// Validate checks that trading costs are within range
+func (s Settings) Validate() error {
+	switch {
+	case s.Commission < 0 || s.CommissionRate < 0 || s.Slippage < 0:
+		return fmt.Errorf("%w: commissions and slippage cannot be negative", ErrInvalidOrder)
+	case s.CommissionRate >= 1 || s.Slippage >= 1:
+		return fmt.Errorf("%w: commission rate and slippage must be below 1", ErrInvalidOrder)
+	}
+	return nil
+}
+
+// NewAccount opens an account funded with starting cash
+func NewAccount(id, name string, startingCash float64, settings Settings, now time.Time) (*Account, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return nil, fmt.Errorf("%w: account name is required", ErrInvalidOrder)
+	case startingCash <= 0:
+		return nil, fmt.Errorf("%w: starting cash must be positive", ErrInvalidOrder)
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Account{
+		ID:           id,
+		Name:         name,
+		StartingCash: startingCash,
+		Cash:         startingCash,
+		Settings:     settings,
+		Positions:    []Position{},
+		Orders:       []Order{},
+		Trades:       []Trade{},
+		NextOrderID:  1,
+		NextTradeID:  1,
+		CreatedAt:    now.Format(time.RFC3339),
+	}, nil
+}
+
+// Value values the account's positions at prices keyed by symbol
+func (a *Account) Value(prices map[string]float64) Valuation {
+	valuation := Valuation{
+		Cash:     a.Cash,
+		Holdings: make([]HoldingValue, 0, len(a.Positions)),
+	}
+	for _, trade := range a.Trades {
+		valuation.RealizedGain += trade.RealizedGain
+		valuation.Commissions += trade.Commission
+	}
+
+	for _, position := range a.Positions {
+		price, ok := prices[position.Symbol]
+		if !ok {
+			price = position.CostBasis / position.Quantity
+		}
+		holding := HoldingValue{
+			Position:    position,
+			Price:       price,
+			MarketValue: roundTo(position.Quantity*price, 2),
+		}
+		holding.UnrealizedGain = roundTo(holding.MarketValue-position.CostBasis, 2)
+		valuation.MarketValue += holding.MarketValue
+		valuation.UnrealizedGain += holding.UnrealizedGain
+		valuation.Holdings = append(valuation.Holdings, holding)
+	}
+	sort.Slice(valuation.Holdings, func(i, j int) bool {
+		return valuation.Holdings[i].Symbol < valuation.Holdings[j].Symbol
+	})
+
+	valuation.Equity = roundTo(valuation.Cash+valuation.MarketValue, 2)
+	valuation.MarketValue = roundTo(valuation.MarketValue, 2)
+	valuation.UnrealizedGain = roundTo(valuation.UnrealizedGain, 2)
+	valuation.RealizedGain = roundTo(valuation.RealizedGain, 2)
+	valuation.Commissions = roundTo(valuation.Commissions, 2)
+	valuation.TotalReturn = roundTo(valuation.Equity/a.StartingCash-1, 6)
+	return valuation
+}
+
+// Clone returns a copy of the account that shares no positions, orders or trades with it
+func (a *Account) Clone() *Account {
+	clone := *a
+	clone.Positions = append([]Position{}, a.Positions...)
+	clone.Orders = append([]Order{}, a.Orders...)
+	clone.Trades = append([]Trade{}, a.Trades...)
+	return &clone
+}
+
+// findPosition returns the account's position in a symbol, or nil
+func (a *Account) findPosition(symbol string) *Position {
+	for i := range a.Positions {
+		if a.Positions[i].Symbol == symbol {
+			return &a.Positions[i]
+		}
+	}
+	return nil
+}
+
+// removeEmptyPositions drops positions that were sold out
+func (a *Account) removeEmptyPositions() {
+	positions := a.Positions[:0]
+	for _, position := range a.Positions {
+		if position.Quantity > quantityTolerance {
+			positions = append(positions, position)
+		}
+	}
+	a.Positions = positions
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
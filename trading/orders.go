@@ -0,0 +1,296 @@
+package trading
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// quantityTolerance absorbs floating point error when comparing share quantities
+const quantityTolerance = 1e-9
+
+// OrderRequest describes an order to place. Symbols are stock tickers or, for the crypto
+// source, CoinGecko coin IDs. Limit orders need LimitPrice and stop orders need StopPrice.
+type OrderRequest struct {
+	Symbol     string  `json:"symbol"`
+	Source     string  `json:"source,omitempty"`
+	Side       string  `json:"side"`
+	Type       string  `json:"type,omitempty"`
+	Quantity   float64 `json:"quantity"`
+	LimitPrice float64 `json:"limitPrice,omitempty"`
+	StopPrice  float64 `json:"stopPrice,omitempty"`
+}
+
+// Order is an order and its outcome. Reason explains a rejection.
+type Order struct {
+	ID         int64   `json:"id"`
+	Symbol     string  `json:"symbol"`
+	Source     string  `json:"source"`
+	Side       string  `json:"side"`
+	Type       string  `json:"type"`
+	Quantity   float64 `json:"quantity"`
+	LimitPrice float64 `json:"limitPrice,omitempty"`
+	StopPrice  float64 `json:"stopPrice,omitempty"`
+	Status     string  `json:"status"`
+	CreatedAt  string  `json:"createdAt"`
+	ClosedAt   string  `json:"closedAt,omitempty"`
+	FillPrice  float64 `json:"fillPrice,omitempty"`
+	Commission float64 `json:"commission,omitempty"`
+	Reason     string  `json:"reason,omitempty"`
+}
+
+// Normalize validates the request and fills in the default source and order type.
+// Stock symbols are upper-cased and coin IDs lower-cased.
+func (r *OrderRequest) Normalize() error {
+	r.Symbol = strings.TrimSpace(r.Symbol)
+	if r.Source == "" {
+		r.Source = SourceStock
+	}
+	if r.Type == "" {
+		r.Type = Market
+	}
+	switch r.Source {
+	case SourceStock:
+		r.Symbol = strings.ToUpper(r.Symbol)
+	case SourceCrypto:
+		r.Symbol = strings.ToLower(r.Symbol)
+	default:
+		return fmt.Errorf("%w: source must be stock or crypto", ErrInvalidOrder)
+	}
+
+	switch {
+	case r.Symbol == "":
+		return fmt.Errorf("%w: symbol is required", ErrInvalidOrder)
+	case r.Side != Buy && r.Side != Sell:
+		return fmt.Errorf("%w: side must be buy or sell", ErrInvalidOrder)
+	case r.Type != Market && r.Type != Limit && r.Type != Stop:
+		return fmt.Errorf("%w: type must be market, limit or stop", ErrInvalidOrder)
+	case r.Quantity <= 0:
+		return fmt.Errorf("%w: quantity must be positive", ErrInvalidOrder)
+	case r.Type == Limit && r.LimitPrice <= 0:
+		return fmt.Errorf("%w: limit orders need a positive limit price", ErrInvalidOrder)
+	case r.Type == Stop && r.StopPrice <= 0:
+		return fmt.Errorf("%w: stop orders need a positive stop price", ErrInvalidOrder)
+	}
+	if r.Type != Limit {
+		r.LimitPrice = 0
+	}
+	if r.Type != Stop {
+		r.StopPrice = 0
+	}
+	return nil
+}
+
+// PlaceOrder adds an order at the current quoted price. Market orders and limit or stop orders
+// that are already triggered fill immediately; others join the book of pending orders.
+// Buys may not exceed the cash left after pending buys, and sells may not exceed the shares
+// left after pending sells.
+func (a *Account) PlaceOrder(req OrderRequest, price float64, now time.Time) (*Order, error) {
+	if err := req.Normalize(); err != nil {
+		return nil, err
+	}
+	if price <= 0 {
+		return nil, fmt.Errorf("%w: no price for %s", ErrInvalidOrder, req.Symbol)
+	}
+	if position := a.findPosition(req.Symbol); position != nil && position.Source != req.Source {
+		return nil, fmt.Errorf("%w: %s is held as a %s position", ErrInvalidOrder, req.Symbol, position.Source)
+	}
+
+	order := Order{
+		ID:         a.NextOrderID,
+		Symbol:     req.Symbol,
+		Source:     req.Source,
+		Side:       req.Side,
+		Type:       req.Type,
+		Quantity:   req.Quantity,
+		LimitPrice: req.LimitPrice,
+		StopPrice:  req.StopPrice,
+		Status:     StatusPending,
+		CreatedAt:  now.Format(time.RFC3339),
+	}
+
+	if order.Side == Buy {
+		estimate := order.Quantity * a.executionPrice(order, math.Max(price, order.LimitPrice+order.StopPrice))
+		if estimate+a.commission(estimate) > a.buyingPower()+0.005 {
+			return nil, fmt.Errorf("%w: %s costs about %.2f with %.2f available",
+				ErrInsufficientFunds, order.Symbol, estimate+a.commission(estimate), a.buyingPower())
+		}
+	} else if available := a.availableShares(order.Symbol); order.Quantity > available+quantityTolerance {
+		return nil, fmt.Errorf("%w: %g %s available to sell", ErrInsufficientShares, available, order.Symbol)
+	}
+
+	a.NextOrderID++
+	a.Orders = append(a.Orders, order)
+	placed := &a.Orders[len(a.Orders)-1]
+	if triggered(*placed, price) {
+		a.fill(placed, price, now)
+	}
+	return placed, nil
+}
+
+// ProcessQuotes evaluates pending orders, oldest first, against the latest prices keyed by
+// symbol and fills those that are triggered. Fills that can no longer be afforded are rejected.
+func (a *Account) ProcessQuotes(prices map[string]float64, now time.Time) []Trade {
+	trades := []Trade{}
+	for i := range a.Orders {
+		order := &a.Orders[i]
+		price, ok := prices[order.Symbol]
+		if order.Status != StatusPending || !ok || price <= 0 || !triggered(*order, price) {
+			continue
+		}
+		if trade := a.fill(order, price, now); trade != nil {
+			trades = append(trades, *trade)
+		}
+	}
+	return trades
+}
+
+// CancelOrder cancels a pending order
+func (a *Account) CancelOrder(id int64, now time.Time) (*Order, error) {
+	for i := range a.Orders {
+		order := &a.Orders[i]
+		if order.ID != id {
+			continue
+		}
+		if order.Status != StatusPending {
+			return nil, fmt.Errorf("%w: order %d is %s", ErrInvalidOrder, id, order.Status)
+		}
+		order.Status = StatusCancelled
+		order.ClosedAt = now.Format(time.RFC3339)
+		return order, nil
+	}
+	return nil, fmt.Errorf("%w: %d", ErrOrderNotFound, id)
+}
+
+// PendingSymbols returns the source of each symbol with pending orders
+func (a *Account) PendingSymbols() map[string]string {
+	symbols := make(map[string]string)
+	for _, order := range a.Orders {
+		if order.Status == StatusPending {
+			symbols[order.Symbol] = order.Source
+		}
+	}
+	return symbols
+}
+
+// triggered reports whether an order executes at the price. Buy limits fill at or below the limit
+// and sell limits at or above it; buy stops trigger at or above the stop and sell stops at or below it.
+func triggered(order Order, price float64) bool {
+	switch {
+	case order.Type == Limit && order.Side == Buy:
+		return price <= order.LimitPrice
+	case order.Type == Limit:
+		return price >= order.LimitPrice
+	case order.Type == Stop && order.Side == Buy:
+		return price >= order.StopPrice
+	case order.Type == Stop:
+		return price <= order.StopPrice
+	}
+	return true
+}
+
+// fill executes an order at the quoted price, updating cash and positions, or rejects it
+// when the account can no longer cover it
+func (a *Account) fill(order *Order, price float64, now time.Time) *Trade {
+	executed := a.executionPrice(*order, price)
+	value := order.Quantity * executed
+	commission := roundTo(a.commission(value), 2)
+	order.ClosedAt = now.Format(time.RFC3339)
+
+	trade := Trade{
+		ID:         a.NextTradeID,
+		OrderID:    order.ID,
+		Symbol:     order.Symbol,
+		Source:     order.Source,
+		Side:       order.Side,
+		Quantity:   order.Quantity,
+		Price:      executed,
+		Commission: commission,
+		ExecutedAt: order.ClosedAt,
+	}
+
+	position := a.findPosition(order.Symbol)
+	if order.Side == Buy {
+		cost := roundTo(value+commission, 2)
+		if cost > a.Cash+0.005 {
+			order.Status, order.Reason = StatusRejected, ErrInsufficientFunds.Error()
+			return nil
+		}
+		if position == nil {
+			a.Positions = append(a.Positions, Position{Symbol: order.Symbol, Source: order.Source})
+			position = &a.Positions[len(a.Positions)-1]
+		}
+		position.Quantity += order.Quantity
+		position.CostBasis = roundTo(position.CostBasis+cost, 2)
+		a.Cash = roundTo(a.Cash-cost, 2)
+		trade.Amount = -cost
+	} else {
+		if position == nil || order.Quantity > position.Quantity+quantityTolerance {
+			order.Status, order.Reason = StatusRejected, ErrInsufficientShares.Error()
+			return nil
+		}
+		proceeds := roundTo(value-commission, 2)
+		cost := roundTo(position.CostBasis*order.Quantity/position.Quantity, 2)
+		position.Quantity -= order.Quantity
+		position.CostBasis = roundTo(position.CostBasis-cost, 2)
+		a.Cash = roundTo(a.Cash+proceeds, 2)
+		trade.Amount = proceeds
+		trade.RealizedGain = roundTo(proceeds-cost, 2)
+		a.removeEmptyPositions()
+	}
+
+	order.Status = StatusFilled
+	order.FillPrice = executed
+	order.Commission = commission
+	a.NextTradeID++
+	a.Trades = append(a.Trades, trade)
+	return &trade
+}
+
+// executionPrice applies slippage against the trader, never past a limit order's limit price
+func (a *Account) executionPrice(order Order, price float64) float64 {
+	if order.Side == Buy {
+		price *= 1 + a.Settings.Slippage
+		if order.Type == Limit {
+			price = math.Min(price, order.LimitPrice)
+		}
+	} else {
+		price *= 1 - a.Settings.Slippage
+		if order.Type == Limit {
+			price = math.Max(price, order.LimitPrice)
+		}
+	}
+	return roundTo(price, 6)
+}
+
+// commission returns the commission on a fill of the given value
+func (a *Account) commission(value float64) float64 {
+	return a.Settings.Commission + a.Settings.CommissionRate*value
+}
+
+// buyingPower returns cash less the estimated cost of pending buys
+func (a *Account) buyingPower() float64 {
+	power := a.Cash
+	for _, order := range a.Orders {
+		if order.Status == StatusPending && order.Side == Buy {
+			estimate := order.Quantity * a.executionPrice(order, order.LimitPrice+order.StopPrice)
+			power -= estimate + a.commission(estimate)
+		}
+	}
+	return power
+}
+
+// availableShares returns the shares held less those committed to pending sells
+func (a *Account) availableShares(symbol string) float64 {
+	available := 0.0
+	if position := a.findPosition(symbol); position != nil {
+		available = position.Quantity
+	}
+	for _, order := range a.Orders {
+		if order.Status == StatusPending && order.Side == Sell && order.Symbol == symbol {
+			available -= order.Quantity
+		}
+	}
+	return available
+}
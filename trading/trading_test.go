@@ -0,0 +1,167 @@
+package trading
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testNow = time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC)
+
+func newTestAccount(t *testing.T, settings Settings) *Account {
+	t.Helper()
+	account, err := NewAccount("1", "Practice", 10000, settings, testNow)
+	assert.NoError(t, err)
+	return account
+}
+
+func TestNewAccount(t *testing.T) {
+	account := newTestAccount(t, Settings{})
+	assert.Equal(t, 10000.0, account.Cash)
+	assert.Equal(t, int64(1), account.NextOrderID)
+
+	tests := []struct {
+		name     string
+		cash     float64
+		settings Settings
+	}{
+		{"", 1000, Settings{}},
+		{"Practice", 0, Settings{}},
+		{"Practice", 1000, Settings{Commission: -1}},
+		{"Practice", 1000, Settings{Slippage: 1}},
+	}
+	for _, tt := range tests {
+		_, err := NewAccount("1", tt.name, tt.cash, tt.settings, testNow)
+		assert.ErrorIs(t, err, ErrInvalidOrder)
+	}
+}
+
+func TestMarketOrders(t *testing.T) {
+	account := newTestAccount(t, Settings{Commission: 1, CommissionRate: 0.001, Slippage: 0.01})
+
+	order, err := account.PlaceOrder(OrderRequest{Symbol: "aapl", Side: Buy, Quantity: 10}, 100, testNow)
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", order.Symbol)
+	assert.Equal(t, StatusFilled, order.Status)
+	// Buys pay 1% slippage, then a $1 fee plus 0.1% of the $1,010 traded
+	assert.Equal(t, 101.0, order.FillPrice)
+	assert.Equal(t, 2.01, order.Commission)
+	assert.Equal(t, 8987.99, account.Cash)
+	assert.Equal(t, []Position{{Symbol: "AAPL", Source: SourceStock, Quantity: 10, CostBasis: 1012.01}}, account.Positions)
+
+	order, err = account.PlaceOrder(OrderRequest{Symbol: "AAPL", Side: Sell, Quantity: 10}, 120, testNow)
+	assert.NoError(t, err)
+	assert.Equal(t, 118.8, order.FillPrice)
+	assert.Empty(t, account.Positions)
+	assert.Len(t, account.Trades, 2)
+	// Proceeds of $1,188 less a $2.19 commission, against $1,012.01 of cost
+	assert.Equal(t, 1185.81, account.Trades[1].Amount)
+	assert.Equal(t, 173.8, account.Trades[1].RealizedGain)
+	assert.Equal(t, 10173.8, account.Cash)
+
+	_, err = account.PlaceOrder(OrderRequest{Symbol: "AAPL", Side: Sell, Quantity: 1}, 120, testNow)
+	assert.ErrorIs(t, err, ErrInsufficientShares)
+	_, err = account.PlaceOrder(OrderRequest{Symbol: "AAPL", Side: Buy, Quantity: 1000}, 120, testNow)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	_, err = account.PlaceOrder(OrderRequest{Symbol: "AAPL", Side: Buy, Quantity: 1}, 0, testNow)
+	assert.ErrorIs(t, err, ErrInvalidOrder)
+}
+
+func TestPendingOrders(t *testing.T) {
+	account := newTestAccount(t, Settings{})
+
+	limit, err := account.PlaceOrder(OrderRequest{Symbol: "bitcoin", Source: SourceCrypto, Side: Buy, Type: Limit, Quantity: 0.1, LimitPrice: 50000}, 52000, testNow)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusPending, limit.Status)
+
+	// The pending limit buy reserves $5,000 of buying power
+	_, err = account.PlaceOrder(OrderRequest{Symbol: "MSFT", Side: Buy, Quantity: 20}, 300, testNow)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+	assert.Empty(t, account.ProcessQuotes(map[string]float64{"bitcoin": 51000}, testNow))
+	trades := account.ProcessQuotes(map[string]float64{"bitcoin": 49000}, testNow)
+	assert.Len(t, trades, 1)
+	assert.Equal(t, 49000.0, trades[0].Price)
+	assert.Equal(t, -4900.0, trades[0].Amount)
+	assert.Equal(t, StatusFilled, account.Orders[0].Status)
+
+	stop, err := account.PlaceOrder(OrderRequest{Symbol: "BITCOIN", Source: SourceCrypto, Side: Sell, Type: Stop, Quantity: 0.1, StopPrice: 45000}, 49000, testNow)
+	assert.NoError(t, err)
+	assert.Equal(t, "bitcoin", stop.Symbol)
+	assert.Equal(t, map[string]string{"bitcoin": SourceCrypto}, account.PendingSymbols())
+
+	// Shares committed to the stop cannot be sold again
+	_, err = account.PlaceOrder(OrderRequest{Symbol: "bitcoin", Source: SourceCrypto, Side: Sell, Quantity: 0.05}, 49000, testNow)
+	assert.ErrorIs(t, err, ErrInsufficientShares)
+
+	trades = account.ProcessQuotes(map[string]float64{"bitcoin": 44000}, testNow)
+	assert.Len(t, trades, 1)
+	assert.Equal(t, -500.0, trades[0].RealizedGain)
+	assert.Equal(t, 9500.0, account.Cash)
+	assert.Empty(t, account.PendingSymbols())
+}
+
+func TestTriggered(t *testing.T) {
+	tests := []struct {
+		order Order
+		price float64
+		want  bool
+	}{
+		{Order{Type: Market, Side: Buy}, 100, true},
+		{Order{Type: Limit, Side: Buy, LimitPrice: 100}, 100, true},
+		{Order{Type: Limit, Side: Buy, LimitPrice: 100}, 101, false},
+		{Order{Type: Limit, Side: Sell, LimitPrice: 100}, 99, false},
+		{Order{Type: Limit, Side: Sell, LimitPrice: 100}, 101, true},
+		{Order{Type: Stop, Side: Buy, StopPrice: 100}, 99, false},
+		{Order{Type: Stop, Side: Buy, StopPrice: 100}, 100, true},
+		{Order{Type: Stop, Side: Sell, StopPrice: 100}, 99, true},
+		{Order{Type: Stop, Side: Sell, StopPrice: 100}, 101, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, triggered(tt.order, tt.price), "%s %s at %g", tt.order.Type, tt.order.Side, tt.price)
+	}
+}
+
+func TestLimitPriceCapsSlippage(t *testing.T) {
+	account := newTestAccount(t, Settings{Slippage: 0.01})
+
+	order, err := account.PlaceOrder(OrderRequest{Symbol: "AAPL", Side: Buy, Type: Limit, Quantity: 10, LimitPrice: 100}, 99.5, testNow)
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, order.FillPrice)
+}
+
+func TestCancelOrder(t *testing.T) {
+	account := newTestAccount(t, Settings{})
+	order, err := account.PlaceOrder(OrderRequest{Symbol: "AAPL", Side: Buy, Type: Limit, Quantity: 10, LimitPrice: 90}, 100, testNow)
+	assert.NoError(t, err)
+
+	cancelled, err := account.CancelOrder(order.ID, testNow)
+	assert.NoError(t, err)
+	assert.Equal(t, StatusCancelled, cancelled.Status)
+	assert.Empty(t, account.ProcessQuotes(map[string]float64{"AAPL": 80}, testNow))
+
+	_, err = account.CancelOrder(order.ID, testNow)
+	assert.ErrorIs(t, err, ErrInvalidOrder)
+	_, err = account.CancelOrder(99, testNow)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+func TestValue(t *testing.T) {
+	account := newTestAccount(t, Settings{Commission: 5})
+	_, err := account.PlaceOrder(OrderRequest{Symbol: "AAPL", Side: Buy, Quantity: 10}, 100, testNow)
+	assert.NoError(t, err)
+	_, err = account.PlaceOrder(OrderRequest{Symbol: "MSFT", Side: Buy, Quantity: 5}, 200, testNow)
+	assert.NoError(t, err)
+
+	// MSFT has no price and is valued at cost
+	valuation := account.Value(map[string]float64{"AAPL": 110})
+	assert.Equal(t, 7990.0, valuation.Cash)
+	assert.Equal(t, 2105.0, valuation.MarketValue)
+	assert.Equal(t, 10095.0, valuation.Equity)
+	assert.Equal(t, 95.0, valuation.UnrealizedGain)
+	assert.Equal(t, 10.0, valuation.Commissions)
+	assert.Equal(t, 0.0095, valuation.TotalReturn)
+	assert.Len(t, valuation.Holdings, 2)
+	assert.Equal(t, "AAPL", valuation.Holdings[0].Symbol)
+}
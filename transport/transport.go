@@ -0,0 +1,231 @@
+// Package transport provides the HTTP transport used for market data providers. Idempotent
+// requests are retried with jittered exponential backoff on server errors and timeouts, rate
+// limited requests wait as long as the provider's Retry-After asks, and each provider has a
+// circuit breaker that stops requests while it keeps failing.
+package transport
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a request is sent, including the first
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, doubled for each retry after it
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between retries
+	MaxDelay time.Duration
+	// MaxRetryAfter is the longest Retry-After that is waited for; a rate limited response
+	// asking for longer is returned as is
+	MaxRetryAfter time.Duration
+	// AttemptTimeout limits each attempt, including reading the response body
+	AttemptTimeout time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used for market data providers
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		BaseDelay:      500 * time.Millisecond,
+		MaxDelay:       5 * time.Second,
+		MaxRetryAfter:  30 * time.Second,
+		AttemptTimeout: 10 * time.Second,
+	}
+}
+
+// Transport is an http.RoundTripper that retries requests and reports their outcome to a
+// circuit breaker
+type Transport struct {
+	// Base sends the requests, http.DefaultTransport when nil
+	Base    http.RoundTripper
+	Retry   RetryPolicy
+	Breaker *Breaker
+}
+
+// New creates a transport with the default retry policy and the provider's shared breaker
+func New(provider string) *Transport {
+	return &Transport{
+		Retry:   DefaultRetryPolicy(),
+		Breaker: BreakerFor(provider),
+	}
+}
+
+// NewClient creates an HTTP client for the provider. It has no overall timeout; each attempt
+// is limited by the retry policy and the whole request by its context.
+func NewClient(provider string) *http.Client {
+	return &http.Client{Transport: New(provider)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Breaker != nil {
+		if err := t.Breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.roundTrip(req)
+
+	if t.Breaker != nil {
+		switch {
+		case err != nil && req.Context().Err() != nil:
+			t.Breaker.Release()
+		case err != nil:
+			t.Breaker.Failure(err)
+		case resp.StatusCode >= http.StatusInternalServerError:
+			t.Breaker.Failure(errors.New(resp.Status))
+		default:
+			t.Breaker.Success()
+		}
+	}
+	return resp, err
+}
+
+func (t *Transport) roundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attempts := t.Retry.MaxAttempts
+	if attempts < 1 || !idempotent(req) {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.send(req)
+		if attempt >= attempts || ctx.Err() != nil {
+			return resp, err
+		}
+		delay, retry := t.retryDelay(attempt, resp, err)
+		if !retry {
+			return resp, err
+		}
+
+		reason := "timeout"
+		if resp != nil {
+			reason = resp.Status
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		slog.DebugContext(ctx, "Retrying request", "host", req.URL.Host, "attempt", attempt,
+			"reason", reason, "delay", delay.String())
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes a single attempt, limited by the attempt timeout until the body is closed
+func (t *Transport) send(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if t.Retry.AttemptTimeout <= 0 {
+		return base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.Retry.AttemptTimeout)
+	resp, err := base.RoundTrip(req.Clone(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// retryDelay reports whether a failed attempt is retried and how long to wait first
+func (t *Transport) retryDelay(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	switch {
+	case err != nil:
+		return t.backoff(attempt), isTimeout(err)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		delay, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			return t.backoff(attempt), true
+		}
+		return delay, delay <= t.Retry.MaxRetryAfter
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return t.backoff(attempt), true
+	}
+	return 0, false
+}
+
+// backoff returns the exponential delay before the given retry with equal jitter: between
+// half and all of BaseDelay doubled per attempt, capped at MaxDelay
+func (t *Transport) backoff(attempt int) time.Duration {
+	delay := t.Retry.BaseDelay
+	for i := 1; i < attempt && delay < t.Retry.MaxDelay; i++ {
+		delay *= 2
+	}
+	if t.Retry.MaxDelay > 0 && delay > t.Retry.MaxDelay {
+		delay = t.Retry.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// idempotent reports whether a request can be sent again safely
+func idempotent(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead && req.Method != "" {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody
+}
+
+// isTimeout reports whether an attempt failed by timing out, as opposed to being cancelled
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// cancelBody releases an attempt's context when its response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
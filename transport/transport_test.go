@@ -0,0 +1,224 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client with short delays and a breaker of its own
+func newTestClient() (*http.Client, *Transport) {
+	t := &Transport{
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			BaseDelay:      time.Millisecond,
+			MaxDelay:       5 * time.Millisecond,
+			MaxRetryAfter:  2 * time.Second,
+			AttemptTimeout: time.Second,
+		},
+		Breaker: NewBreaker("test"),
+	}
+	return &http.Client{Transport: t}, t
+}
+
+func TestRetryServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	client, transport := newTestClient()
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, StateClosed, transport.Breaker.State().State)
+
+	// A request failing every attempt returns the last response and counts one failure
+	calls.Store(-10)
+	resp, err = client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(-7), calls.Load())
+	assert.Equal(t, 1, transport.Breaker.State().Failures)
+	assert.Equal(t, "502 Bad Gateway", transport.Breaker.State().LastError)
+}
+
+func TestRetryTimeout(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	client, transport := newTestClient()
+	transport.Retry.AttemptTimeout = 50 * time.Millisecond
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var retried time.Time
+	started := time.Now()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		retried = time.Now()
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	client, transport := newTestClient()
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, retried.Sub(started), time.Second)
+
+	// A Retry-After longer than the policy allows is returned without waiting
+	transport.Retry.MaxRetryAfter = 0
+	calls.Store(0)
+	resp, err = client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, 0, transport.Breaker.State().Failures)
+}
+
+func TestNoRetryNonIdempotent(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client, _ := newTestClient()
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client, transport := newTestClient()
+	transport.Retry.BaseDelay = time.Minute
+	transport.Retry.MaxDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	started := time.Now()
+	_, err := client.Do(req)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(started), time.Second)
+	assert.Equal(t, 0, transport.Breaker.State().Failures)
+}
+
+func TestBreaker(t *testing.T) {
+	b := NewBreaker("test")
+	b.Threshold = 2
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, b.Allow())
+		b.Failure(errors.New("503 Service Unavailable"))
+	}
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+	assert.Equal(t, BreakerState{
+		Provider:  "test",
+		State:     StateOpen,
+		Failures:  2,
+		LastError: "503 Service Unavailable",
+		OpenedAt:  "2024-01-02T15:04:05Z",
+		RetryAt:   "2024-01-02T15:04:35Z",
+	}, b.State())
+
+	// After the cooldown a single probe is let through and its failure reopens the breaker
+	now = now.Add(DefaultCooldown)
+	assert.NoError(t, b.Allow())
+	assert.Equal(t, StateHalfOpen, b.State().State)
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+	b.Failure(errors.New("timeout"))
+	assert.Equal(t, StateOpen, b.State().State)
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	// A released probe lets the next request probe, a successful one closes the breaker
+	now = now.Add(DefaultCooldown)
+	assert.NoError(t, b.Allow())
+	b.Release()
+	assert.NoError(t, b.Allow())
+	b.Success()
+	assert.Equal(t, BreakerState{Provider: "test", State: StateClosed}, b.State())
+}
+
+func TestBreakerRejectsRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	client, transport := newTestClient()
+	transport.Retry.MaxAttempts = 1
+	transport.Breaker.Threshold = 1
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	_, err = client.Get(server.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestBreakerFor(t *testing.T) {
+	b := BreakerFor("transport-test")
+	assert.Same(t, b, BreakerFor("transport-test"))
+	assert.Contains(t, States(), BreakerState{Provider: "transport-test", State: StateClosed})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC)
+
+	delay, ok := retryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = retryAfter("Wed, 21 Oct 2015 07:28:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	_, ok = retryAfter("soon", now)
+	assert.False(t, ok)
+}